@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"io"
 	"log"
 	"net"
 
@@ -51,6 +52,147 @@ func (s *apiGatewayServer) CreateMatch(ctx context.Context, req *apipb.CreateMat
 	}, nil
 }
 
+func (s *apiGatewayServer) GetAdminMatchList(ctx context.Context, req *apipb.AdminMatchListRequest) (*apipb.AdminMatchListResponse, error) {
+	res, err := s.matchClient.GetAdminMatchList(ctx, &matchpb.AdminMatchListRequest{
+		Status:      req.Status,
+		Team:        req.Team,
+		Competition: req.Competition,
+		StartFrom:   req.StartFrom,
+		StartTo:     req.StartTo,
+		SortBy:      req.SortBy,
+		Descending:  req.Descending,
+		PageSize:    req.PageSize,
+		PageToken:   req.PageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &apipb.AdminMatchListResponse{
+		TotalCount:    res.TotalCount,
+		NextPageToken: res.NextPageToken,
+	}
+	for _, m := range res.Matches {
+		resp.Matches = append(resp.Matches, &apipb.AdminMatchSummary{
+			MatchId:          m.MatchId,
+			HomeTeam:         m.HomeTeam,
+			AwayTeam:         m.AwayTeam,
+			StartTime:        m.StartTime,
+			Competition:      m.Competition,
+			Status:           m.Status,
+			HomeScore:        m.HomeScore,
+			AwayScore:        m.AwayScore,
+			OverriddenFields: m.OverriddenFields,
+			PeakViewers:      m.PeakViewers,
+		})
+	}
+	return resp, nil
+}
+
+func (s *apiGatewayServer) GetMostWatchedMatches(ctx context.Context, req *apipb.MostWatchedRequest) (*apipb.MostWatchedResponse, error) {
+	res, err := s.matchClient.GetMostWatchedMatches(ctx, &matchpb.MostWatchedRequest{Limit: req.Limit})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &apipb.MostWatchedResponse{}
+	for _, w := range res.Matches {
+		m := w.Match
+		resp.Matches = append(resp.Matches, &apipb.AdminMatchSummary{
+			MatchId:          m.MatchId,
+			HomeTeam:         m.HomeTeam,
+			AwayTeam:         m.AwayTeam,
+			StartTime:        m.StartTime,
+			Competition:      m.Competition,
+			Status:           m.Status,
+			HomeScore:        m.HomeScore,
+			AwayScore:        m.AwayScore,
+			OverriddenFields: m.OverriddenFields,
+			Viewers:          w.Viewers,
+			PeakViewers:      w.PeakViewers,
+		})
+	}
+	return resp, nil
+}
+
+func (s *apiGatewayServer) GetMatchTimeline(ctx context.Context, req *apipb.MatchTimelineRequest) (*apipb.MatchTimelineResponse, error) {
+	res, err := s.matchClient.GetMatchTimeline(ctx, &matchpb.TimelineRequest{
+		MatchId:   req.MatchId,
+		Since:     req.Since,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &apipb.MatchTimelineResponse{
+		NextPageToken: res.NextPageToken,
+		LatestSeq:     res.LatestSeq,
+	}
+	for _, e := range res.Events {
+		resp.Events = append(resp.Events, &apipb.MatchTimelineEvent{
+			EventId:         e.EventId,
+			Seq:             e.Seq,
+			EventType:       e.EventType,
+			Description:     e.Description,
+			Timestamp:       e.Timestamp,
+			TeamSide:        e.TeamSide,
+			PlayerId:        e.PlayerId,
+			RelatedPlayerId: e.RelatedPlayerId,
+			Minute:          e.Minute,
+			AddedTime:       e.AddedTime,
+			Detail:          e.Detail,
+			Revision:        e.Revision,
+		})
+	}
+	return resp, nil
+}
+
+func (s *apiGatewayServer) StreamMatchUpdates(req *apipb.MatchUpdatesRequest, stream apipb.ApiGatewayService_StreamMatchUpdatesServer) error {
+	upstream, err := s.matchClient.StreamMatchUpdates(stream.Context(), &matchpb.MatchRequest{MatchId: req.MatchId})
+	if err != nil {
+		return err
+	}
+	return proxyMatchUpdates(upstream, stream.Send)
+}
+
+func (s *apiGatewayServer) StreamMultiMatchUpdates(req *apipb.MultiMatchUpdatesRequest, stream apipb.ApiGatewayService_StreamMultiMatchUpdatesServer) error {
+	upstream, err := s.matchClient.StreamMultiMatchUpdates(stream.Context(), &matchpb.MultiMatchRequest{MatchIds: req.MatchIds})
+	if err != nil {
+		return err
+	}
+	return proxyMatchUpdates(upstream, stream.Send)
+}
+
+// proxyMatchUpdates forwards a match update stream until either side ends it. Backpressure
+// is left to the match service, which coalesces updates for clients that fall behind.
+func proxyMatchUpdates(upstream grpc.ServerStreamingClient[matchpb.MatchResponse], send func(*apipb.MatchUpdate) error) error {
+	for {
+		m, err := upstream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := send(&apipb.MatchUpdate{
+			MatchId:          m.MatchId,
+			Status:           m.Status,
+			HomeScore:        m.HomeScore,
+			AwayScore:        m.AwayScore,
+			LastEvent:        m.LastEvent,
+			Possession:       m.Possession,
+			Shots:            m.Shots,
+			Fouls:            m.Fouls,
+			Cards:            m.Cards,
+			OverriddenFields: m.OverriddenFields,
+		}); err != nil {
+			return err
+		}
+	}
+}
+
 func main() {
 	lis, err := net.Listen("tcp", ":5000")
 	if err != nil {
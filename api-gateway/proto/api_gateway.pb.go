@@ -253,6 +253,850 @@ func (x *CreateMatchResponse) GetStatus() string {
 	return ""
 }
 
+type AdminMatchListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Team          string                 `protobuf:"bytes,2,opt,name=team,proto3" json:"team,omitempty"`
+	Competition   string                 `protobuf:"bytes,3,opt,name=competition,proto3" json:"competition,omitempty"`
+	StartFrom     string                 `protobuf:"bytes,4,opt,name=start_from,json=startFrom,proto3" json:"start_from,omitempty"` // RFC 3339, inclusive
+	StartTo       string                 `protobuf:"bytes,5,opt,name=start_to,json=startTo,proto3" json:"start_to,omitempty"`       // RFC 3339, exclusive
+	SortBy        string                 `protobuf:"bytes,6,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`          // "start_time" (default), "status", "home_team", "competition" or "match_id"
+	Descending    bool                   `protobuf:"varint,7,opt,name=descending,proto3" json:"descending,omitempty"`
+	PageSize      int32                  `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminMatchListRequest) Reset() {
+	*x = AdminMatchListRequest{}
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminMatchListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminMatchListRequest) ProtoMessage() {}
+
+func (x *AdminMatchListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminMatchListRequest.ProtoReflect.Descriptor instead.
+func (*AdminMatchListRequest) Descriptor() ([]byte, []int) {
+	return file_api_gateway_proto_api_gateway_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AdminMatchListRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AdminMatchListRequest) GetTeam() string {
+	if x != nil {
+		return x.Team
+	}
+	return ""
+}
+
+func (x *AdminMatchListRequest) GetCompetition() string {
+	if x != nil {
+		return x.Competition
+	}
+	return ""
+}
+
+func (x *AdminMatchListRequest) GetStartFrom() string {
+	if x != nil {
+		return x.StartFrom
+	}
+	return ""
+}
+
+func (x *AdminMatchListRequest) GetStartTo() string {
+	if x != nil {
+		return x.StartTo
+	}
+	return ""
+}
+
+func (x *AdminMatchListRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *AdminMatchListRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *AdminMatchListRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *AdminMatchListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type AdminMatchSummary struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MatchId          string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	HomeTeam         string                 `protobuf:"bytes,2,opt,name=home_team,json=homeTeam,proto3" json:"home_team,omitempty"`
+	AwayTeam         string                 `protobuf:"bytes,3,opt,name=away_team,json=awayTeam,proto3" json:"away_team,omitempty"`
+	StartTime        string                 `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	Competition      string                 `protobuf:"bytes,5,opt,name=competition,proto3" json:"competition,omitempty"`
+	Status           string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	HomeScore        int32                  `protobuf:"varint,7,opt,name=home_score,json=homeScore,proto3" json:"home_score,omitempty"`
+	AwayScore        int32                  `protobuf:"varint,8,opt,name=away_score,json=awayScore,proto3" json:"away_score,omitempty"`
+	OverriddenFields []string               `protobuf:"bytes,9,rep,name=overridden_fields,json=overriddenFields,proto3" json:"overridden_fields,omitempty"`
+	Viewers          int64                  `protobuf:"varint,10,opt,name=viewers,proto3" json:"viewers,omitempty"`                            // Watching now, only set by GetMostWatchedMatches
+	PeakViewers      int64                  `protobuf:"varint,11,opt,name=peak_viewers,json=peakViewers,proto3" json:"peak_viewers,omitempty"` // Most at once: so far for GetMostWatchedMatches, recorded after full time otherwise
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AdminMatchSummary) Reset() {
+	*x = AdminMatchSummary{}
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminMatchSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminMatchSummary) ProtoMessage() {}
+
+func (x *AdminMatchSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminMatchSummary.ProtoReflect.Descriptor instead.
+func (*AdminMatchSummary) Descriptor() ([]byte, []int) {
+	return file_api_gateway_proto_api_gateway_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AdminMatchSummary) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *AdminMatchSummary) GetHomeTeam() string {
+	if x != nil {
+		return x.HomeTeam
+	}
+	return ""
+}
+
+func (x *AdminMatchSummary) GetAwayTeam() string {
+	if x != nil {
+		return x.AwayTeam
+	}
+	return ""
+}
+
+func (x *AdminMatchSummary) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *AdminMatchSummary) GetCompetition() string {
+	if x != nil {
+		return x.Competition
+	}
+	return ""
+}
+
+func (x *AdminMatchSummary) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AdminMatchSummary) GetHomeScore() int32 {
+	if x != nil {
+		return x.HomeScore
+	}
+	return 0
+}
+
+func (x *AdminMatchSummary) GetAwayScore() int32 {
+	if x != nil {
+		return x.AwayScore
+	}
+	return 0
+}
+
+func (x *AdminMatchSummary) GetOverriddenFields() []string {
+	if x != nil {
+		return x.OverriddenFields
+	}
+	return nil
+}
+
+func (x *AdminMatchSummary) GetViewers() int64 {
+	if x != nil {
+		return x.Viewers
+	}
+	return 0
+}
+
+func (x *AdminMatchSummary) GetPeakViewers() int64 {
+	if x != nil {
+		return x.PeakViewers
+	}
+	return 0
+}
+
+type AdminMatchListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*AdminMatchSummary   `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminMatchListResponse) Reset() {
+	*x = AdminMatchListResponse{}
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminMatchListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminMatchListResponse) ProtoMessage() {}
+
+func (x *AdminMatchListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminMatchListResponse.ProtoReflect.Descriptor instead.
+func (*AdminMatchListResponse) Descriptor() ([]byte, []int) {
+	return file_api_gateway_proto_api_gateway_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AdminMatchListResponse) GetMatches() []*AdminMatchSummary {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *AdminMatchListResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *AdminMatchListResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type MostWatchedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"` // Defaults to 10, at most 50
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MostWatchedRequest) Reset() {
+	*x = MostWatchedRequest{}
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MostWatchedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MostWatchedRequest) ProtoMessage() {}
+
+func (x *MostWatchedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MostWatchedRequest.ProtoReflect.Descriptor instead.
+func (*MostWatchedRequest) Descriptor() ([]byte, []int) {
+	return file_api_gateway_proto_api_gateway_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MostWatchedRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type MostWatchedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*AdminMatchSummary   `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"` // Most viewers first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MostWatchedResponse) Reset() {
+	*x = MostWatchedResponse{}
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MostWatchedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MostWatchedResponse) ProtoMessage() {}
+
+func (x *MostWatchedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MostWatchedResponse.ProtoReflect.Descriptor instead.
+func (*MostWatchedResponse) Descriptor() ([]byte, []int) {
+	return file_api_gateway_proto_api_gateway_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MostWatchedResponse) GetMatches() []*AdminMatchSummary {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+type MatchTimelineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Since         int64                  `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`                       // Only events after this seq, e.g. latest_seq of an earlier response
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // Defaults to 100, at most 500
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchTimelineRequest) Reset() {
+	*x = MatchTimelineRequest{}
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchTimelineRequest) ProtoMessage() {}
+
+func (x *MatchTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchTimelineRequest.ProtoReflect.Descriptor instead.
+func (*MatchTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_api_gateway_proto_api_gateway_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MatchTimelineRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *MatchTimelineRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *MatchTimelineRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *MatchTimelineRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type MatchTimelineEvent struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	EventId         string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Seq             int64                  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	EventType       string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"` // "goal", "own_goal", "penalty", "card", "substitution", "var_review", "injury", ...
+	Description     string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Timestamp       string                 `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	TeamSide        string                 `protobuf:"bytes,6,opt,name=team_side,json=teamSide,proto3" json:"team_side,omitempty"` // "home" or "away"
+	PlayerId        string                 `protobuf:"bytes,7,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	RelatedPlayerId string                 `protobuf:"bytes,8,opt,name=related_player_id,json=relatedPlayerId,proto3" json:"related_player_id,omitempty"` // Assist on goals, player coming off on substitutions
+	Minute          int32                  `protobuf:"varint,9,opt,name=minute,proto3" json:"minute,omitempty"`
+	AddedTime       int32                  `protobuf:"varint,10,opt,name=added_time,json=addedTime,proto3" json:"added_time,omitempty"`
+	Detail          string                 `protobuf:"bytes,11,opt,name=detail,proto3" json:"detail,omitempty"`      // Card color, penalty outcome or VAR decision
+	Revision        int32                  `protobuf:"varint,12,opt,name=revision,proto3" json:"revision,omitempty"` // Times an admin amended the event
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MatchTimelineEvent) Reset() {
+	*x = MatchTimelineEvent{}
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchTimelineEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchTimelineEvent) ProtoMessage() {}
+
+func (x *MatchTimelineEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchTimelineEvent.ProtoReflect.Descriptor instead.
+func (*MatchTimelineEvent) Descriptor() ([]byte, []int) {
+	return file_api_gateway_proto_api_gateway_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MatchTimelineEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *MatchTimelineEvent) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *MatchTimelineEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *MatchTimelineEvent) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *MatchTimelineEvent) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *MatchTimelineEvent) GetTeamSide() string {
+	if x != nil {
+		return x.TeamSide
+	}
+	return ""
+}
+
+func (x *MatchTimelineEvent) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *MatchTimelineEvent) GetRelatedPlayerId() string {
+	if x != nil {
+		return x.RelatedPlayerId
+	}
+	return ""
+}
+
+func (x *MatchTimelineEvent) GetMinute() int32 {
+	if x != nil {
+		return x.Minute
+	}
+	return 0
+}
+
+func (x *MatchTimelineEvent) GetAddedTime() int32 {
+	if x != nil {
+		return x.AddedTime
+	}
+	return 0
+}
+
+func (x *MatchTimelineEvent) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *MatchTimelineEvent) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type MatchTimelineResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*MatchTimelineEvent  `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"` // Oldest first
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	LatestSeq     int64                  `protobuf:"varint,3,opt,name=latest_seq,json=latestSeq,proto3" json:"latest_seq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchTimelineResponse) Reset() {
+	*x = MatchTimelineResponse{}
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchTimelineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchTimelineResponse) ProtoMessage() {}
+
+func (x *MatchTimelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchTimelineResponse.ProtoReflect.Descriptor instead.
+func (*MatchTimelineResponse) Descriptor() ([]byte, []int) {
+	return file_api_gateway_proto_api_gateway_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MatchTimelineResponse) GetEvents() []*MatchTimelineEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *MatchTimelineResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *MatchTimelineResponse) GetLatestSeq() int64 {
+	if x != nil {
+		return x.LatestSeq
+	}
+	return 0
+}
+
+type MatchUpdatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchUpdatesRequest) Reset() {
+	*x = MatchUpdatesRequest{}
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchUpdatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchUpdatesRequest) ProtoMessage() {}
+
+func (x *MatchUpdatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchUpdatesRequest.ProtoReflect.Descriptor instead.
+func (*MatchUpdatesRequest) Descriptor() ([]byte, []int) {
+	return file_api_gateway_proto_api_gateway_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MatchUpdatesRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+type MultiMatchUpdatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchIds      []string               `protobuf:"bytes,1,rep,name=match_ids,json=matchIds,proto3" json:"match_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MultiMatchUpdatesRequest) Reset() {
+	*x = MultiMatchUpdatesRequest{}
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultiMatchUpdatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiMatchUpdatesRequest) ProtoMessage() {}
+
+func (x *MultiMatchUpdatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiMatchUpdatesRequest.ProtoReflect.Descriptor instead.
+func (*MultiMatchUpdatesRequest) Descriptor() ([]byte, []int) {
+	return file_api_gateway_proto_api_gateway_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MultiMatchUpdatesRequest) GetMatchIds() []string {
+	if x != nil {
+		return x.MatchIds
+	}
+	return nil
+}
+
+type MatchUpdate struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MatchId          string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Status           string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	HomeScore        int32                  `protobuf:"varint,3,opt,name=home_score,json=homeScore,proto3" json:"home_score,omitempty"`
+	AwayScore        int32                  `protobuf:"varint,4,opt,name=away_score,json=awayScore,proto3" json:"away_score,omitempty"`
+	LastEvent        string                 `protobuf:"bytes,5,opt,name=last_event,json=lastEvent,proto3" json:"last_event,omitempty"`
+	Possession       int32                  `protobuf:"varint,6,opt,name=possession,proto3" json:"possession,omitempty"`
+	Shots            int32                  `protobuf:"varint,7,opt,name=shots,proto3" json:"shots,omitempty"`
+	Fouls            int32                  `protobuf:"varint,8,opt,name=fouls,proto3" json:"fouls,omitempty"`
+	Cards            []string               `protobuf:"bytes,9,rep,name=cards,proto3" json:"cards,omitempty"`
+	OverriddenFields []string               `protobuf:"bytes,10,rep,name=overridden_fields,json=overriddenFields,proto3" json:"overridden_fields,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *MatchUpdate) Reset() {
+	*x = MatchUpdate{}
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchUpdate) ProtoMessage() {}
+
+func (x *MatchUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_gateway_proto_api_gateway_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchUpdate.ProtoReflect.Descriptor instead.
+func (*MatchUpdate) Descriptor() ([]byte, []int) {
+	return file_api_gateway_proto_api_gateway_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MatchUpdate) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *MatchUpdate) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MatchUpdate) GetHomeScore() int32 {
+	if x != nil {
+		return x.HomeScore
+	}
+	return 0
+}
+
+func (x *MatchUpdate) GetAwayScore() int32 {
+	if x != nil {
+		return x.AwayScore
+	}
+	return 0
+}
+
+func (x *MatchUpdate) GetLastEvent() string {
+	if x != nil {
+		return x.LastEvent
+	}
+	return ""
+}
+
+func (x *MatchUpdate) GetPossession() int32 {
+	if x != nil {
+		return x.Possession
+	}
+	return 0
+}
+
+func (x *MatchUpdate) GetShots() int32 {
+	if x != nil {
+		return x.Shots
+	}
+	return 0
+}
+
+func (x *MatchUpdate) GetFouls() int32 {
+	if x != nil {
+		return x.Fouls
+	}
+	return 0
+}
+
+func (x *MatchUpdate) GetCards() []string {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *MatchUpdate) GetOverriddenFields() []string {
+	if x != nil {
+		return x.OverriddenFields
+	}
+	return nil
+}
+
 var File_api_gateway_proto_api_gateway_proto protoreflect.FileDescriptor
 
 const file_api_gateway_proto_api_gateway_proto_rawDesc = "" +
@@ -272,10 +1116,102 @@ const file_api_gateway_proto_api_gateway_proto_rawDesc = "" +
 	"\taway_team\x18\x03 \x01(\tR\bawayTeam\"H\n" +
 	"\x13CreateMatchResponse\x12\x19\n" +
 	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status2\x9a\x01\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\x94\x02\n" +
+	"\x15AdminMatchListRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x12\n" +
+	"\x04team\x18\x02 \x01(\tR\x04team\x12 \n" +
+	"\vcompetition\x18\x03 \x01(\tR\vcompetition\x12\x1d\n" +
+	"\n" +
+	"start_from\x18\x04 \x01(\tR\tstartFrom\x12\x19\n" +
+	"\bstart_to\x18\x05 \x01(\tR\astartTo\x12\x17\n" +
+	"\asort_by\x18\x06 \x01(\tR\x06sortBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\a \x01(\bR\n" +
+	"descending\x12\x1b\n" +
+	"\tpage_size\x18\b \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\t \x01(\tR\tpageToken\"\xe9\x02\n" +
+	"\x11AdminMatchSummary\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x1b\n" +
+	"\thome_team\x18\x02 \x01(\tR\bhomeTeam\x12\x1b\n" +
+	"\taway_team\x18\x03 \x01(\tR\bawayTeam\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\tR\tstartTime\x12 \n" +
+	"\vcompetition\x18\x05 \x01(\tR\vcompetition\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"home_score\x18\a \x01(\x05R\thomeScore\x12\x1d\n" +
+	"\n" +
+	"away_score\x18\b \x01(\x05R\tawayScore\x12+\n" +
+	"\x11overridden_fields\x18\t \x03(\tR\x10overriddenFields\x12\x18\n" +
+	"\aviewers\x18\n" +
+	" \x01(\x03R\aviewers\x12!\n" +
+	"\fpeak_viewers\x18\v \x01(\x03R\vpeakViewers\"\x93\x01\n" +
+	"\x16AdminMatchListResponse\x120\n" +
+	"\amatches\x18\x01 \x03(\v2\x16.api.AdminMatchSummaryR\amatches\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"*\n" +
+	"\x12MostWatchedRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"G\n" +
+	"\x13MostWatchedResponse\x120\n" +
+	"\amatches\x18\x01 \x03(\v2\x16.api.AdminMatchSummaryR\amatches\"\x83\x01\n" +
+	"\x14MatchTimelineRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x14\n" +
+	"\x05since\x18\x02 \x01(\x03R\x05since\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"\xf1\x02\n" +
+	"\x12MatchTimelineEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x10\n" +
+	"\x03seq\x18\x02 \x01(\x03R\x03seq\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\tR\teventType\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\tR\ttimestamp\x12\x1b\n" +
+	"\tteam_side\x18\x06 \x01(\tR\bteamSide\x12\x1b\n" +
+	"\tplayer_id\x18\a \x01(\tR\bplayerId\x12*\n" +
+	"\x11related_player_id\x18\b \x01(\tR\x0frelatedPlayerId\x12\x16\n" +
+	"\x06minute\x18\t \x01(\x05R\x06minute\x12\x1d\n" +
+	"\n" +
+	"added_time\x18\n" +
+	" \x01(\x05R\taddedTime\x12\x16\n" +
+	"\x06detail\x18\v \x01(\tR\x06detail\x12\x1a\n" +
+	"\brevision\x18\f \x01(\x05R\brevision\"\x8f\x01\n" +
+	"\x15MatchTimelineResponse\x12/\n" +
+	"\x06events\x18\x01 \x03(\v2\x17.api.MatchTimelineEventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"latest_seq\x18\x03 \x01(\x03R\tlatestSeq\"0\n" +
+	"\x13MatchUpdatesRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\"7\n" +
+	"\x18MultiMatchUpdatesRequest\x12\x1b\n" +
+	"\tmatch_ids\x18\x01 \x03(\tR\bmatchIds\"\xac\x02\n" +
+	"\vMatchUpdate\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"home_score\x18\x03 \x01(\x05R\thomeScore\x12\x1d\n" +
+	"\n" +
+	"away_score\x18\x04 \x01(\x05R\tawayScore\x12\x1d\n" +
+	"\n" +
+	"last_event\x18\x05 \x01(\tR\tlastEvent\x12\x1e\n" +
+	"\n" +
+	"possession\x18\x06 \x01(\x05R\n" +
+	"possession\x12\x14\n" +
+	"\x05shots\x18\a \x01(\x05R\x05shots\x12\x14\n" +
+	"\x05fouls\x18\b \x01(\x05R\x05fouls\x12\x14\n" +
+	"\x05cards\x18\t \x03(\tR\x05cards\x12+\n" +
+	"\x11overridden_fields\x18\n" +
+	" \x03(\tR\x10overriddenFields2\x91\x04\n" +
 	"\x11ApiGatewayService\x12C\n" +
 	"\fRegisterUser\x12\x18.api.RegisterUserRequest\x1a\x19.api.RegisterUserResponse\x12@\n" +
-	"\vCreateMatch\x12\x17.api.CreateMatchRequest\x1a\x18.api.CreateMatchResponseB>Z<github.com/abaika-abay/live_sports_project/api-gateway/protob\x06proto3"
+	"\vCreateMatch\x12\x17.api.CreateMatchRequest\x1a\x18.api.CreateMatchResponse\x12L\n" +
+	"\x11GetAdminMatchList\x12\x1a.api.AdminMatchListRequest\x1a\x1b.api.AdminMatchListResponse\x12B\n" +
+	"\x12StreamMatchUpdates\x12\x18.api.MatchUpdatesRequest\x1a\x10.api.MatchUpdate0\x01\x12L\n" +
+	"\x17StreamMultiMatchUpdates\x12\x1d.api.MultiMatchUpdatesRequest\x1a\x10.api.MatchUpdate0\x01\x12J\n" +
+	"\x15GetMostWatchedMatches\x12\x17.api.MostWatchedRequest\x1a\x18.api.MostWatchedResponse\x12I\n" +
+	"\x10GetMatchTimeline\x12\x19.api.MatchTimelineRequest\x1a\x1a.api.MatchTimelineResponseB>Z<github.com/abaika-abay/live_sports_project/api-gateway/protob\x06proto3"
 
 var (
 	file_api_gateway_proto_api_gateway_proto_rawDescOnce sync.Once
@@ -289,23 +1225,47 @@ func file_api_gateway_proto_api_gateway_proto_rawDescGZIP() []byte {
 	return file_api_gateway_proto_api_gateway_proto_rawDescData
 }
 
-var file_api_gateway_proto_api_gateway_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_api_gateway_proto_api_gateway_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_api_gateway_proto_api_gateway_proto_goTypes = []any{
-	(*RegisterUserRequest)(nil),  // 0: api.RegisterUserRequest
-	(*RegisterUserResponse)(nil), // 1: api.RegisterUserResponse
-	(*CreateMatchRequest)(nil),   // 2: api.CreateMatchRequest
-	(*CreateMatchResponse)(nil),  // 3: api.CreateMatchResponse
+	(*RegisterUserRequest)(nil),      // 0: api.RegisterUserRequest
+	(*RegisterUserResponse)(nil),     // 1: api.RegisterUserResponse
+	(*CreateMatchRequest)(nil),       // 2: api.CreateMatchRequest
+	(*CreateMatchResponse)(nil),      // 3: api.CreateMatchResponse
+	(*AdminMatchListRequest)(nil),    // 4: api.AdminMatchListRequest
+	(*AdminMatchSummary)(nil),        // 5: api.AdminMatchSummary
+	(*AdminMatchListResponse)(nil),   // 6: api.AdminMatchListResponse
+	(*MostWatchedRequest)(nil),       // 7: api.MostWatchedRequest
+	(*MostWatchedResponse)(nil),      // 8: api.MostWatchedResponse
+	(*MatchTimelineRequest)(nil),     // 9: api.MatchTimelineRequest
+	(*MatchTimelineEvent)(nil),       // 10: api.MatchTimelineEvent
+	(*MatchTimelineResponse)(nil),    // 11: api.MatchTimelineResponse
+	(*MatchUpdatesRequest)(nil),      // 12: api.MatchUpdatesRequest
+	(*MultiMatchUpdatesRequest)(nil), // 13: api.MultiMatchUpdatesRequest
+	(*MatchUpdate)(nil),              // 14: api.MatchUpdate
 }
 var file_api_gateway_proto_api_gateway_proto_depIdxs = []int32{
-	0, // 0: api.ApiGatewayService.RegisterUser:input_type -> api.RegisterUserRequest
-	2, // 1: api.ApiGatewayService.CreateMatch:input_type -> api.CreateMatchRequest
-	1, // 2: api.ApiGatewayService.RegisterUser:output_type -> api.RegisterUserResponse
-	3, // 3: api.ApiGatewayService.CreateMatch:output_type -> api.CreateMatchResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	5,  // 0: api.AdminMatchListResponse.matches:type_name -> api.AdminMatchSummary
+	5,  // 1: api.MostWatchedResponse.matches:type_name -> api.AdminMatchSummary
+	10, // 2: api.MatchTimelineResponse.events:type_name -> api.MatchTimelineEvent
+	0,  // 3: api.ApiGatewayService.RegisterUser:input_type -> api.RegisterUserRequest
+	2,  // 4: api.ApiGatewayService.CreateMatch:input_type -> api.CreateMatchRequest
+	4,  // 5: api.ApiGatewayService.GetAdminMatchList:input_type -> api.AdminMatchListRequest
+	12, // 6: api.ApiGatewayService.StreamMatchUpdates:input_type -> api.MatchUpdatesRequest
+	13, // 7: api.ApiGatewayService.StreamMultiMatchUpdates:input_type -> api.MultiMatchUpdatesRequest
+	7,  // 8: api.ApiGatewayService.GetMostWatchedMatches:input_type -> api.MostWatchedRequest
+	9,  // 9: api.ApiGatewayService.GetMatchTimeline:input_type -> api.MatchTimelineRequest
+	1,  // 10: api.ApiGatewayService.RegisterUser:output_type -> api.RegisterUserResponse
+	3,  // 11: api.ApiGatewayService.CreateMatch:output_type -> api.CreateMatchResponse
+	6,  // 12: api.ApiGatewayService.GetAdminMatchList:output_type -> api.AdminMatchListResponse
+	14, // 13: api.ApiGatewayService.StreamMatchUpdates:output_type -> api.MatchUpdate
+	14, // 14: api.ApiGatewayService.StreamMultiMatchUpdates:output_type -> api.MatchUpdate
+	8,  // 15: api.ApiGatewayService.GetMostWatchedMatches:output_type -> api.MostWatchedResponse
+	11, // 16: api.ApiGatewayService.GetMatchTimeline:output_type -> api.MatchTimelineResponse
+	10, // [10:17] is the sub-list for method output_type
+	3,  // [3:10] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_api_gateway_proto_api_gateway_proto_init() }
@@ -319,7 +1279,7 @@ func file_api_gateway_proto_api_gateway_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_gateway_proto_api_gateway_proto_rawDesc), len(file_api_gateway_proto_api_gateway_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ApiGatewayService_RegisterUser_FullMethodName = "/api.ApiGatewayService/RegisterUser"
-	ApiGatewayService_CreateMatch_FullMethodName  = "/api.ApiGatewayService/CreateMatch"
+	ApiGatewayService_RegisterUser_FullMethodName            = "/api.ApiGatewayService/RegisterUser"
+	ApiGatewayService_CreateMatch_FullMethodName             = "/api.ApiGatewayService/CreateMatch"
+	ApiGatewayService_GetAdminMatchList_FullMethodName       = "/api.ApiGatewayService/GetAdminMatchList"
+	ApiGatewayService_StreamMatchUpdates_FullMethodName      = "/api.ApiGatewayService/StreamMatchUpdates"
+	ApiGatewayService_StreamMultiMatchUpdates_FullMethodName = "/api.ApiGatewayService/StreamMultiMatchUpdates"
+	ApiGatewayService_GetMostWatchedMatches_FullMethodName   = "/api.ApiGatewayService/GetMostWatchedMatches"
+	ApiGatewayService_GetMatchTimeline_FullMethodName        = "/api.ApiGatewayService/GetMatchTimeline"
 )
 
 // ApiGatewayServiceClient is the client API for ApiGatewayService service.
@@ -29,6 +34,11 @@ const (
 type ApiGatewayServiceClient interface {
 	RegisterUser(ctx context.Context, in *RegisterUserRequest, opts ...grpc.CallOption) (*RegisterUserResponse, error)
 	CreateMatch(ctx context.Context, in *CreateMatchRequest, opts ...grpc.CallOption) (*CreateMatchResponse, error)
+	GetAdminMatchList(ctx context.Context, in *AdminMatchListRequest, opts ...grpc.CallOption) (*AdminMatchListResponse, error)
+	StreamMatchUpdates(ctx context.Context, in *MatchUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchUpdate], error)
+	StreamMultiMatchUpdates(ctx context.Context, in *MultiMatchUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchUpdate], error)
+	GetMostWatchedMatches(ctx context.Context, in *MostWatchedRequest, opts ...grpc.CallOption) (*MostWatchedResponse, error)
+	GetMatchTimeline(ctx context.Context, in *MatchTimelineRequest, opts ...grpc.CallOption) (*MatchTimelineResponse, error)
 }
 
 type apiGatewayServiceClient struct {
@@ -59,12 +69,85 @@ func (c *apiGatewayServiceClient) CreateMatch(ctx context.Context, in *CreateMat
 	return out, nil
 }
 
+func (c *apiGatewayServiceClient) GetAdminMatchList(ctx context.Context, in *AdminMatchListRequest, opts ...grpc.CallOption) (*AdminMatchListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminMatchListResponse)
+	err := c.cc.Invoke(ctx, ApiGatewayService_GetAdminMatchList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiGatewayServiceClient) StreamMatchUpdates(ctx context.Context, in *MatchUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ApiGatewayService_ServiceDesc.Streams[0], ApiGatewayService_StreamMatchUpdates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[MatchUpdatesRequest, MatchUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ApiGatewayService_StreamMatchUpdatesClient = grpc.ServerStreamingClient[MatchUpdate]
+
+func (c *apiGatewayServiceClient) StreamMultiMatchUpdates(ctx context.Context, in *MultiMatchUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ApiGatewayService_ServiceDesc.Streams[1], ApiGatewayService_StreamMultiMatchUpdates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[MultiMatchUpdatesRequest, MatchUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ApiGatewayService_StreamMultiMatchUpdatesClient = grpc.ServerStreamingClient[MatchUpdate]
+
+func (c *apiGatewayServiceClient) GetMostWatchedMatches(ctx context.Context, in *MostWatchedRequest, opts ...grpc.CallOption) (*MostWatchedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MostWatchedResponse)
+	err := c.cc.Invoke(ctx, ApiGatewayService_GetMostWatchedMatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiGatewayServiceClient) GetMatchTimeline(ctx context.Context, in *MatchTimelineRequest, opts ...grpc.CallOption) (*MatchTimelineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchTimelineResponse)
+	err := c.cc.Invoke(ctx, ApiGatewayService_GetMatchTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ApiGatewayServiceServer is the server API for ApiGatewayService service.
 // All implementations must embed UnimplementedApiGatewayServiceServer
 // for forward compatibility.
 type ApiGatewayServiceServer interface {
 	RegisterUser(context.Context, *RegisterUserRequest) (*RegisterUserResponse, error)
 	CreateMatch(context.Context, *CreateMatchRequest) (*CreateMatchResponse, error)
+	GetAdminMatchList(context.Context, *AdminMatchListRequest) (*AdminMatchListResponse, error)
+	StreamMatchUpdates(*MatchUpdatesRequest, grpc.ServerStreamingServer[MatchUpdate]) error
+	StreamMultiMatchUpdates(*MultiMatchUpdatesRequest, grpc.ServerStreamingServer[MatchUpdate]) error
+	GetMostWatchedMatches(context.Context, *MostWatchedRequest) (*MostWatchedResponse, error)
+	GetMatchTimeline(context.Context, *MatchTimelineRequest) (*MatchTimelineResponse, error)
 	mustEmbedUnimplementedApiGatewayServiceServer()
 }
 
@@ -81,6 +164,21 @@ func (UnimplementedApiGatewayServiceServer) RegisterUser(context.Context, *Regis
 func (UnimplementedApiGatewayServiceServer) CreateMatch(context.Context, *CreateMatchRequest) (*CreateMatchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateMatch not implemented")
 }
+func (UnimplementedApiGatewayServiceServer) GetAdminMatchList(context.Context, *AdminMatchListRequest) (*AdminMatchListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAdminMatchList not implemented")
+}
+func (UnimplementedApiGatewayServiceServer) StreamMatchUpdates(*MatchUpdatesRequest, grpc.ServerStreamingServer[MatchUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMatchUpdates not implemented")
+}
+func (UnimplementedApiGatewayServiceServer) StreamMultiMatchUpdates(*MultiMatchUpdatesRequest, grpc.ServerStreamingServer[MatchUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMultiMatchUpdates not implemented")
+}
+func (UnimplementedApiGatewayServiceServer) GetMostWatchedMatches(context.Context, *MostWatchedRequest) (*MostWatchedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMostWatchedMatches not implemented")
+}
+func (UnimplementedApiGatewayServiceServer) GetMatchTimeline(context.Context, *MatchTimelineRequest) (*MatchTimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMatchTimeline not implemented")
+}
 func (UnimplementedApiGatewayServiceServer) mustEmbedUnimplementedApiGatewayServiceServer() {}
 func (UnimplementedApiGatewayServiceServer) testEmbeddedByValue()                           {}
 
@@ -138,6 +236,82 @@ func _ApiGatewayService_CreateMatch_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApiGatewayService_GetAdminMatchList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminMatchListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiGatewayServiceServer).GetAdminMatchList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ApiGatewayService_GetAdminMatchList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiGatewayServiceServer).GetAdminMatchList(ctx, req.(*AdminMatchListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiGatewayService_StreamMatchUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MatchUpdatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ApiGatewayServiceServer).StreamMatchUpdates(m, &grpc.GenericServerStream[MatchUpdatesRequest, MatchUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ApiGatewayService_StreamMatchUpdatesServer = grpc.ServerStreamingServer[MatchUpdate]
+
+func _ApiGatewayService_StreamMultiMatchUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MultiMatchUpdatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ApiGatewayServiceServer).StreamMultiMatchUpdates(m, &grpc.GenericServerStream[MultiMatchUpdatesRequest, MatchUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ApiGatewayService_StreamMultiMatchUpdatesServer = grpc.ServerStreamingServer[MatchUpdate]
+
+func _ApiGatewayService_GetMostWatchedMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MostWatchedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiGatewayServiceServer).GetMostWatchedMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ApiGatewayService_GetMostWatchedMatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiGatewayServiceServer).GetMostWatchedMatches(ctx, req.(*MostWatchedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiGatewayService_GetMatchTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiGatewayServiceServer).GetMatchTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ApiGatewayService_GetMatchTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiGatewayServiceServer).GetMatchTimeline(ctx, req.(*MatchTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ApiGatewayService_ServiceDesc is the grpc.ServiceDesc for ApiGatewayService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,7 +327,30 @@ var ApiGatewayService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateMatch",
 			Handler:    _ApiGatewayService_CreateMatch_Handler,
 		},
+		{
+			MethodName: "GetAdminMatchList",
+			Handler:    _ApiGatewayService_GetAdminMatchList_Handler,
+		},
+		{
+			MethodName: "GetMostWatchedMatches",
+			Handler:    _ApiGatewayService_GetMostWatchedMatches_Handler,
+		},
+		{
+			MethodName: "GetMatchTimeline",
+			Handler:    _ApiGatewayService_GetMatchTimeline_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamMatchUpdates",
+			Handler:       _ApiGatewayService_StreamMatchUpdates_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamMultiMatchUpdates",
+			Handler:       _ApiGatewayService_StreamMultiMatchUpdates_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api-gateway/proto/api_gateway.proto",
 }
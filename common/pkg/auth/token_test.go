@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "test-secret-of-at-least-32-bytes-long"
+
+func TestIssueAndVerify(t *testing.T) {
+	signer, err := NewSigner(testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, issued, err := signer.Issue("user-1", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := signer.Verify(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *claims != *issued || claims.UserID != "user-1" {
+		t.Errorf("claims = %+v, want %+v", claims, issued)
+	}
+}
+
+func TestVerifyRejectsForgedTokens(t *testing.T) {
+	signer, _ := NewSigner(testSecret)
+	token, _, _ := signer.Issue("user-1", time.Hour)
+	payload, signature, _ := strings.Cut(token, ".")
+	other, _ := NewSigner(testSecret + "-other")
+	forged, _, _ := other.Issue("admin", time.Hour)
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	for name, token := range map[string]string{
+		"other secret":      forged,
+		"swapped payload":   forgedPayload + "." + signature,
+		"altered signature": payload + "." + strings.ToUpper(signature),
+		"no signature":      payload,
+		"empty":             "",
+	} {
+		if _, err := signer.Verify(token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: Verify() = %v, want ErrInvalidToken", name, err)
+		}
+	}
+}
+
+func TestVerifyRejectsExpiredTokens(t *testing.T) {
+	signer, _ := NewSigner(testSecret)
+	token, _, _ := signer.Issue("user-1", -time.Minute)
+	if _, err := signer.Verify(token); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("Verify() = %v, want ErrTokenExpired", err)
+	}
+}
+
+func TestNewSignerRejectsShortSecrets(t *testing.T) {
+	if _, err := NewSigner(testSecret[:31]); err == nil {
+		t.Error("NewSigner() accepted a 31-byte secret")
+	}
+}
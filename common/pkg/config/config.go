@@ -4,19 +4,45 @@ import (
 	"fmt"
 	"os"
 	"path/filepath" // Import for path manipulation
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	DBUrl              string
-	Port               string
-	SportradarAPIKey   string
-	SportradarBaseURL  string // New field
-	NotificationBroker string
-	WebSocketPort      string // New field for WebSocket server
+	DBUrl               string
+	Port                string
+	SportradarAPIKey    string
+	SportradarBaseURL   string // New field
+	NotificationBroker  string
+	NATSUrl             string // Fans WebSocket broadcasts out across match-service replicas if set
+	WebSocketPort       string // New field for WebSocket server
+	DebugPort           string // Internal listener for debug endpoints, not exposed to clients
+	WebSocketDropPolicy string // What to do with WebSocket clients that fall behind, unless they pick one with the drop_policy query parameter
+	// Record/replay of provider responses, for reproducing incidents locally
+	SportradarRecordFile  string  // Append every Sportradar response to this file if set
+	SportradarReplayFile  string  // Serve Sportradar data from this recording instead of the API if set
+	SportradarReplaySpeed float64 // Replay speed, 1 for real time
+	// Calls to the Sportradar API instead of the built-in simulator, within the limits of the key
+	SportradarUseAPI            bool
+	SportradarRequestsPerSecond float64 // 0 for no rate limit
+	SportradarDailyQuota        int     // Requests per UTC day, 0 for unlimited
+	// Optional second Sportradar-compatible feed, merged with the primary one
+	SecondaryFeedURL    string
+	SecondaryFeedAPIKey string
+	// Tokens issued by user-service and required by the match-service WebSocket and SSE endpoints
+	AuthTokenSecret          string        // Shared by all services; push endpoints are open if unset
+	AuthTokenTTL             time.Duration // How long a login token is valid
+	WebSocketAllowedOrigins  []string      // Origins allowed to open WebSockets, "*" for any; same origin only if empty
+	WebSocketMaxConnsPerUser int           // Push connections a single user can have open at once
 }
 
+// exampleAuthTokenSecret is the placeholder .env files used to ship with. It is public,
+// so services refuse to start with it.
+const exampleAuthTokenSecret = "change-me-to-a-random-string-of-32-bytes-or-more"
+
 func LoadConfig() (*Config, error) {
 	currentDir, err := os.Getwd()
 	if err != nil {
@@ -39,12 +65,24 @@ func LoadConfig() (*Config, error) {
 	}
 
 	cfg := &Config{
-		DBUrl:              os.Getenv("DB_URL"),
-		Port:               os.Getenv("PORT"),
-		SportradarAPIKey:   os.Getenv("SPORTRADAR_API_KEY"),
-		SportradarBaseURL:  os.Getenv("SPORTRADAR_BASE_URL"), // Get from env
-		NotificationBroker: os.Getenv("NOTIFICATION_BROKER"),
-		WebSocketPort:      os.Getenv("WS_PORT"), // Get WebSocket port
+		DBUrl:                       os.Getenv("DB_URL"),
+		Port:                        os.Getenv("PORT"),
+		SportradarAPIKey:            os.Getenv("SPORTRADAR_API_KEY"),
+		SportradarBaseURL:           os.Getenv("SPORTRADAR_BASE_URL"), // Get from env
+		NotificationBroker:          os.Getenv("NOTIFICATION_BROKER"),
+		NATSUrl:                     os.Getenv("NATS_URL"),
+		WebSocketPort:               os.Getenv("WS_PORT"), // Get WebSocket port
+		DebugPort:                   os.Getenv("DEBUG_PORT"),
+		WebSocketDropPolicy:         os.Getenv("WS_DROP_POLICY"),
+		SportradarRecordFile:        os.Getenv("SPORTRADAR_RECORD_FILE"),
+		SportradarReplayFile:        os.Getenv("SPORTRADAR_REPLAY_FILE"),
+		SportradarReplaySpeed:       1,
+		SportradarRequestsPerSecond: 1,
+		SecondaryFeedURL:            os.Getenv("SECONDARY_FEED_URL"),
+		SecondaryFeedAPIKey:         os.Getenv("SECONDARY_FEED_API_KEY"),
+		AuthTokenSecret:             os.Getenv("AUTH_TOKEN_SECRET"),
+		AuthTokenTTL:                time.Hour,
+		WebSocketMaxConnsPerUser:    5,
 	}
 
 	if cfg.DBUrl == "" {
@@ -66,6 +104,59 @@ func LoadConfig() (*Config, error) {
 		cfg.WebSocketPort = ":8080" // Default WebSocket port
 		fmt.Printf("Warning: WS_PORT not set, defaulting to %s\n", cfg.WebSocketPort)
 	}
+	if cfg.DebugPort == "" {
+		cfg.DebugPort = "localhost:6060" // Reachable from the host only
+	}
+
+	if speed := os.Getenv("SPORTRADAR_REPLAY_SPEED"); speed != "" {
+		cfg.SportradarReplaySpeed, err = strconv.ParseFloat(speed, 64)
+		if err != nil || cfg.SportradarReplaySpeed <= 0 {
+			return nil, fmt.Errorf("invalid SPORTRADAR_REPLAY_SPEED %q: must be a positive number", speed)
+		}
+	}
+
+	if useAPI := os.Getenv("SPORTRADAR_USE_API"); useAPI != "" {
+		cfg.SportradarUseAPI, err = strconv.ParseBool(useAPI)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SPORTRADAR_USE_API %q: must be true or false", useAPI)
+		}
+	}
+	if rate := os.Getenv("SPORTRADAR_REQUESTS_PER_SECOND"); rate != "" {
+		cfg.SportradarRequestsPerSecond, err = strconv.ParseFloat(rate, 64)
+		if err != nil || cfg.SportradarRequestsPerSecond < 0 {
+			return nil, fmt.Errorf("invalid SPORTRADAR_REQUESTS_PER_SECOND %q: must be a number, 0 for no limit", rate)
+		}
+	}
+	if quota := os.Getenv("SPORTRADAR_DAILY_QUOTA"); quota != "" {
+		cfg.SportradarDailyQuota, err = strconv.Atoi(quota)
+		if err != nil || cfg.SportradarDailyQuota < 0 {
+			return nil, fmt.Errorf("invalid SPORTRADAR_DAILY_QUOTA %q: must be a number, 0 for unlimited", quota)
+		}
+	}
+
+	if cfg.AuthTokenSecret == "" {
+		fmt.Println("Warning: AUTH_TOKEN_SECRET not set, login tokens can't be issued or checked.")
+	}
+	if cfg.AuthTokenSecret == exampleAuthTokenSecret {
+		return nil, fmt.Errorf("AUTH_TOKEN_SECRET is the example value, anyone could forge tokens with it: set a random string of 32 bytes or more")
+	}
+	if ttl := os.Getenv("AUTH_TOKEN_TTL"); ttl != "" {
+		cfg.AuthTokenTTL, err = time.ParseDuration(ttl)
+		if err != nil || cfg.AuthTokenTTL <= 0 {
+			return nil, fmt.Errorf("invalid AUTH_TOKEN_TTL %q: must be a positive duration like 1h", ttl)
+		}
+	}
+	for _, origin := range strings.Split(os.Getenv("WS_ALLOWED_ORIGINS"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			cfg.WebSocketAllowedOrigins = append(cfg.WebSocketAllowedOrigins, origin)
+		}
+	}
+	if limit := os.Getenv("WS_MAX_CONNECTIONS_PER_USER"); limit != "" {
+		cfg.WebSocketMaxConnsPerUser, err = strconv.Atoi(limit)
+		if err != nil || cfg.WebSocketMaxConnsPerUser <= 0 {
+			return nil, fmt.Errorf("invalid WS_MAX_CONNECTIONS_PER_USER %q: must be a positive number", limit)
+		}
+	}
 
 	return cfg, nil
 }
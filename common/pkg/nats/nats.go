@@ -19,6 +19,19 @@ func NewNATS(url string) (*NATS, error) {
 	return &NATS{Conn: conn}, nil
 }
 
+// Publish sends data on subject.
+func (n *NATS) Publish(subject string, data []byte) error {
+	return n.Conn.Publish(subject, data)
+}
+
+// Subscribe calls handler for every message on subject, which may contain wildcards.
+// Messages are handled one at a time, in the order they arrive.
+func (n *NATS) Subscribe(subject string, handler func(subject string, data []byte)) (*nats.Subscription, error) {
+	return n.Conn.Subscribe(subject, func(msg *nats.Msg) {
+		handler(msg.Subject, msg.Data)
+	})
+}
+
 func (n *NATS) Close() {
 	if n.Conn != nil {
 		n.Conn.Close()
@@ -0,0 +1,46 @@
+// Command fakeprovider serves simulated matches through a fake Sportradar Soccer v4 API.
+// Point match-service at it for demos and load tests:
+//
+//	go run ./cmd/fakeprovider -matches 20 -speed 30
+//	SPORTRADAR_BASE_URL="http://localhost:9090/soccer/trial/v4/en/"
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/sportradar"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "address to listen on")
+	prefix := flag.String("prefix", "/soccer/trial/v4/en/", "base path of the fake API")
+	seed := flag.Int64("seed", 1, "seed for the simulated matches; the same seed replays the same matches")
+	matches := flag.Int("matches", 10, "number of matches to simulate")
+	firstKickOff := flag.Duration("kickoff-in", 1*time.Minute, "time until the first match kicks off")
+	spacing := flag.Duration("spacing", 5*time.Minute, "time between kick-offs")
+	speed := flag.Float64("speed", 1, "clock speed, e.g. 90 plays a whole match in about a minute")
+	flag.Parse()
+	if *matches < 1 {
+		log.Fatalf("-matches must be at least 1")
+	}
+
+	clock := time.Now
+	if *speed != 1 {
+		clock = sportradar.AcceleratedClock(*speed)
+	}
+	sim := sportradar.NewSimulator(*seed, clock)
+	ids := sim.GenerateFixtures(*matches, clock().Add(*firstKickOff), *spacing)
+	log.Printf("Simulating %d matches (%s ... %s) with seed %d at %vx speed", len(ids), ids[0], ids[len(ids)-1], *seed, *speed)
+
+	mux := http.NewServeMux()
+	mux.Handle(*prefix, http.StripPrefix(strings.TrimSuffix(*prefix, "/"), sim.Handler()))
+
+	log.Printf("Fake Sportradar API listening on %s%s", *addr, *prefix)
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		log.Fatalf("fake Sportradar API failed: %v", err)
+	}
+}
@@ -6,13 +6,14 @@ import (
 	"log"
 	"net"
 	"net/http" // New import for HTTP server
-	"slices"
 	"time"
 
 	"google.golang.org/grpc"
 
+	"github.com/abaika-abay/live_sports_project/common/pkg/auth"
 	"github.com/abaika-abay/live_sports_project/common/pkg/config"
 	"github.com/abaika-abay/live_sports_project/common/pkg/db"
+	natsw "github.com/abaika-abay/live_sports_project/common/pkg/nats"
 	"github.com/abaika-abay/live_sports_project/match-service/proto"
 	"github.com/abaika-abay/live_sports_project/match-service/repository"
 	"github.com/abaika-abay/live_sports_project/match-service/service"
@@ -35,21 +36,73 @@ func main() {
 		}
 	}()
 
+	// Rate limiting, retries and circuit breaking around the Sportradar API and the secondary
+	// feed. While a breaker is open, GetMatchUpdates and the poller fall back to DB data without
+	// waiting on the provider. The simulator and replays run in-process and aren't limited.
+	resilientConfig := sportradar.DefaultResilientConfig()
+	resilientConfig.RequestsPerSecond = c.SportradarRequestsPerSecond
+	resilientConfig.DailyQuota = c.SportradarDailyQuota
+	resilientClients := map[string]*sportradar.ResilientClient{} // By source name, for /debug/<name>
+
 	// Initialize Sportradar Client
-	// For now, let's keep using the mock for easier testing of WebSockets
-	// You'll switch to NewSportradarHTTPClient when you're ready for real API calls.
-	srClient := sportradar.NewMockSportradarClient() // Using the mock for now
+	// By default, use the simulator for easier testing of WebSockets: it plays a deterministic
+	// match instead of calling the API. Set SPORTRADAR_USE_API for real API calls, or point
+	// SPORTRADAR_BASE_URL at cmd/fakeprovider to go through HTTP with simulated data.
+	simulator := sportradar.NewSimulator(1, time.Now)
+	var provider sportradar.SportradarClientI = simulator
+	if c.SportradarUseAPI {
+		apiClient := sportradar.NewResilientClient(sportradar.NewSportradarHTTPClient(c.SportradarBaseURL, c.SportradarAPIKey), resilientConfig)
+		resilientClients["sportradar"] = apiClient
+		provider = apiClient
+		log.Printf("Calling the Sportradar API at %s", c.SportradarBaseURL)
+	}
 
-	repo := repository.NewMatchRepository(dbHandler)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	// Replay a recorded provider feed instead, e.g. to reproduce a production incident locally.
+	if c.SportradarReplayFile != "" {
+		replayClient, err := sportradar.LoadReplayFile(c.SportradarReplayFile, c.SportradarReplaySpeed)
+		if err != nil {
+			log.Fatalf("failed to load Sportradar replay: %v", err)
+		}
+		provider = replayClient
+		log.Printf("Replaying Sportradar data from %s at %vx speed", c.SportradarReplayFile, c.SportradarReplaySpeed)
+	}
+	// Record every provider response, so incidents can be replayed later.
+	if c.SportradarRecordFile != "" {
+		recordingClient, err := sportradar.NewRecordingFileClient(provider, c.SportradarRecordFile)
+		if err != nil {
+			log.Fatalf("failed to start Sportradar recording: %v", err)
+		}
+		defer recordingClient.Close()
+		provider = recordingClient
+		log.Printf("Recording Sportradar responses to %s", c.SportradarRecordFile)
+	}
+
+	// Merge Sportradar with a second feed, if configured, and cross-check them on score and status.
+	// Admin corrections are kept as overrides on the match instead, see service.DefaultOverrideTTL.
+	sources := []sportradar.Source{
+		{Name: "sportradar", Client: provider},
+	}
+	if c.SecondaryFeedURL != "" {
+		secondary := sportradar.NewResilientClient(sportradar.NewSportradarHTTPClient(c.SecondaryFeedURL, c.SecondaryFeedAPIKey), resilientConfig)
+		resilientClients["secondary"] = secondary
+		sources = append(sources, sportradar.Source{Name: "secondary", Client: secondary})
+		log.Printf("Cross-checking Sportradar with secondary feed at %s", c.SecondaryFeedURL)
+	}
+	var matchService *service.MatchService
+	aggregatedClient := sportradar.NewAggregatedClient(sources, sportradar.AggregatorConfig{
+		ConflictThreshold: 2 * time.Minute,
+		OnConflict: func(conflict sportradar.Conflict) {
+			matchService.ReportConflict(conflict)
+		},
+	})
 
 	initialMatchID := "match-123"
+	kickOff := time.Now().Add(1 * time.Minute).Truncate(time.Second)
 	initialMatch := &repository.Match{
 		MatchID:    initialMatchID,
 		HomeTeam:   "Real Madrid",
 		AwayTeam:   "Barcelona",
-		StartTime:  time.Now().Add(1 * time.Hour).Format(time.RFC3339),
+		StartTime:  kickOff.UTC().Format(time.RFC3339),
 		Status:     "Scheduled",
 		HomeScore:  0,
 		AwayScore:  0,
@@ -59,97 +112,91 @@ func main() {
 		Fouls:      0,
 		Cards:      []string{},
 	}
-	if err := repo.UpdateMatch(ctx, initialMatch); err != nil {
-		fmt.Printf("Warning: Failed to ensure initial match %s exists in DB: %v\n", initialMatchID, err)
-	} else {
-		fmt.Printf("Ensured initial match data for: %s in DB\n", initialMatchID)
-	}
-	if mockSR, ok := srClient.(*sportradar.MockSportradarClient); ok {
-		mockSR.AddInitialMatchData(initialMatch)
-		fmt.Printf("Initialized mock Sportradar data for: %s\n", initialMatchID)
-	}
+	simulator.AddMatch(initialMatchID, initialMatch.HomeTeam, initialMatch.AwayTeam, kickOff)
+	fmt.Printf("Simulating match %s, kick-off at %s\n", initialMatchID, initialMatch.StartTime)
 
 	// --- Start WebSocket setup (Part 2) ---
 	websocketHub := service.NewWebSocketHub()
-	go websocketHub.Run() // Run the hub's goroutine
+	if c.WebSocketDropPolicy != "" {
+		policy, err := service.ParseDropPolicy(c.WebSocketDropPolicy)
+		if err != nil {
+			log.Fatalf("invalid WS_DROP_POLICY: %v", err)
+		}
+		websocketHub.SetDropPolicy(policy)
+	}
+	websocketHub.SetAllowedOrigins(c.WebSocketAllowedOrigins)
+	if c.AuthTokenSecret != "" {
+		tokens, err := auth.NewSigner(c.AuthTokenSecret)
+		if err != nil {
+			log.Fatalf("invalid AUTH_TOKEN_SECRET: %v", err)
+		}
+		websocketHub.RequireAuth(tokens, c.WebSocketMaxConnsPerUser)
+	} else {
+		log.Println("Warning: WebSocket and SSE connections are not authenticated, set AUTH_TOKEN_SECRET")
+	}
+	// With several replicas, broadcasts go through NATS so every replica's clients get them
+	if c.NATSUrl != "" {
+		broker, err := natsw.NewNATS(c.NATSUrl)
+		if err != nil {
+			log.Fatalf("failed to connect to NATS: %v", err)
+		}
+		defer broker.Close()
+		if err := websocketHub.EnableFanout(broker); err != nil {
+			log.Fatalf("failed to enable WebSocket fan-out: %v", err)
+		}
+	}
+	go websocketHub.Run() // Run the hub's housekeeping
 
 	// Create a new HTTP server for WebSockets (often on a different port)
 	mux := http.NewServeMux()
 	mux.HandleFunc("/ws", websocketHub.HandleConnections)
+	mux.HandleFunc("/events", websocketHub.HandleEvents) // Same updates as Server-Sent Events
 	go func() {
 		log.Printf("WebSocket server starting on %s", c.WebSocketPort)
 		if err := http.ListenAndServe(c.WebSocketPort, mux); err != nil {
 			log.Fatalf("WebSocket server failed to start: %v", err)
 		}
 	}()
-	// --- End WebSocket setup ---
-
-	matchService := service.NewMatchService(dbHandler, srClient, websocketHub) // Pass WebSocket hub here
 
-	// --- Start Background Polling (Part 3) ---
+	// Debug endpoints have no auth, so they get their own listener, kept off public interfaces.
+	debugMux := http.NewServeMux()
+	for name, client := range resilientClients {
+		debugMux.HandleFunc("/debug/"+name, client.StatsHandler) // Breaker state and quota use
+	}
 	go func() {
-		pollInterval := 5 * time.Second // Poll Sportradar every 5 seconds
-		ticker := time.NewTicker(pollInterval)
-		defer ticker.Stop()
-
-		log.Printf("Starting Sportradar background polling every %s for %s", pollInterval, initialMatchID) // Polling for fixed match for demo
-		for range ticker.C {
-			pollCtx, cancelPoll := context.WithTimeout(context.Background(), 3*time.Second) // Shorter timeout for polling
-			matchIDToPoll := initialMatchID                                                 // Or iterate over all live matches in DB
-			currentMatch, err := repo.GetMatch(pollCtx, matchIDToPoll)                      // Get current state from DB
-			if err != nil {
-				log.Printf("Polling: Could not get match %s from DB: %v", matchIDToPoll, err)
-				cancelPoll()
-				continue
-			}
-
-			srUpdate, err := srClient.FetchMatchData(pollCtx, matchIDToPoll)
-			if err != nil {
-				log.Printf("Polling: Failed to fetch real-time data for %s from Sportradar: %v", matchIDToPoll, err)
-				cancelPoll()
-				continue
-			}
-
-			// Check if there are actual changes before updating and broadcasting
-			if srUpdate.HomeScore != currentMatch.HomeScore || srUpdate.AwayScore != currentMatch.AwayScore ||
-				srUpdate.Status != currentMatch.Status || srUpdate.LastEvent != currentMatch.LastEvent ||
-				srUpdate.Possession != currentMatch.Possession || srUpdate.Shots != currentMatch.Shots ||
-				srUpdate.Fouls != currentMatch.Fouls || !slices.Equal(srUpdate.Cards, currentMatch.Cards) { // Use slices.Equal for slices
-				// Update current match object with new SR data
-				currentMatch.HomeScore = srUpdate.HomeScore
-				currentMatch.AwayScore = srUpdate.AwayScore
-				currentMatch.Status = srUpdate.Status
-				currentMatch.LastEvent = srUpdate.LastEvent
-				currentMatch.Possession = srUpdate.Possession
-				currentMatch.Shots = srUpdate.Shots
-				currentMatch.Fouls = srUpdate.Fouls
-				currentMatch.Cards = srUpdate.Cards // Deep copy if needed
-
-				// Update database
-				if err := repo.UpdateMatch(pollCtx, currentMatch); err != nil {
-					log.Printf("Polling: Failed to update DB for match %s: %v", matchIDToPoll, err)
-					cancelPoll()
-					continue
-				}
-
-				// Broadcast update via WebSockets
-				protoMatch := &proto.MatchResponse{
-					MatchId:    currentMatch.MatchID,
-					Status:     currentMatch.Status,
-					HomeScore:  currentMatch.HomeScore,
-					AwayScore:  currentMatch.AwayScore,
-					LastEvent:  currentMatch.LastEvent,
-					Possession: currentMatch.Possession,
-					Shots:      currentMatch.Shots,
-					Fouls:      currentMatch.Fouls,
-					Cards:      currentMatch.Cards,
-				}
-				websocketHub.BroadcastMatchUpdate(currentMatch.MatchID, protoMatch)
-				log.Printf("Polling: Broadcasted live update for match %s (score %d-%d).\n", currentMatch.MatchID, currentMatch.HomeScore, currentMatch.AwayScore)
-			}
-			cancelPoll()
+		log.Printf("Debug server starting on %s", c.DebugPort)
+		if err := http.ListenAndServe(c.DebugPort, debugMux); err != nil {
+			log.Fatalf("Debug server failed to start: %v", err)
 		}
 	}()
+	// --- End WebSocket setup ---
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// Event seqs must be unique before anything records events, see MatchRepository.EnsureIndexes
+	if err := repository.NewMatchRepository(dbHandler).EnsureIndexes(ctx); err != nil {
+		log.Fatalf("failed to set up MongoDB indexes: %v", err)
+	}
+
+	matchService = service.NewMatchService(dbHandler, aggregatedClient, websocketHub) // Pass WebSocket hub here
+	websocketHub.SetPeakRecorder(matchService.RecordPeakViewers)                      // Peak viewers are saved after full time
+
+	if err := matchService.SeedMatch(ctx, initialMatch); err != nil {
+		fmt.Printf("Warning: Failed to ensure initial match %s exists in DB: %v\n", initialMatchID, err)
+	} else {
+		fmt.Printf("Ensured initial match data for: %s in DB\n", initialMatchID)
+	}
+
+	// --- Start Background Polling (Part 3) ---
+	// Every scheduled/live match in the DB is polled; how often depends on the match phase
+	// (see service.DefaultPhaseIntervals). Per-sport/competition intervals can be set with
+	// pollPolicy.SetOverride.
+	pollPolicy := service.NewPhasePollPolicy(service.DefaultPhaseIntervals())
+	poller := service.NewMatchPoller(matchService, pollPolicy, 8)
+	go poller.Run(context.Background())
+
+	// Import fixtures and live matches from Sportradar so admins don't have to create them by hand.
+	go matchService.RunFixtureSync(context.Background(), 30*time.Minute)
 	// --- End Background Polling ---
 
 	lis, err := net.Listen("tcp", c.Port)
@@ -9,7 +9,6 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -67,25 +66,76 @@ func (x *MatchRequest) GetMatchId() string {
 	return ""
 }
 
-// MatchResponse contains current match data
-type MatchResponse struct {
+// MultiMatchRequest is used for following several matches at once
+type MultiMatchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	HomeScore     int32                  `protobuf:"varint,3,opt,name=home_score,json=homeScore,proto3" json:"home_score,omitempty"`
-	AwayScore     int32                  `protobuf:"varint,4,opt,name=away_score,json=awayScore,proto3" json:"away_score,omitempty"`
-	LastEvent     string                 `protobuf:"bytes,5,opt,name=last_event,json=lastEvent,proto3" json:"last_event,omitempty"`
-	Possession    int32                  `protobuf:"varint,6,opt,name=possession,proto3" json:"possession,omitempty"`
-	Shots         int32                  `protobuf:"varint,7,opt,name=shots,proto3" json:"shots,omitempty"`
-	Fouls         int32                  `protobuf:"varint,8,opt,name=fouls,proto3" json:"fouls,omitempty"`
-	Cards         []string               `protobuf:"bytes,9,rep,name=cards,proto3" json:"cards,omitempty"` // Added cards field
+	MatchIds      []string               `protobuf:"bytes,1,rep,name=match_ids,json=matchIds,proto3" json:"match_ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *MultiMatchRequest) Reset() {
+	*x = MultiMatchRequest{}
+	mi := &file_match_service_proto_match_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultiMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiMatchRequest) ProtoMessage() {}
+
+func (x *MultiMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_match_service_proto_match_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiMatchRequest.ProtoReflect.Descriptor instead.
+func (*MultiMatchRequest) Descriptor() ([]byte, []int) {
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MultiMatchRequest) GetMatchIds() []string {
+	if x != nil {
+		return x.MatchIds
+	}
+	return nil
+}
+
+// MatchResponse contains current match data
+type MatchResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MatchId          string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Status           string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	HomeScore        int32                  `protobuf:"varint,3,opt,name=home_score,json=homeScore,proto3" json:"home_score,omitempty"`
+	AwayScore        int32                  `protobuf:"varint,4,opt,name=away_score,json=awayScore,proto3" json:"away_score,omitempty"`
+	LastEvent        string                 `protobuf:"bytes,5,opt,name=last_event,json=lastEvent,proto3" json:"last_event,omitempty"`
+	Possession       int32                  `protobuf:"varint,6,opt,name=possession,proto3" json:"possession,omitempty"`
+	Shots            int32                  `protobuf:"varint,7,opt,name=shots,proto3" json:"shots,omitempty"`
+	Fouls            int32                  `protobuf:"varint,8,opt,name=fouls,proto3" json:"fouls,omitempty"`
+	Cards            []string               `protobuf:"bytes,9,rep,name=cards,proto3" json:"cards,omitempty"`                                                // Added cards field
+	OverriddenFields []string               `protobuf:"bytes,10,rep,name=overridden_fields,json=overriddenFields,proto3" json:"overridden_fields,omitempty"` // Fields corrected by an admin that provider updates don't touch: "score", "status", "cards"
+	HomeTeam         string                 `protobuf:"bytes,11,opt,name=home_team,json=homeTeam,proto3" json:"home_team,omitempty"`
+	AwayTeam         string                 `protobuf:"bytes,12,opt,name=away_team,json=awayTeam,proto3" json:"away_team,omitempty"`
+	StartTime        string                 `protobuf:"bytes,13,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // ISO 8601 format
+	Competition      string                 `protobuf:"bytes,14,opt,name=competition,proto3" json:"competition,omitempty"`
+	PeakViewers      int64                  `protobuf:"varint,15,opt,name=peak_viewers,json=peakViewers,proto3" json:"peak_viewers,omitempty"` // Most concurrent viewers, recorded after full time
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
 func (x *MatchResponse) Reset() {
 	*x = MatchResponse{}
-	mi := &file_match_service_proto_match_proto_msgTypes[1]
+	mi := &file_match_service_proto_match_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -97,7 +147,7 @@ func (x *MatchResponse) String() string {
 func (*MatchResponse) ProtoMessage() {}
 
 func (x *MatchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_match_service_proto_match_proto_msgTypes[1]
+	mi := &file_match_service_proto_match_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -110,7 +160,7 @@ func (x *MatchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MatchResponse.ProtoReflect.Descriptor instead.
 func (*MatchResponse) Descriptor() ([]byte, []int) {
-	return file_match_service_proto_match_proto_rawDescGZIP(), []int{1}
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *MatchResponse) GetMatchId() string {
@@ -176,6 +226,48 @@ func (x *MatchResponse) GetCards() []string {
 	return nil
 }
 
+func (x *MatchResponse) GetOverriddenFields() []string {
+	if x != nil {
+		return x.OverriddenFields
+	}
+	return nil
+}
+
+func (x *MatchResponse) GetHomeTeam() string {
+	if x != nil {
+		return x.HomeTeam
+	}
+	return ""
+}
+
+func (x *MatchResponse) GetAwayTeam() string {
+	if x != nil {
+		return x.AwayTeam
+	}
+	return ""
+}
+
+func (x *MatchResponse) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *MatchResponse) GetCompetition() string {
+	if x != nil {
+		return x.Competition
+	}
+	return ""
+}
+
+func (x *MatchResponse) GetPeakViewers() int64 {
+	if x != nil {
+		return x.PeakViewers
+	}
+	return 0
+}
+
 // CreateMatchRequest for creating a new match
 type CreateMatchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -189,7 +281,7 @@ type CreateMatchRequest struct {
 
 func (x *CreateMatchRequest) Reset() {
 	*x = CreateMatchRequest{}
-	mi := &file_match_service_proto_match_proto_msgTypes[2]
+	mi := &file_match_service_proto_match_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -201,7 +293,7 @@ func (x *CreateMatchRequest) String() string {
 func (*CreateMatchRequest) ProtoMessage() {}
 
 func (x *CreateMatchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_match_service_proto_match_proto_msgTypes[2]
+	mi := &file_match_service_proto_match_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -214,7 +306,7 @@ func (x *CreateMatchRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateMatchRequest.ProtoReflect.Descriptor instead.
 func (*CreateMatchRequest) Descriptor() ([]byte, []int) {
-	return file_match_service_proto_match_proto_rawDescGZIP(), []int{2}
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CreateMatchRequest) GetMatchId() string {
@@ -247,20 +339,28 @@ func (x *CreateMatchRequest) GetStartTime() string {
 
 // New message for updating match events
 type UpdateMatchEventRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	MatchId         string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
-	EventType       string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`                      // e.g., "goal", "foul", "card", "substitution"
-	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`                                   // e.g., "Messi scores", "Ronaldo gets yellow card"
-	HomeScoreChange int32                  `protobuf:"varint,4,opt,name=home_score_change,json=homeScoreChange,proto3" json:"home_score_change,omitempty"` // Use for goal events
-	AwayScoreChange int32                  `protobuf:"varint,5,opt,name=away_score_change,json=awayScoreChange,proto3" json:"away_score_change,omitempty"` // Use for goal events
-	CardColor       string                 `protobuf:"bytes,6,opt,name=card_color,json=cardColor,proto3" json:"card_color,omitempty"`                      // Use for card events (e.g., "yellow", "red")
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	MatchId string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// "goal", "own_goal", "penalty", "card", "substitution", "var_review", "injury", "foul" or "status_change"
+	EventType          string `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Description        string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`                                            // e.g., "Messi scores", "Ronaldo gets yellow card"; built from the fields below if empty
+	HomeScoreChange    int32  `protobuf:"varint,4,opt,name=home_score_change,json=homeScoreChange,proto3" json:"home_score_change,omitempty"`          // Use for goal events; without it team_side decides who scored
+	AwayScoreChange    int32  `protobuf:"varint,5,opt,name=away_score_change,json=awayScoreChange,proto3" json:"away_score_change,omitempty"`          // Use for goal events
+	CardColor          string `protobuf:"bytes,6,opt,name=card_color,json=cardColor,proto3" json:"card_color,omitempty"`                               // Use for card events (e.g., "yellow", "red"), same as detail
+	OverrideTtlSeconds int64  `protobuf:"varint,7,opt,name=override_ttl_seconds,json=overrideTtlSeconds,proto3" json:"override_ttl_seconds,omitempty"` // How long the correction wins over provider data: 0 for the default, negative until released
+	TeamSide           string `protobuf:"bytes,8,opt,name=team_side,json=teamSide,proto3" json:"team_side,omitempty"`                                  // "home" or "away"; for own goals, the side of the player who scored it
+	PlayerId           string `protobuf:"bytes,9,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`                                  // Scorer, booked player, player coming on or injured player
+	RelatedPlayerId    string `protobuf:"bytes,10,opt,name=related_player_id,json=relatedPlayerId,proto3" json:"related_player_id,omitempty"`          // Assist on goals, player coming off on substitutions
+	Minute             int32  `protobuf:"varint,11,opt,name=minute,proto3" json:"minute,omitempty"`                                                    // Match minute, e.g. 45 for 45+2
+	AddedTime          int32  `protobuf:"varint,12,opt,name=added_time,json=addedTime,proto3" json:"added_time,omitempty"`                             // Minute of added time, e.g. 2 for 45+2
+	Detail             string `protobuf:"bytes,13,opt,name=detail,proto3" json:"detail,omitempty"`                                                     // Cards: "yellow", "second_yellow", "red"; penalties: "scored", "missed", "saved"; VAR reviews: the decision
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *UpdateMatchEventRequest) Reset() {
 	*x = UpdateMatchEventRequest{}
-	mi := &file_match_service_proto_match_proto_msgTypes[3]
+	mi := &file_match_service_proto_match_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -272,7 +372,7 @@ func (x *UpdateMatchEventRequest) String() string {
 func (*UpdateMatchEventRequest) ProtoMessage() {}
 
 func (x *UpdateMatchEventRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_match_service_proto_match_proto_msgTypes[3]
+	mi := &file_match_service_proto_match_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -285,7 +385,7 @@ func (x *UpdateMatchEventRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateMatchEventRequest.ProtoReflect.Descriptor instead.
 func (*UpdateMatchEventRequest) Descriptor() ([]byte, []int) {
-	return file_match_service_proto_match_proto_rawDescGZIP(), []int{3}
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *UpdateMatchEventRequest) GetMatchId() string {
@@ -330,21 +430,133 @@ func (x *UpdateMatchEventRequest) GetCardColor() string {
 	return ""
 }
 
-// Event details (similar to your Event class in the diagram)
-type Event struct {
+func (x *UpdateMatchEventRequest) GetOverrideTtlSeconds() int64 {
+	if x != nil {
+		return x.OverrideTtlSeconds
+	}
+	return 0
+}
+
+func (x *UpdateMatchEventRequest) GetTeamSide() string {
+	if x != nil {
+		return x.TeamSide
+	}
+	return ""
+}
+
+func (x *UpdateMatchEventRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *UpdateMatchEventRequest) GetRelatedPlayerId() string {
+	if x != nil {
+		return x.RelatedPlayerId
+	}
+	return ""
+}
+
+func (x *UpdateMatchEventRequest) GetMinute() int32 {
+	if x != nil {
+		return x.Minute
+	}
+	return 0
+}
+
+func (x *UpdateMatchEventRequest) GetAddedTime() int32 {
+	if x != nil {
+		return x.AddedTime
+	}
+	return 0
+}
+
+func (x *UpdateMatchEventRequest) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+// Releases admin overrides so provider updates apply to the fields again
+type ReleaseMatchOverrideRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
-	MatchId       string                 `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
-	EventType     string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Timestamp     string                 `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // ISO 8601 format
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Fields        []string               `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"` // "score", "status", "cards"; empty releases all
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *ReleaseMatchOverrideRequest) Reset() {
+	*x = ReleaseMatchOverrideRequest{}
+	mi := &file_match_service_proto_match_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseMatchOverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseMatchOverrideRequest) ProtoMessage() {}
+
+func (x *ReleaseMatchOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_match_service_proto_match_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseMatchOverrideRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseMatchOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReleaseMatchOverrideRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *ReleaseMatchOverrideRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// Event details (similar to your Event class in the diagram)
+type Event struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	EventId     string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	MatchId     string                 `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	EventType   string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Timestamp   string                 `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // ISO 8601 format
+	// Structured details, see UpdateMatchEventRequest. Empty on events recorded before they existed.
+	TeamSide        string `protobuf:"bytes,6,opt,name=team_side,json=teamSide,proto3" json:"team_side,omitempty"`
+	PlayerId        string `protobuf:"bytes,7,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	RelatedPlayerId string `protobuf:"bytes,8,opt,name=related_player_id,json=relatedPlayerId,proto3" json:"related_player_id,omitempty"`
+	Minute          int32  `protobuf:"varint,9,opt,name=minute,proto3" json:"minute,omitempty"`
+	AddedTime       int32  `protobuf:"varint,10,opt,name=added_time,json=addedTime,proto3" json:"added_time,omitempty"`
+	Detail          string `protobuf:"bytes,11,opt,name=detail,proto3" json:"detail,omitempty"`
+	Seq             int64  `protobuf:"varint,12,opt,name=seq,proto3" json:"seq,omitempty"`             // Order within the match, from 1; 0 on events recorded before events were numbered
+	Revision        int32  `protobuf:"varint,13,opt,name=revision,proto3" json:"revision,omitempty"`   // Times an admin amended or retracted the event
+	Retracted       bool   `protobuf:"varint,14,opt,name=retracted,proto3" json:"retracted,omitempty"` // Voided by an admin; no longer counts towards the match state
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
 func (x *Event) Reset() {
 	*x = Event{}
-	mi := &file_match_service_proto_match_proto_msgTypes[4]
+	mi := &file_match_service_proto_match_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -356,7 +568,7 @@ func (x *Event) String() string {
 func (*Event) ProtoMessage() {}
 
 func (x *Event) ProtoReflect() protoreflect.Message {
-	mi := &file_match_service_proto_match_proto_msgTypes[4]
+	mi := &file_match_service_proto_match_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -369,7 +581,7 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Event.ProtoReflect.Descriptor instead.
 func (*Event) Descriptor() ([]byte, []int) {
-	return file_match_service_proto_match_proto_rawDescGZIP(), []int{4}
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Event) GetEventId() string {
@@ -407,29 +619,97 @@ func (x *Event) GetTimestamp() string {
 	return ""
 }
 
-// Required for GetAdminMatchList if you add it
-type MatchListResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Matches       []*MatchResponse       `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+func (x *Event) GetTeamSide() string {
+	if x != nil {
+		return x.TeamSide
+	}
+	return ""
+}
+
+func (x *Event) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *Event) GetRelatedPlayerId() string {
+	if x != nil {
+		return x.RelatedPlayerId
+	}
+	return ""
+}
+
+func (x *Event) GetMinute() int32 {
+	if x != nil {
+		return x.Minute
+	}
+	return 0
+}
+
+func (x *Event) GetAddedTime() int32 {
+	if x != nil {
+		return x.AddedTime
+	}
+	return 0
+}
+
+func (x *Event) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *Event) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Event) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *Event) GetRetracted() bool {
+	if x != nil {
+		return x.Retracted
+	}
+	return false
+}
+
+// Corrects an admin event, e.g. a goal entered for the wrong team. The event is replaced by
+// the given one, validated like UpdateMatchEvent, and keeps its ID, seq and time. The match
+// is recomputed from its events and the original is kept in the event's audit trail.
+type AmendMatchEventRequest struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	MatchId       string                   `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	EventId       string                   `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Event         *UpdateMatchEventRequest `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`   // Its match_id is ignored; override_ttl_seconds 0 keeps the original TTL
+	Reason        string                   `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"` // For the audit trail
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MatchListResponse) Reset() {
-	*x = MatchListResponse{}
-	mi := &file_match_service_proto_match_proto_msgTypes[5]
+func (x *AmendMatchEventRequest) Reset() {
+	*x = AmendMatchEventRequest{}
+	mi := &file_match_service_proto_match_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MatchListResponse) String() string {
+func (x *AmendMatchEventRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MatchListResponse) ProtoMessage() {}
+func (*AmendMatchEventRequest) ProtoMessage() {}
 
-func (x *MatchListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_match_service_proto_match_proto_msgTypes[5]
+func (x *AmendMatchEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_match_service_proto_match_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -440,31 +720,986 @@ func (x *MatchListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MatchListResponse.ProtoReflect.Descriptor instead.
-func (*MatchListResponse) Descriptor() ([]byte, []int) {
-	return file_match_service_proto_match_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use AmendMatchEventRequest.ProtoReflect.Descriptor instead.
+func (*AmendMatchEventRequest) Descriptor() ([]byte, []int) {
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *MatchListResponse) GetMatches() []*MatchResponse {
+func (x *AmendMatchEventRequest) GetMatchId() string {
 	if x != nil {
-		return x.Matches
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *AmendMatchEventRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *AmendMatchEventRequest) GetEvent() *UpdateMatchEventRequest {
+	if x != nil {
+		return x.Event
 	}
 	return nil
 }
 
-var File_match_service_proto_match_proto protoreflect.FileDescriptor
+func (x *AmendMatchEventRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
 
-const file_match_service_proto_match_proto_rawDesc = "" +
-	"\n" +
-	"\x1fmatch-service/proto/match.proto\x12\x05match\x1a\x1bgoogle/protobuf/empty.proto\")\n" +
-	"\fMatchRequest\x12\x19\n" +
-	"\bmatch_id\x18\x01 \x01(\tR\amatchId\"\x81\x02\n" +
-	"\rMatchResponse\x12\x19\n" +
-	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
-	"\n" +
-	"home_score\x18\x03 \x01(\x05R\thomeScore\x12\x1d\n" +
-	"\n" +
+// Voids an admin event. The match is recomputed without it; the event is kept in the log.
+type RetractMatchEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	EventId       string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // For the audit trail
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetractMatchEventRequest) Reset() {
+	*x = RetractMatchEventRequest{}
+	mi := &file_match_service_proto_match_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetractMatchEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetractMatchEventRequest) ProtoMessage() {}
+
+func (x *RetractMatchEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_match_service_proto_match_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetractMatchEventRequest.ProtoReflect.Descriptor instead.
+func (*RetractMatchEventRequest) Descriptor() ([]byte, []int) {
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RetractMatchEventRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *RetractMatchEventRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *RetractMatchEventRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type EventCorrectionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *Event                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`                                      // As amended or retracted
+	Match         *MatchResponse         `protobuf:"bytes,2,opt,name=match,proto3" json:"match,omitempty"`                                      // Recomputed state
+	ChangedFields []string               `protobuf:"bytes,3,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"` // See RebuildMatchResponse
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventCorrectionResponse) Reset() {
+	*x = EventCorrectionResponse{}
+	mi := &file_match_service_proto_match_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventCorrectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventCorrectionResponse) ProtoMessage() {}
+
+func (x *EventCorrectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_service_proto_match_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventCorrectionResponse.ProtoReflect.Descriptor instead.
+func (*EventCorrectionResponse) Descriptor() ([]byte, []int) {
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EventCorrectionResponse) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *EventCorrectionResponse) GetMatch() *MatchResponse {
+	if x != nil {
+		return x.Match
+	}
+	return nil
+}
+
+func (x *EventCorrectionResponse) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+// TimelineRequest asks for the events of a match, oldest first
+type TimelineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Since         int64                  `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`                         // Only events after this seq, e.g. latest_seq of an earlier response
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Defaults to 100, at most 500
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page, with the same match and since
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimelineRequest) Reset() {
+	*x = TimelineRequest{}
+	mi := &file_match_service_proto_match_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimelineRequest) ProtoMessage() {}
+
+func (x *TimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_match_service_proto_match_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimelineRequest.ProtoReflect.Descriptor instead.
+func (*TimelineRequest) Descriptor() ([]byte, []int) {
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TimelineRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *TimelineRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *TimelineRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *TimelineRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type TimelineResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	LatestSeq     int64                  `protobuf:"varint,3,opt,name=latest_seq,json=latestSeq,proto3" json:"latest_seq,omitempty"`              // Seq of the last event returned, or since if there were none
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimelineResponse) Reset() {
+	*x = TimelineResponse{}
+	mi := &file_match_service_proto_match_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimelineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimelineResponse) ProtoMessage() {}
+
+func (x *TimelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_service_proto_match_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimelineResponse.ProtoReflect.Descriptor instead.
+func (*TimelineResponse) Descriptor() ([]byte, []int) {
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TimelineResponse) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *TimelineResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *TimelineResponse) GetLatestSeq() int64 {
+	if x != nil {
+		return x.LatestSeq
+	}
+	return 0
+}
+
+// Recomputes the live fields of a match from its event log
+type RebuildMatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebuildMatchRequest) Reset() {
+	*x = RebuildMatchRequest{}
+	mi := &file_match_service_proto_match_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildMatchRequest) ProtoMessage() {}
+
+func (x *RebuildMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_match_service_proto_match_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildMatchRequest.ProtoReflect.Descriptor instead.
+func (*RebuildMatchRequest) Descriptor() ([]byte, []int) {
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RebuildMatchRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+type RebuildMatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Match         *MatchResponse         `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"` // State after the rebuild
+	EventsFolded  int64                  `protobuf:"varint,2,opt,name=events_folded,json=eventsFolded,proto3" json:"events_folded,omitempty"`
+	ChangedFields []string               `protobuf:"bytes,3,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"` // "status", "score", "stats", "cards", "last_event", "overrides"; empty if it was right
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebuildMatchResponse) Reset() {
+	*x = RebuildMatchResponse{}
+	mi := &file_match_service_proto_match_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildMatchResponse) ProtoMessage() {}
+
+func (x *RebuildMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_service_proto_match_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildMatchResponse.ProtoReflect.Descriptor instead.
+func (*RebuildMatchResponse) Descriptor() ([]byte, []int) {
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RebuildMatchResponse) GetMatch() *MatchResponse {
+	if x != nil {
+		return x.Match
+	}
+	return nil
+}
+
+func (x *RebuildMatchResponse) GetEventsFolded() int64 {
+	if x != nil {
+		return x.EventsFolded
+	}
+	return 0
+}
+
+func (x *RebuildMatchResponse) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+// Checks the given matches, or every scheduled and live match if there are none (up to 200)
+type ConsistencyCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchIds      []string               `protobuf:"bytes,1,rep,name=match_ids,json=matchIds,proto3" json:"match_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsistencyCheckRequest) Reset() {
+	*x = ConsistencyCheckRequest{}
+	mi := &file_match_service_proto_match_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsistencyCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsistencyCheckRequest) ProtoMessage() {}
+
+func (x *ConsistencyCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_match_service_proto_match_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsistencyCheckRequest.ProtoReflect.Descriptor instead.
+func (*ConsistencyCheckRequest) Descriptor() ([]byte, []int) {
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ConsistencyCheckRequest) GetMatchIds() []string {
+	if x != nil {
+		return x.MatchIds
+	}
+	return nil
+}
+
+type MatchInconsistency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Fields        []string               `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`                      // Fields whose stored value disagrees with the events, named like changed_fields
+	StateSeq      int64                  `protobuf:"varint,3,opt,name=state_seq,json=stateSeq,proto3" json:"state_seq,omitempty"` // Seq of the last event applied to the stored state
+	LogSeq        int64                  `protobuf:"varint,4,opt,name=log_seq,json=logSeq,proto3" json:"log_seq,omitempty"`       // Seq of the last event in the log
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchInconsistency) Reset() {
+	*x = MatchInconsistency{}
+	mi := &file_match_service_proto_match_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchInconsistency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchInconsistency) ProtoMessage() {}
+
+func (x *MatchInconsistency) ProtoReflect() protoreflect.Message {
+	mi := &file_match_service_proto_match_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchInconsistency.ProtoReflect.Descriptor instead.
+func (*MatchInconsistency) Descriptor() ([]byte, []int) {
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MatchInconsistency) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *MatchInconsistency) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *MatchInconsistency) GetStateSeq() int64 {
+	if x != nil {
+		return x.StateSeq
+	}
+	return 0
+}
+
+func (x *MatchInconsistency) GetLogSeq() int64 {
+	if x != nil {
+		return x.LogSeq
+	}
+	return 0
+}
+
+type ConsistencyCheckResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Checked           int32                  `protobuf:"varint,1,opt,name=checked,proto3" json:"checked,omitempty"`
+	Inconsistencies   []*MatchInconsistency  `protobuf:"bytes,2,rep,name=inconsistencies,proto3" json:"inconsistencies,omitempty"`
+	UnsourcedMatchIds []string               `protobuf:"bytes,3,rep,name=unsourced_match_ids,json=unsourcedMatchIds,proto3" json:"unsourced_match_ids,omitempty"` // Created before events were recorded, so they can't be checked
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ConsistencyCheckResponse) Reset() {
+	*x = ConsistencyCheckResponse{}
+	mi := &file_match_service_proto_match_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsistencyCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsistencyCheckResponse) ProtoMessage() {}
+
+func (x *ConsistencyCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_service_proto_match_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsistencyCheckResponse.ProtoReflect.Descriptor instead.
+func (*ConsistencyCheckResponse) Descriptor() ([]byte, []int) {
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ConsistencyCheckResponse) GetChecked() int32 {
+	if x != nil {
+		return x.Checked
+	}
+	return 0
+}
+
+func (x *ConsistencyCheckResponse) GetInconsistencies() []*MatchInconsistency {
+	if x != nil {
+		return x.Inconsistencies
+	}
+	return nil
+}
+
+func (x *ConsistencyCheckResponse) GetUnsourcedMatchIds() []string {
+	if x != nil {
+		return x.UnsourcedMatchIds
+	}
+	return nil
+}
+
+// Filters, sort order and page for GetAdminMatchList. Empty filters match everything.
+type AdminMatchListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                        // Exact status, case-insensitive
+	Team          string                 `protobuf:"bytes,2,opt,name=team,proto3" json:"team,omitempty"`                            // Part of the home or away team name, case-insensitive
+	Competition   string                 `protobuf:"bytes,3,opt,name=competition,proto3" json:"competition,omitempty"`              // Exact competition, case-insensitive
+	StartFrom     string                 `protobuf:"bytes,4,opt,name=start_from,json=startFrom,proto3" json:"start_from,omitempty"` // RFC 3339, inclusive
+	StartTo       string                 `protobuf:"bytes,5,opt,name=start_to,json=startTo,proto3" json:"start_to,omitempty"`       // RFC 3339, exclusive
+	SortBy        string                 `protobuf:"bytes,6,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`          // "start_time" (default), "status", "home_team", "competition" or "match_id"
+	Descending    bool                   `protobuf:"varint,7,opt,name=descending,proto3" json:"descending,omitempty"`
+	PageSize      int32                  `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Defaults to 50, at most 200
+	PageToken     string                 `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page, with the same filters and sort order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminMatchListRequest) Reset() {
+	*x = AdminMatchListRequest{}
+	mi := &file_match_service_proto_match_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminMatchListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminMatchListRequest) ProtoMessage() {}
+
+func (x *AdminMatchListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_match_service_proto_match_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminMatchListRequest.ProtoReflect.Descriptor instead.
+func (*AdminMatchListRequest) Descriptor() ([]byte, []int) {
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AdminMatchListRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AdminMatchListRequest) GetTeam() string {
+	if x != nil {
+		return x.Team
+	}
+	return ""
+}
+
+func (x *AdminMatchListRequest) GetCompetition() string {
+	if x != nil {
+		return x.Competition
+	}
+	return ""
+}
+
+func (x *AdminMatchListRequest) GetStartFrom() string {
+	if x != nil {
+		return x.StartFrom
+	}
+	return ""
+}
+
+func (x *AdminMatchListRequest) GetStartTo() string {
+	if x != nil {
+		return x.StartTo
+	}
+	return ""
+}
+
+func (x *AdminMatchListRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *AdminMatchListRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *AdminMatchListRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *AdminMatchListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Required for GetAdminMatchList if you add it
+type MatchListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*MatchResponse       `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`           // Matches for the filters across all pages
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchListResponse) Reset() {
+	*x = MatchListResponse{}
+	mi := &file_match_service_proto_match_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchListResponse) ProtoMessage() {}
+
+func (x *MatchListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_service_proto_match_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchListResponse.ProtoReflect.Descriptor instead.
+func (*MatchListResponse) Descriptor() ([]byte, []int) {
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MatchListResponse) GetMatches() []*MatchResponse {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *MatchListResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *MatchListResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// UpdateFrame is a WebSocket update for clients that negotiated the protobuf encoding,
+// sent as a binary message. Match states are always sent whole, as snapshots.
+type UpdateFrame struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "snapshot", "update", "correction" or "viewers"; never "patch", which is JSON only
+	MatchId       string                 `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Seq           int64                  `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`         // Version of the match state after this frame
+	State         *MatchResponse         `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`      // On snapshots
+	Data          []byte                 `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`        // On updates and corrections: the JSON they'd have in the JSON encoding, like admin alerts
+	Viewers       int64                  `protobuf:"varint,6,opt,name=viewers,proto3" json:"viewers,omitempty"` // On "viewers" frames, which have no seq
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFrame) Reset() {
+	*x = UpdateFrame{}
+	mi := &file_match_service_proto_match_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFrame) ProtoMessage() {}
+
+func (x *UpdateFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_match_service_proto_match_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFrame.ProtoReflect.Descriptor instead.
+func (*UpdateFrame) Descriptor() ([]byte, []int) {
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateFrame) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *UpdateFrame) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *UpdateFrame) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *UpdateFrame) GetState() *MatchResponse {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *UpdateFrame) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UpdateFrame) GetViewers() int64 {
+	if x != nil {
+		return x.Viewers
+	}
+	return 0
+}
+
+type MostWatchedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"` // Defaults to 10, at most 50
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MostWatchedRequest) Reset() {
+	*x = MostWatchedRequest{}
+	mi := &file_match_service_proto_match_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MostWatchedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MostWatchedRequest) ProtoMessage() {}
+
+func (x *MostWatchedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_match_service_proto_match_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MostWatchedRequest.ProtoReflect.Descriptor instead.
+func (*MostWatchedRequest) Descriptor() ([]byte, []int) {
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MostWatchedRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type MatchViewers struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Match         *MatchResponse         `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"`
+	Viewers       int64                  `protobuf:"varint,2,opt,name=viewers,proto3" json:"viewers,omitempty"`                            // Watching now
+	PeakViewers   int64                  `protobuf:"varint,3,opt,name=peak_viewers,json=peakViewers,proto3" json:"peak_viewers,omitempty"` // Most at once so far
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchViewers) Reset() {
+	*x = MatchViewers{}
+	mi := &file_match_service_proto_match_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchViewers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchViewers) ProtoMessage() {}
+
+func (x *MatchViewers) ProtoReflect() protoreflect.Message {
+	mi := &file_match_service_proto_match_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchViewers.ProtoReflect.Descriptor instead.
+func (*MatchViewers) Descriptor() ([]byte, []int) {
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *MatchViewers) GetMatch() *MatchResponse {
+	if x != nil {
+		return x.Match
+	}
+	return nil
+}
+
+func (x *MatchViewers) GetViewers() int64 {
+	if x != nil {
+		return x.Viewers
+	}
+	return 0
+}
+
+func (x *MatchViewers) GetPeakViewers() int64 {
+	if x != nil {
+		return x.PeakViewers
+	}
+	return 0
+}
+
+type MostWatchedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*MatchViewers        `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"` // Most viewers first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MostWatchedResponse) Reset() {
+	*x = MostWatchedResponse{}
+	mi := &file_match_service_proto_match_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MostWatchedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MostWatchedResponse) ProtoMessage() {}
+
+func (x *MostWatchedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_match_service_proto_match_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MostWatchedResponse.ProtoReflect.Descriptor instead.
+func (*MostWatchedResponse) Descriptor() ([]byte, []int) {
+	return file_match_service_proto_match_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *MostWatchedResponse) GetMatches() []*MatchViewers {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+var File_match_service_proto_match_proto protoreflect.FileDescriptor
+
+const file_match_service_proto_match_proto_rawDesc = "" +
+	"\n" +
+	"\x1fmatch-service/proto/match.proto\x12\x05match\")\n" +
+	"\fMatchRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\"0\n" +
+	"\x11MultiMatchRequest\x12\x1b\n" +
+	"\tmatch_ids\x18\x01 \x03(\tR\bmatchIds\"\xcc\x03\n" +
+	"\rMatchResponse\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"home_score\x18\x03 \x01(\x05R\thomeScore\x12\x1d\n" +
+	"\n" +
 	"away_score\x18\x04 \x01(\x05R\tawayScore\x12\x1d\n" +
 	"\n" +
 	"last_event\x18\x05 \x01(\tR\tlastEvent\x12\x1e\n" +
@@ -473,13 +1708,21 @@ const file_match_service_proto_match_proto_rawDesc = "" +
 	"possession\x12\x14\n" +
 	"\x05shots\x18\a \x01(\x05R\x05shots\x12\x14\n" +
 	"\x05fouls\x18\b \x01(\x05R\x05fouls\x12\x14\n" +
-	"\x05cards\x18\t \x03(\tR\x05cards\"\x88\x01\n" +
+	"\x05cards\x18\t \x03(\tR\x05cards\x12+\n" +
+	"\x11overridden_fields\x18\n" +
+	" \x03(\tR\x10overriddenFields\x12\x1b\n" +
+	"\thome_team\x18\v \x01(\tR\bhomeTeam\x12\x1b\n" +
+	"\taway_team\x18\f \x01(\tR\bawayTeam\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\r \x01(\tR\tstartTime\x12 \n" +
+	"\vcompetition\x18\x0e \x01(\tR\vcompetition\x12!\n" +
+	"\fpeak_viewers\x18\x0f \x01(\x03R\vpeakViewers\"\x88\x01\n" +
 	"\x12CreateMatchRequest\x12\x19\n" +
 	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x1b\n" +
 	"\thome_team\x18\x02 \x01(\tR\bhomeTeam\x12\x1b\n" +
 	"\taway_team\x18\x03 \x01(\tR\bawayTeam\x12\x1d\n" +
 	"\n" +
-	"start_time\x18\x04 \x01(\tR\tstartTime\"\xec\x01\n" +
+	"start_time\x18\x04 \x01(\tR\tstartTime\"\xd3\x03\n" +
 	"\x17UpdateMatchEventRequest\x12\x19\n" +
 	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x1d\n" +
 	"\n" +
@@ -488,21 +1731,126 @@ const file_match_service_proto_match_proto_rawDesc = "" +
 	"\x11home_score_change\x18\x04 \x01(\x05R\x0fhomeScoreChange\x12*\n" +
 	"\x11away_score_change\x18\x05 \x01(\x05R\x0fawayScoreChange\x12\x1d\n" +
 	"\n" +
-	"card_color\x18\x06 \x01(\tR\tcardColor\"\x9c\x01\n" +
+	"card_color\x18\x06 \x01(\tR\tcardColor\x120\n" +
+	"\x14override_ttl_seconds\x18\a \x01(\x03R\x12overrideTtlSeconds\x12\x1b\n" +
+	"\tteam_side\x18\b \x01(\tR\bteamSide\x12\x1b\n" +
+	"\tplayer_id\x18\t \x01(\tR\bplayerId\x12*\n" +
+	"\x11related_player_id\x18\n" +
+	" \x01(\tR\x0frelatedPlayerId\x12\x16\n" +
+	"\x06minute\x18\v \x01(\x05R\x06minute\x12\x1d\n" +
+	"\n" +
+	"added_time\x18\f \x01(\x05R\taddedTime\x12\x16\n" +
+	"\x06detail\x18\r \x01(\tR\x06detail\"P\n" +
+	"\x1bReleaseMatchOverrideRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x16\n" +
+	"\x06fields\x18\x02 \x03(\tR\x06fields\"\x9d\x03\n" +
 	"\x05Event\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x19\n" +
 	"\bmatch_id\x18\x02 \x01(\tR\amatchId\x12\x1d\n" +
 	"\n" +
 	"event_type\x18\x03 \x01(\tR\teventType\x12 \n" +
 	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1c\n" +
-	"\ttimestamp\x18\x05 \x01(\tR\ttimestamp\"C\n" +
+	"\ttimestamp\x18\x05 \x01(\tR\ttimestamp\x12\x1b\n" +
+	"\tteam_side\x18\x06 \x01(\tR\bteamSide\x12\x1b\n" +
+	"\tplayer_id\x18\a \x01(\tR\bplayerId\x12*\n" +
+	"\x11related_player_id\x18\b \x01(\tR\x0frelatedPlayerId\x12\x16\n" +
+	"\x06minute\x18\t \x01(\x05R\x06minute\x12\x1d\n" +
+	"\n" +
+	"added_time\x18\n" +
+	" \x01(\x05R\taddedTime\x12\x16\n" +
+	"\x06detail\x18\v \x01(\tR\x06detail\x12\x10\n" +
+	"\x03seq\x18\f \x01(\x03R\x03seq\x12\x1a\n" +
+	"\brevision\x18\r \x01(\x05R\brevision\x12\x1c\n" +
+	"\tretracted\x18\x0e \x01(\bR\tretracted\"\x9c\x01\n" +
+	"\x16AmendMatchEventRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x124\n" +
+	"\x05event\x18\x03 \x01(\v2\x1e.match.UpdateMatchEventRequestR\x05event\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"h\n" +
+	"\x18RetractMatchEventRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x90\x01\n" +
+	"\x17EventCorrectionResponse\x12\"\n" +
+	"\x05event\x18\x01 \x01(\v2\f.match.EventR\x05event\x12*\n" +
+	"\x05match\x18\x02 \x01(\v2\x14.match.MatchResponseR\x05match\x12%\n" +
+	"\x0echanged_fields\x18\x03 \x03(\tR\rchangedFields\"~\n" +
+	"\x0fTimelineRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x14\n" +
+	"\x05since\x18\x02 \x01(\x03R\x05since\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"\x7f\n" +
+	"\x10TimelineResponse\x12$\n" +
+	"\x06events\x18\x01 \x03(\v2\f.match.EventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"latest_seq\x18\x03 \x01(\x03R\tlatestSeq\"0\n" +
+	"\x13RebuildMatchRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\"\x8e\x01\n" +
+	"\x14RebuildMatchResponse\x12*\n" +
+	"\x05match\x18\x01 \x01(\v2\x14.match.MatchResponseR\x05match\x12#\n" +
+	"\revents_folded\x18\x02 \x01(\x03R\feventsFolded\x12%\n" +
+	"\x0echanged_fields\x18\x03 \x03(\tR\rchangedFields\"6\n" +
+	"\x17ConsistencyCheckRequest\x12\x1b\n" +
+	"\tmatch_ids\x18\x01 \x03(\tR\bmatchIds\"}\n" +
+	"\x12MatchInconsistency\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x16\n" +
+	"\x06fields\x18\x02 \x03(\tR\x06fields\x12\x1b\n" +
+	"\tstate_seq\x18\x03 \x01(\x03R\bstateSeq\x12\x17\n" +
+	"\alog_seq\x18\x04 \x01(\x03R\x06logSeq\"\xa9\x01\n" +
+	"\x18ConsistencyCheckResponse\x12\x18\n" +
+	"\achecked\x18\x01 \x01(\x05R\achecked\x12C\n" +
+	"\x0finconsistencies\x18\x02 \x03(\v2\x19.match.MatchInconsistencyR\x0finconsistencies\x12.\n" +
+	"\x13unsourced_match_ids\x18\x03 \x03(\tR\x11unsourcedMatchIds\"\x94\x02\n" +
+	"\x15AdminMatchListRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x12\n" +
+	"\x04team\x18\x02 \x01(\tR\x04team\x12 \n" +
+	"\vcompetition\x18\x03 \x01(\tR\vcompetition\x12\x1d\n" +
+	"\n" +
+	"start_from\x18\x04 \x01(\tR\tstartFrom\x12\x19\n" +
+	"\bstart_to\x18\x05 \x01(\tR\astartTo\x12\x17\n" +
+	"\asort_by\x18\x06 \x01(\tR\x06sortBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\a \x01(\bR\n" +
+	"descending\x12\x1b\n" +
+	"\tpage_size\x18\b \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\t \x01(\tR\tpageToken\"\x8c\x01\n" +
 	"\x11MatchListResponse\x12.\n" +
-	"\amatches\x18\x01 \x03(\v2\x14.match.MatchResponseR\amatches2\x9d\x02\n" +
+	"\amatches\x18\x01 \x03(\v2\x14.match.MatchResponseR\amatches\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"\xa8\x01\n" +
+	"\vUpdateFrame\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x19\n" +
+	"\bmatch_id\x18\x02 \x01(\tR\amatchId\x12\x10\n" +
+	"\x03seq\x18\x03 \x01(\x03R\x03seq\x12*\n" +
+	"\x05state\x18\x04 \x01(\v2\x14.match.MatchResponseR\x05state\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\fR\x04data\x12\x18\n" +
+	"\aviewers\x18\x06 \x01(\x03R\aviewers\"*\n" +
+	"\x12MostWatchedRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"w\n" +
+	"\fMatchViewers\x12*\n" +
+	"\x05match\x18\x01 \x01(\v2\x14.match.MatchResponseR\x05match\x12\x18\n" +
+	"\aviewers\x18\x02 \x01(\x03R\aviewers\x12!\n" +
+	"\fpeak_viewers\x18\x03 \x01(\x03R\vpeakViewers\"D\n" +
+	"\x13MostWatchedResponse\x12-\n" +
+	"\amatches\x18\x01 \x03(\v2\x13.match.MatchViewersR\amatches2\xe5\a\n" +
 	"\fMatchService\x12<\n" +
-	"\x0fGetMatchUpdates\x12\x13.match.MatchRequest\x1a\x14.match.MatchResponse\x12>\n" +
+	"\x0fGetMatchUpdates\x12\x13.match.MatchRequest\x1a\x14.match.MatchResponse\x12A\n" +
+	"\x12StreamMatchUpdates\x12\x13.match.MatchRequest\x1a\x14.match.MatchResponse0\x01\x12K\n" +
+	"\x17StreamMultiMatchUpdates\x12\x18.match.MultiMatchRequest\x1a\x14.match.MatchResponse0\x01\x12>\n" +
 	"\vCreateMatch\x12\x19.match.CreateMatchRequest\x1a\x14.match.MatchResponse\x12H\n" +
-	"\x10UpdateMatchEvent\x12\x1e.match.UpdateMatchEventRequest\x1a\x14.match.MatchResponse\x12E\n" +
-	"\x11GetAdminMatchList\x12\x16.google.protobuf.Empty\x1a\x18.match.MatchListResponseB@Z>github.com/abaika-abay/live_sports_project/match-service/protob\x06proto3"
+	"\x10UpdateMatchEvent\x12\x1e.match.UpdateMatchEventRequest\x1a\x14.match.MatchResponse\x12P\n" +
+	"\x0fAmendMatchEvent\x12\x1d.match.AmendMatchEventRequest\x1a\x1e.match.EventCorrectionResponse\x12T\n" +
+	"\x11RetractMatchEvent\x12\x1f.match.RetractMatchEventRequest\x1a\x1e.match.EventCorrectionResponse\x12P\n" +
+	"\x14ReleaseMatchOverride\x12\".match.ReleaseMatchOverrideRequest\x1a\x14.match.MatchResponse\x12K\n" +
+	"\x11GetAdminMatchList\x12\x1c.match.AdminMatchListRequest\x1a\x18.match.MatchListResponse\x12C\n" +
+	"\x10GetMatchTimeline\x12\x16.match.TimelineRequest\x1a\x17.match.TimelineResponse\x12N\n" +
+	"\x15GetMostWatchedMatches\x12\x19.match.MostWatchedRequest\x1a\x1a.match.MostWatchedResponse\x12G\n" +
+	"\fRebuildMatch\x12\x1a.match.RebuildMatchRequest\x1a\x1b.match.RebuildMatchResponse\x12X\n" +
+	"\x15CheckMatchConsistency\x12\x1e.match.ConsistencyCheckRequest\x1a\x1f.match.ConsistencyCheckResponseB@Z>github.com/abaika-abay/live_sports_project/match-service/protob\x06proto3"
 
 var (
 	file_match_service_proto_match_proto_rawDescOnce sync.Once
@@ -516,31 +1864,74 @@ func file_match_service_proto_match_proto_rawDescGZIP() []byte {
 	return file_match_service_proto_match_proto_rawDescData
 }
 
-var file_match_service_proto_match_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_match_service_proto_match_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_match_service_proto_match_proto_goTypes = []any{
-	(*MatchRequest)(nil),            // 0: match.MatchRequest
-	(*MatchResponse)(nil),           // 1: match.MatchResponse
-	(*CreateMatchRequest)(nil),      // 2: match.CreateMatchRequest
-	(*UpdateMatchEventRequest)(nil), // 3: match.UpdateMatchEventRequest
-	(*Event)(nil),                   // 4: match.Event
-	(*MatchListResponse)(nil),       // 5: match.MatchListResponse
-	(*emptypb.Empty)(nil),           // 6: google.protobuf.Empty
+	(*MatchRequest)(nil),                // 0: match.MatchRequest
+	(*MultiMatchRequest)(nil),           // 1: match.MultiMatchRequest
+	(*MatchResponse)(nil),               // 2: match.MatchResponse
+	(*CreateMatchRequest)(nil),          // 3: match.CreateMatchRequest
+	(*UpdateMatchEventRequest)(nil),     // 4: match.UpdateMatchEventRequest
+	(*ReleaseMatchOverrideRequest)(nil), // 5: match.ReleaseMatchOverrideRequest
+	(*Event)(nil),                       // 6: match.Event
+	(*AmendMatchEventRequest)(nil),      // 7: match.AmendMatchEventRequest
+	(*RetractMatchEventRequest)(nil),    // 8: match.RetractMatchEventRequest
+	(*EventCorrectionResponse)(nil),     // 9: match.EventCorrectionResponse
+	(*TimelineRequest)(nil),             // 10: match.TimelineRequest
+	(*TimelineResponse)(nil),            // 11: match.TimelineResponse
+	(*RebuildMatchRequest)(nil),         // 12: match.RebuildMatchRequest
+	(*RebuildMatchResponse)(nil),        // 13: match.RebuildMatchResponse
+	(*ConsistencyCheckRequest)(nil),     // 14: match.ConsistencyCheckRequest
+	(*MatchInconsistency)(nil),          // 15: match.MatchInconsistency
+	(*ConsistencyCheckResponse)(nil),    // 16: match.ConsistencyCheckResponse
+	(*AdminMatchListRequest)(nil),       // 17: match.AdminMatchListRequest
+	(*MatchListResponse)(nil),           // 18: match.MatchListResponse
+	(*UpdateFrame)(nil),                 // 19: match.UpdateFrame
+	(*MostWatchedRequest)(nil),          // 20: match.MostWatchedRequest
+	(*MatchViewers)(nil),                // 21: match.MatchViewers
+	(*MostWatchedResponse)(nil),         // 22: match.MostWatchedResponse
 }
 var file_match_service_proto_match_proto_depIdxs = []int32{
-	1, // 0: match.MatchListResponse.matches:type_name -> match.MatchResponse
-	0, // 1: match.MatchService.GetMatchUpdates:input_type -> match.MatchRequest
-	2, // 2: match.MatchService.CreateMatch:input_type -> match.CreateMatchRequest
-	3, // 3: match.MatchService.UpdateMatchEvent:input_type -> match.UpdateMatchEventRequest
-	6, // 4: match.MatchService.GetAdminMatchList:input_type -> google.protobuf.Empty
-	1, // 5: match.MatchService.GetMatchUpdates:output_type -> match.MatchResponse
-	1, // 6: match.MatchService.CreateMatch:output_type -> match.MatchResponse
-	1, // 7: match.MatchService.UpdateMatchEvent:output_type -> match.MatchResponse
-	5, // 8: match.MatchService.GetAdminMatchList:output_type -> match.MatchListResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	4,  // 0: match.AmendMatchEventRequest.event:type_name -> match.UpdateMatchEventRequest
+	6,  // 1: match.EventCorrectionResponse.event:type_name -> match.Event
+	2,  // 2: match.EventCorrectionResponse.match:type_name -> match.MatchResponse
+	6,  // 3: match.TimelineResponse.events:type_name -> match.Event
+	2,  // 4: match.RebuildMatchResponse.match:type_name -> match.MatchResponse
+	15, // 5: match.ConsistencyCheckResponse.inconsistencies:type_name -> match.MatchInconsistency
+	2,  // 6: match.MatchListResponse.matches:type_name -> match.MatchResponse
+	2,  // 7: match.UpdateFrame.state:type_name -> match.MatchResponse
+	2,  // 8: match.MatchViewers.match:type_name -> match.MatchResponse
+	21, // 9: match.MostWatchedResponse.matches:type_name -> match.MatchViewers
+	0,  // 10: match.MatchService.GetMatchUpdates:input_type -> match.MatchRequest
+	0,  // 11: match.MatchService.StreamMatchUpdates:input_type -> match.MatchRequest
+	1,  // 12: match.MatchService.StreamMultiMatchUpdates:input_type -> match.MultiMatchRequest
+	3,  // 13: match.MatchService.CreateMatch:input_type -> match.CreateMatchRequest
+	4,  // 14: match.MatchService.UpdateMatchEvent:input_type -> match.UpdateMatchEventRequest
+	7,  // 15: match.MatchService.AmendMatchEvent:input_type -> match.AmendMatchEventRequest
+	8,  // 16: match.MatchService.RetractMatchEvent:input_type -> match.RetractMatchEventRequest
+	5,  // 17: match.MatchService.ReleaseMatchOverride:input_type -> match.ReleaseMatchOverrideRequest
+	17, // 18: match.MatchService.GetAdminMatchList:input_type -> match.AdminMatchListRequest
+	10, // 19: match.MatchService.GetMatchTimeline:input_type -> match.TimelineRequest
+	20, // 20: match.MatchService.GetMostWatchedMatches:input_type -> match.MostWatchedRequest
+	12, // 21: match.MatchService.RebuildMatch:input_type -> match.RebuildMatchRequest
+	14, // 22: match.MatchService.CheckMatchConsistency:input_type -> match.ConsistencyCheckRequest
+	2,  // 23: match.MatchService.GetMatchUpdates:output_type -> match.MatchResponse
+	2,  // 24: match.MatchService.StreamMatchUpdates:output_type -> match.MatchResponse
+	2,  // 25: match.MatchService.StreamMultiMatchUpdates:output_type -> match.MatchResponse
+	2,  // 26: match.MatchService.CreateMatch:output_type -> match.MatchResponse
+	2,  // 27: match.MatchService.UpdateMatchEvent:output_type -> match.MatchResponse
+	9,  // 28: match.MatchService.AmendMatchEvent:output_type -> match.EventCorrectionResponse
+	9,  // 29: match.MatchService.RetractMatchEvent:output_type -> match.EventCorrectionResponse
+	2,  // 30: match.MatchService.ReleaseMatchOverride:output_type -> match.MatchResponse
+	18, // 31: match.MatchService.GetAdminMatchList:output_type -> match.MatchListResponse
+	11, // 32: match.MatchService.GetMatchTimeline:output_type -> match.TimelineResponse
+	22, // 33: match.MatchService.GetMostWatchedMatches:output_type -> match.MostWatchedResponse
+	13, // 34: match.MatchService.RebuildMatch:output_type -> match.RebuildMatchResponse
+	16, // 35: match.MatchService.CheckMatchConsistency:output_type -> match.ConsistencyCheckResponse
+	23, // [23:36] is the sub-list for method output_type
+	10, // [10:23] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_match_service_proto_match_proto_init() }
@@ -554,7 +1945,7 @@ func file_match_service_proto_match_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_match_service_proto_match_proto_rawDesc), len(file_match_service_proto_match_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
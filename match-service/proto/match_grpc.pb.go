@@ -11,7 +11,6 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -20,10 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MatchService_GetMatchUpdates_FullMethodName   = "/match.MatchService/GetMatchUpdates"
-	MatchService_CreateMatch_FullMethodName       = "/match.MatchService/CreateMatch"
-	MatchService_UpdateMatchEvent_FullMethodName  = "/match.MatchService/UpdateMatchEvent"
-	MatchService_GetAdminMatchList_FullMethodName = "/match.MatchService/GetAdminMatchList"
+	MatchService_GetMatchUpdates_FullMethodName         = "/match.MatchService/GetMatchUpdates"
+	MatchService_StreamMatchUpdates_FullMethodName      = "/match.MatchService/StreamMatchUpdates"
+	MatchService_StreamMultiMatchUpdates_FullMethodName = "/match.MatchService/StreamMultiMatchUpdates"
+	MatchService_CreateMatch_FullMethodName             = "/match.MatchService/CreateMatch"
+	MatchService_UpdateMatchEvent_FullMethodName        = "/match.MatchService/UpdateMatchEvent"
+	MatchService_AmendMatchEvent_FullMethodName         = "/match.MatchService/AmendMatchEvent"
+	MatchService_RetractMatchEvent_FullMethodName       = "/match.MatchService/RetractMatchEvent"
+	MatchService_ReleaseMatchOverride_FullMethodName    = "/match.MatchService/ReleaseMatchOverride"
+	MatchService_GetAdminMatchList_FullMethodName       = "/match.MatchService/GetAdminMatchList"
+	MatchService_GetMatchTimeline_FullMethodName        = "/match.MatchService/GetMatchTimeline"
+	MatchService_GetMostWatchedMatches_FullMethodName   = "/match.MatchService/GetMostWatchedMatches"
+	MatchService_RebuildMatch_FullMethodName            = "/match.MatchService/RebuildMatch"
+	MatchService_CheckMatchConsistency_FullMethodName   = "/match.MatchService/CheckMatchConsistency"
 )
 
 // MatchServiceClient is the client API for MatchService service.
@@ -31,11 +39,29 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type MatchServiceClient interface {
 	GetMatchUpdates(ctx context.Context, in *MatchRequest, opts ...grpc.CallOption) (*MatchResponse, error)
+	// Current state of a match, then every update, pushed as it happens
+	StreamMatchUpdates(ctx context.Context, in *MatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchResponse], error)
+	// Same as StreamMatchUpdates for several matches, up to 50
+	StreamMultiMatchUpdates(ctx context.Context, in *MultiMatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchResponse], error)
 	CreateMatch(ctx context.Context, in *CreateMatchRequest, opts ...grpc.CallOption) (*MatchResponse, error)
 	// New RPC for admin to update match events
 	UpdateMatchEvent(ctx context.Context, in *UpdateMatchEventRequest, opts ...grpc.CallOption) (*MatchResponse, error)
+	// Correct or void an admin event; subscribers get a "correction" frame, then the new state
+	// Sending the same correction again recomputes the match, e.g. after that failed
+	AmendMatchEvent(ctx context.Context, in *AmendMatchEventRequest, opts ...grpc.CallOption) (*EventCorrectionResponse, error)
+	RetractMatchEvent(ctx context.Context, in *RetractMatchEventRequest, opts ...grpc.CallOption) (*EventCorrectionResponse, error)
+	// Hands overridden fields back to the providers
+	ReleaseMatchOverride(ctx context.Context, in *ReleaseMatchOverrideRequest, opts ...grpc.CallOption) (*MatchResponse, error)
 	// Optional: RPC for getting a list of matches for admin panel
-	GetAdminMatchList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MatchListResponse, error)
+	GetAdminMatchList(ctx context.Context, in *AdminMatchListRequest, opts ...grpc.CallOption) (*MatchListResponse, error)
+	// Play-by-play events of a match, oldest first
+	GetMatchTimeline(ctx context.Context, in *TimelineRequest, opts ...grpc.CallOption) (*TimelineResponse, error)
+	// Matches with the most live viewers, across all replicas
+	GetMostWatchedMatches(ctx context.Context, in *MostWatchedRequest, opts ...grpc.CallOption) (*MostWatchedResponse, error)
+	// Admin: recomputes a match from its event log, e.g. after a consistency check reported it
+	RebuildMatch(ctx context.Context, in *RebuildMatchRequest, opts ...grpc.CallOption) (*RebuildMatchResponse, error)
+	// Admin: reports matches whose stored state disagrees with their event log
+	CheckMatchConsistency(ctx context.Context, in *ConsistencyCheckRequest, opts ...grpc.CallOption) (*ConsistencyCheckResponse, error)
 }
 
 type matchServiceClient struct {
@@ -56,6 +82,44 @@ func (c *matchServiceClient) GetMatchUpdates(ctx context.Context, in *MatchReque
 	return out, nil
 }
 
+func (c *matchServiceClient) StreamMatchUpdates(ctx context.Context, in *MatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MatchService_ServiceDesc.Streams[0], MatchService_StreamMatchUpdates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[MatchRequest, MatchResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchService_StreamMatchUpdatesClient = grpc.ServerStreamingClient[MatchResponse]
+
+func (c *matchServiceClient) StreamMultiMatchUpdates(ctx context.Context, in *MultiMatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MatchService_ServiceDesc.Streams[1], MatchService_StreamMultiMatchUpdates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[MultiMatchRequest, MatchResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchService_StreamMultiMatchUpdatesClient = grpc.ServerStreamingClient[MatchResponse]
+
 func (c *matchServiceClient) CreateMatch(ctx context.Context, in *CreateMatchRequest, opts ...grpc.CallOption) (*MatchResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(MatchResponse)
@@ -76,7 +140,37 @@ func (c *matchServiceClient) UpdateMatchEvent(ctx context.Context, in *UpdateMat
 	return out, nil
 }
 
-func (c *matchServiceClient) GetAdminMatchList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MatchListResponse, error) {
+func (c *matchServiceClient) AmendMatchEvent(ctx context.Context, in *AmendMatchEventRequest, opts ...grpc.CallOption) (*EventCorrectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EventCorrectionResponse)
+	err := c.cc.Invoke(ctx, MatchService_AmendMatchEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) RetractMatchEvent(ctx context.Context, in *RetractMatchEventRequest, opts ...grpc.CallOption) (*EventCorrectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EventCorrectionResponse)
+	err := c.cc.Invoke(ctx, MatchService_RetractMatchEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) ReleaseMatchOverride(ctx context.Context, in *ReleaseMatchOverrideRequest, opts ...grpc.CallOption) (*MatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchResponse)
+	err := c.cc.Invoke(ctx, MatchService_ReleaseMatchOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) GetAdminMatchList(ctx context.Context, in *AdminMatchListRequest, opts ...grpc.CallOption) (*MatchListResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(MatchListResponse)
 	err := c.cc.Invoke(ctx, MatchService_GetAdminMatchList_FullMethodName, in, out, cOpts...)
@@ -86,16 +180,74 @@ func (c *matchServiceClient) GetAdminMatchList(ctx context.Context, in *emptypb.
 	return out, nil
 }
 
+func (c *matchServiceClient) GetMatchTimeline(ctx context.Context, in *TimelineRequest, opts ...grpc.CallOption) (*TimelineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimelineResponse)
+	err := c.cc.Invoke(ctx, MatchService_GetMatchTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) GetMostWatchedMatches(ctx context.Context, in *MostWatchedRequest, opts ...grpc.CallOption) (*MostWatchedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MostWatchedResponse)
+	err := c.cc.Invoke(ctx, MatchService_GetMostWatchedMatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) RebuildMatch(ctx context.Context, in *RebuildMatchRequest, opts ...grpc.CallOption) (*RebuildMatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RebuildMatchResponse)
+	err := c.cc.Invoke(ctx, MatchService_RebuildMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) CheckMatchConsistency(ctx context.Context, in *ConsistencyCheckRequest, opts ...grpc.CallOption) (*ConsistencyCheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConsistencyCheckResponse)
+	err := c.cc.Invoke(ctx, MatchService_CheckMatchConsistency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MatchServiceServer is the server API for MatchService service.
 // All implementations must embed UnimplementedMatchServiceServer
 // for forward compatibility.
 type MatchServiceServer interface {
 	GetMatchUpdates(context.Context, *MatchRequest) (*MatchResponse, error)
+	// Current state of a match, then every update, pushed as it happens
+	StreamMatchUpdates(*MatchRequest, grpc.ServerStreamingServer[MatchResponse]) error
+	// Same as StreamMatchUpdates for several matches, up to 50
+	StreamMultiMatchUpdates(*MultiMatchRequest, grpc.ServerStreamingServer[MatchResponse]) error
 	CreateMatch(context.Context, *CreateMatchRequest) (*MatchResponse, error)
 	// New RPC for admin to update match events
 	UpdateMatchEvent(context.Context, *UpdateMatchEventRequest) (*MatchResponse, error)
+	// Correct or void an admin event; subscribers get a "correction" frame, then the new state
+	// Sending the same correction again recomputes the match, e.g. after that failed
+	AmendMatchEvent(context.Context, *AmendMatchEventRequest) (*EventCorrectionResponse, error)
+	RetractMatchEvent(context.Context, *RetractMatchEventRequest) (*EventCorrectionResponse, error)
+	// Hands overridden fields back to the providers
+	ReleaseMatchOverride(context.Context, *ReleaseMatchOverrideRequest) (*MatchResponse, error)
 	// Optional: RPC for getting a list of matches for admin panel
-	GetAdminMatchList(context.Context, *emptypb.Empty) (*MatchListResponse, error)
+	GetAdminMatchList(context.Context, *AdminMatchListRequest) (*MatchListResponse, error)
+	// Play-by-play events of a match, oldest first
+	GetMatchTimeline(context.Context, *TimelineRequest) (*TimelineResponse, error)
+	// Matches with the most live viewers, across all replicas
+	GetMostWatchedMatches(context.Context, *MostWatchedRequest) (*MostWatchedResponse, error)
+	// Admin: recomputes a match from its event log, e.g. after a consistency check reported it
+	RebuildMatch(context.Context, *RebuildMatchRequest) (*RebuildMatchResponse, error)
+	// Admin: reports matches whose stored state disagrees with their event log
+	CheckMatchConsistency(context.Context, *ConsistencyCheckRequest) (*ConsistencyCheckResponse, error)
 	mustEmbedUnimplementedMatchServiceServer()
 }
 
@@ -109,15 +261,42 @@ type UnimplementedMatchServiceServer struct{}
 func (UnimplementedMatchServiceServer) GetMatchUpdates(context.Context, *MatchRequest) (*MatchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMatchUpdates not implemented")
 }
+func (UnimplementedMatchServiceServer) StreamMatchUpdates(*MatchRequest, grpc.ServerStreamingServer[MatchResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMatchUpdates not implemented")
+}
+func (UnimplementedMatchServiceServer) StreamMultiMatchUpdates(*MultiMatchRequest, grpc.ServerStreamingServer[MatchResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMultiMatchUpdates not implemented")
+}
 func (UnimplementedMatchServiceServer) CreateMatch(context.Context, *CreateMatchRequest) (*MatchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateMatch not implemented")
 }
 func (UnimplementedMatchServiceServer) UpdateMatchEvent(context.Context, *UpdateMatchEventRequest) (*MatchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateMatchEvent not implemented")
 }
-func (UnimplementedMatchServiceServer) GetAdminMatchList(context.Context, *emptypb.Empty) (*MatchListResponse, error) {
+func (UnimplementedMatchServiceServer) AmendMatchEvent(context.Context, *AmendMatchEventRequest) (*EventCorrectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AmendMatchEvent not implemented")
+}
+func (UnimplementedMatchServiceServer) RetractMatchEvent(context.Context, *RetractMatchEventRequest) (*EventCorrectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetractMatchEvent not implemented")
+}
+func (UnimplementedMatchServiceServer) ReleaseMatchOverride(context.Context, *ReleaseMatchOverrideRequest) (*MatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseMatchOverride not implemented")
+}
+func (UnimplementedMatchServiceServer) GetAdminMatchList(context.Context, *AdminMatchListRequest) (*MatchListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAdminMatchList not implemented")
 }
+func (UnimplementedMatchServiceServer) GetMatchTimeline(context.Context, *TimelineRequest) (*TimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMatchTimeline not implemented")
+}
+func (UnimplementedMatchServiceServer) GetMostWatchedMatches(context.Context, *MostWatchedRequest) (*MostWatchedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMostWatchedMatches not implemented")
+}
+func (UnimplementedMatchServiceServer) RebuildMatch(context.Context, *RebuildMatchRequest) (*RebuildMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebuildMatch not implemented")
+}
+func (UnimplementedMatchServiceServer) CheckMatchConsistency(context.Context, *ConsistencyCheckRequest) (*ConsistencyCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckMatchConsistency not implemented")
+}
 func (UnimplementedMatchServiceServer) mustEmbedUnimplementedMatchServiceServer() {}
 func (UnimplementedMatchServiceServer) testEmbeddedByValue()                      {}
 
@@ -157,6 +336,28 @@ func _MatchService_GetMatchUpdates_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MatchService_StreamMatchUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchServiceServer).StreamMatchUpdates(m, &grpc.GenericServerStream[MatchRequest, MatchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchService_StreamMatchUpdatesServer = grpc.ServerStreamingServer[MatchResponse]
+
+func _MatchService_StreamMultiMatchUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MultiMatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchServiceServer).StreamMultiMatchUpdates(m, &grpc.GenericServerStream[MultiMatchRequest, MatchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchService_StreamMultiMatchUpdatesServer = grpc.ServerStreamingServer[MatchResponse]
+
 func _MatchService_CreateMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateMatchRequest)
 	if err := dec(in); err != nil {
@@ -193,8 +394,62 @@ func _MatchService_UpdateMatchEvent_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MatchService_AmendMatchEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AmendMatchEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).AmendMatchEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_AmendMatchEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).AmendMatchEvent(ctx, req.(*AmendMatchEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_RetractMatchEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetractMatchEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).RetractMatchEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_RetractMatchEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).RetractMatchEvent(ctx, req.(*RetractMatchEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_ReleaseMatchOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseMatchOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).ReleaseMatchOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_ReleaseMatchOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).ReleaseMatchOverride(ctx, req.(*ReleaseMatchOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MatchService_GetAdminMatchList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(AdminMatchListRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -206,7 +461,79 @@ func _MatchService_GetAdminMatchList_Handler(srv interface{}, ctx context.Contex
 		FullMethod: MatchService_GetAdminMatchList_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MatchServiceServer).GetAdminMatchList(ctx, req.(*emptypb.Empty))
+		return srv.(MatchServiceServer).GetAdminMatchList(ctx, req.(*AdminMatchListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_GetMatchTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).GetMatchTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_GetMatchTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).GetMatchTimeline(ctx, req.(*TimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_GetMostWatchedMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MostWatchedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).GetMostWatchedMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_GetMostWatchedMatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).GetMostWatchedMatches(ctx, req.(*MostWatchedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_RebuildMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebuildMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).RebuildMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_RebuildMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).RebuildMatch(ctx, req.(*RebuildMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_CheckMatchConsistency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsistencyCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).CheckMatchConsistency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_CheckMatchConsistency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).CheckMatchConsistency(ctx, req.(*ConsistencyCheckRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -230,11 +557,50 @@ var MatchService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateMatchEvent",
 			Handler:    _MatchService_UpdateMatchEvent_Handler,
 		},
+		{
+			MethodName: "AmendMatchEvent",
+			Handler:    _MatchService_AmendMatchEvent_Handler,
+		},
+		{
+			MethodName: "RetractMatchEvent",
+			Handler:    _MatchService_RetractMatchEvent_Handler,
+		},
+		{
+			MethodName: "ReleaseMatchOverride",
+			Handler:    _MatchService_ReleaseMatchOverride_Handler,
+		},
 		{
 			MethodName: "GetAdminMatchList",
 			Handler:    _MatchService_GetAdminMatchList_Handler,
 		},
+		{
+			MethodName: "GetMatchTimeline",
+			Handler:    _MatchService_GetMatchTimeline_Handler,
+		},
+		{
+			MethodName: "GetMostWatchedMatches",
+			Handler:    _MatchService_GetMostWatchedMatches_Handler,
+		},
+		{
+			MethodName: "RebuildMatch",
+			Handler:    _MatchService_RebuildMatch_Handler,
+		},
+		{
+			MethodName: "CheckMatchConsistency",
+			Handler:    _MatchService_CheckMatchConsistency_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamMatchUpdates",
+			Handler:       _MatchService_StreamMatchUpdates_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamMultiMatchUpdates",
+			Handler:       _MatchService_StreamMultiMatchUpdates_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "match-service/proto/match.proto",
 }
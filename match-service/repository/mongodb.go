@@ -2,7 +2,12 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -13,10 +18,36 @@ import (
 
 // Match represents the structure of a match document in MongoDB
 type Match struct {
-	MatchID    string   `bson:"match_id"`
-	HomeTeam   string   `bson:"home_team"`  // Added
-	AwayTeam   string   `bson:"away_team"`  // Added
-	StartTime  string   `bson:"start_time"` // Added
+	MatchID     string   `bson:"match_id"`
+	HomeTeam    string   `bson:"home_team"`             // Added
+	AwayTeam    string   `bson:"away_team"`             // Added
+	StartTime   string   `bson:"start_time"`            // Added
+	Sport       string   `bson:"sport,omitempty"`       // e.g. "soccer", used to pick a poll policy
+	Competition string   `bson:"competition,omitempty"` // e.g. "LaLiga", used to pick a poll policy
+	Status      string   `bson:"status"`
+	HomeScore   int32    `bson:"home_score"`
+	AwayScore   int32    `bson:"away_score"`
+	LastEvent   string   `bson:"last_event"`
+	Possession  int32    `bson:"possession"`
+	Shots       int32    `bson:"shots"`
+	Fouls       int32    `bson:"fouls"`
+	Cards       []string `bson:"cards"` // e.g., ["home_yellow", "away_red"]
+	// Fields corrected by an admin, keyed by OverrideScore etc. Provider updates skip them.
+	Overrides map[string]Override `bson:"overrides"`
+	// Most concurrent viewers across all replicas, recorded after full time, see SetPeakViewers
+	PeakViewers   int64     `bson:"peak_viewers,omitempty"`
+	PeakViewersAt time.Time `bson:"peak_viewers_at,omitempty"`
+	// Seq of the last event of the match, which the live fields reflect (see MatchState).
+	// Events are applied in seq order after they're added to the log (see AddEvent), so the
+	// log may already have a few later ones.
+	EventSeq int64 `bson:"event_seq,omitempty"`
+	// Incremented by every update, for compare-and-swap, see UpdateMatch
+	Version int64 `bson:"version"`
+}
+
+// MatchState holds the live fields of a match. They are derived from its events: the
+// stored match is a projection of the event log, which can be folded again to rebuild it.
+type MatchState struct {
 	Status     string   `bson:"status"`
 	HomeScore  int32    `bson:"home_score"`
 	AwayScore  int32    `bson:"away_score"`
@@ -24,23 +55,238 @@ type Match struct {
 	Possession int32    `bson:"possession"`
 	Shots      int32    `bson:"shots"`
 	Fouls      int32    `bson:"fouls"`
-	Cards      []string `bson:"cards"` // e.g., ["home_yellow", "away_red"]
+	Cards      []string `bson:"cards"`
+}
+
+// State returns the live fields of the match.
+func (m *Match) State() MatchState {
+	return MatchState{
+		Status:     m.Status,
+		HomeScore:  m.HomeScore,
+		AwayScore:  m.AwayScore,
+		LastEvent:  m.LastEvent,
+		Possession: m.Possession,
+		Shots:      m.Shots,
+		Fouls:      m.Fouls,
+		Cards:      slices.Clone(m.Cards),
+	}
+}
+
+// SetState replaces the live fields of the match.
+func (m *Match) SetState(state MatchState) {
+	m.Status = state.Status
+	m.HomeScore = state.HomeScore
+	m.AwayScore = state.AwayScore
+	m.LastEvent = state.LastEvent
+	m.Possession = state.Possession
+	m.Shots = state.Shots
+	m.Fouls = state.Fouls
+	m.Cards = slices.Clone(state.Cards)
+}
+
+// Match fields admins can override. The names match the sportradar field groups.
+const (
+	OverrideScore  = "score"  // HomeScore and AwayScore
+	OverrideStatus = "status" // Status
+	OverrideCards  = "cards"  // Cards
+)
+
+// OverridableFields lists every field that can be overridden.
+var OverridableFields = []string{OverrideScore, OverrideStatus, OverrideCards}
+
+// Override marks a match field as manually corrected. It holds until it expires or is released.
+type Override struct {
+	SetAt     time.Time `bson:"set_at"`
+	ExpiresAt time.Time `bson:"expires_at,omitempty"` // Zero means until released
+}
+
+// Active reports whether the override still holds at now.
+func (o Override) Active(now time.Time) bool {
+	return o.ExpiresAt.IsZero() || now.Before(o.ExpiresAt)
+}
+
+// SetOverride marks field as corrected at now. A ttl of zero or less keeps it until released.
+func (m *Match) SetOverride(field string, now time.Time, ttl time.Duration) {
+	if m.Overrides == nil {
+		m.Overrides = make(map[string]Override)
+	}
+	override := Override{SetAt: now}
+	if ttl > 0 {
+		override.ExpiresAt = now.Add(ttl)
+	}
+	m.Overrides[field] = override
+}
+
+// IsOverridden reports whether field has an active override at now.
+func (m *Match) IsOverridden(field string, now time.Time) bool {
+	override, ok := m.Overrides[field]
+	return ok && override.Active(now)
+}
+
+// OverriddenFields returns the fields with an active override at now, in OverridableFields order.
+func (m *Match) OverriddenFields(now time.Time) []string {
+	var fields []string
+	for _, field := range OverridableFields {
+		if m.IsOverridden(field, now) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// DropExpiredOverrides removes overrides that no longer hold at now and reports whether there were any.
+func (m *Match) DropExpiredOverrides(now time.Time) bool {
+	dropped := false
+	for field, override := range m.Overrides {
+		if !override.Active(now) {
+			delete(m.Overrides, field)
+			dropped = true
+		}
+	}
+	return dropped
+}
+
+// ErrMatchNotFound is returned (wrapped) when no match with the requested ID exists.
+var ErrMatchNotFound = errors.New("match not found")
+
+// ErrMatchExists is returned (wrapped) by CreateMatch when a match with the same ID exists.
+var ErrMatchExists = errors.New("match already exists")
+
+// ErrVersionConflict is returned (wrapped) by UpdateMatch when the match was updated since it was read,
+// and by AddEvent when the seq of the event is taken.
+var ErrVersionConflict = errors.New("match was updated concurrently")
+
+// ErrEventNotFound is returned (wrapped) when a match has no event with the requested ID.
+var ErrEventNotFound = errors.New("event not found")
+
+// ErrEventRevised is returned (wrapped) by ReviseEvent when the event was revised since it was read.
+var ErrEventRevised = errors.New("event was revised concurrently")
+
+// Status values seen in the status field. Sportradar reports lower-case values
+// ("live", "closed") while admin-created matches use "Scheduled", so comparisons
+// are case-insensitive. Knockout matches go on after the 2nd half through extra time and
+// penalties, each with a break before it.
+var (
+	ActiveStatuses = []string{
+		"scheduled", "not_started", "live", "1st_half", "halftime", "2nd_half",
+		"awaiting_extra", "1st_extra", "extra_time_halftime", "2nd_extra", "overtime",
+		"awaiting_penalties", "penalties", "interrupted", "pause",
+	}
+	FinishedStatuses = []string{"finished", "ended", "closed", "cancelled", "postponed", "abandoned"}
+)
+
+// IsFinishedStatus reports whether a match with the given status will not change anymore.
+func IsFinishedStatus(status string) bool {
+	for _, s := range FinishedStatuses {
+		if strings.EqualFold(status, s) {
+			return true
+		}
+	}
+	return false
 }
 
 // Event represents a match event, to be stored in a separate collection or embedded
 type Event struct {
 	EventID     string `bson:"event_id"`
 	MatchID     string `bson:"match_id"`
-	EventType   string `bson:"event_type"` // "goal", "foul", "card", "substitution"
+	EventType   string `bson:"event_type"` // One of the Event* constants
 	Description string `bson:"description"`
 	Timestamp   string `bson:"timestamp"` // ISO 8601 string
-	// Potentially other fields for specific event types (e.g., player_id, team_id, score_change)
+	Seq         int64  `bson:"seq"`       // Order within the match, from 1, see Match.EventSeq
+	// Structured details, for stats and notifications. Older events only have a description.
+	TeamSide        string `bson:"team_side,omitempty"` // TeamHome or TeamAway
+	PlayerID        string `bson:"player_id,omitempty"`
+	RelatedPlayerID string `bson:"related_player_id,omitempty"` // Assist on goals, player coming off on substitutions
+	Minute          int32  `bson:"minute,omitempty"`            // Match minute, e.g. 45 for 45+2
+	AddedTime       int32  `bson:"added_time,omitempty"`        // Minute of added time, e.g. 2 for 45+2
+	Detail          string `bson:"detail,omitempty"`            // Card color, penalty outcome or VAR decision
+	// What the event did to the match, so the event log can be folded again
+	HomeScoreChange int32       `bson:"home_score_change,omitempty"`
+	AwayScoreChange int32       `bson:"away_score_change,omitempty"`
+	OverrideTTL     int64       `bson:"override_ttl,omitempty"` // Seconds the fields it corrects are overridden, negative until released
+	State           *MatchState `bson:"state,omitempty"`        // Reported state, on EventProviderUpdate and EventMatchCreated
+	// Admin corrections, see ReviseEvent. Retracted events are kept but no longer apply.
+	Revision    int32           `bson:"revision,omitempty"` // Times the event was amended or retracted
+	RetractedAt time.Time       `bson:"retracted_at,omitempty"`
+	Revisions   []EventRevision `bson:"revisions,omitempty"` // Audit trail, oldest first
+}
+
+// EventRevision records an amendment or retraction of an event, with the event as it was before.
+type EventRevision struct {
+	Action    string    `bson:"action"` // RevisionAmend or RevisionRetract
+	Reason    string    `bson:"reason,omitempty"`
+	RevisedAt time.Time `bson:"revised_at"`
+	Previous  Event     `bson:"previous"` // Without its own Revisions
+}
+
+// Revision actions.
+const (
+	RevisionAmend   = "amend"
+	RevisionRetract = "retract"
+)
+
+// Retracted reports whether an admin voided the event.
+func (e *Event) Retracted() bool {
+	return !e.RetractedAt.IsZero()
+}
+
+// Event types. Admins may also send other types, which are stored as they are.
+const (
+	EventGoal         = "goal"
+	EventOwnGoal      = "own_goal" // TeamSide is the side of the player who scored it
+	EventPenalty      = "penalty"  // Detail is one of the Penalty* outcomes
+	EventCard         = "card"     // Detail is one of the Card* colors
+	EventSubstitution = "substitution"
+	EventVARReview    = "var_review" // Detail is the decision, e.g. "goal_disallowed"
+	EventInjury       = "injury"
+	EventFoul         = "foul"
+	EventStatusChange = "status_change" // Description is the new status
+
+	// Recorded by the service rather than sent by admins, and left out of timelines
+	EventMatchCreated    = "match_created"    // State is the initial state; overrides are cleared
+	EventProviderUpdate  = "provider_update"  // State is what the providers reported
+	EventOverrideRelease = "override_release" // Detail is the comma-separated fields, empty for all
+)
+
+// InternalEventTypes are the event types the service records itself.
+var InternalEventTypes = []string{EventMatchCreated, EventProviderUpdate, EventOverrideRelease}
+
+// Team sides.
+const (
+	TeamHome = "home"
+	TeamAway = "away"
+)
+
+// Card colors.
+const (
+	CardYellow       = "yellow"
+	CardSecondYellow = "second_yellow"
+	CardRed          = "red"
+)
+
+// Penalty outcomes.
+const (
+	PenaltyScored = "scored"
+	PenaltyMissed = "missed"
+	PenaltySaved  = "saved"
+)
+
+// MatchMinute formats the minute of the event like "45+2'", or returns "" if it has none.
+func (e *Event) MatchMinute() string {
+	switch {
+	case e.Minute == 0 && e.AddedTime == 0:
+		return ""
+	case e.AddedTime > 0:
+		return fmt.Sprintf("%d+%d'", e.Minute, e.AddedTime)
+	}
+	return fmt.Sprintf("%d'", e.Minute)
 }
 
 // MatchRepository handles database operations for matches and events
 type MatchRepository struct {
-	matchesCollection *mongo.Collection
-	eventsCollection  *mongo.Collection
+	matchesCollection   *mongo.Collection
+	eventsCollection    *mongo.Collection
+	snapshotsCollection *mongo.Collection
 }
 
 // NewMatchRepository creates a new MatchRepository
@@ -49,65 +295,478 @@ func NewMatchRepository(database *db.MongoDB) *MatchRepository {
 	liveSportsDB := database.GetDatabase("livesports")
 
 	return &MatchRepository{
-		matchesCollection: liveSportsDB.Collection("matches"),
-		eventsCollection:  liveSportsDB.Collection("events"),
+		matchesCollection:   liveSportsDB.Collection("matches"),
+		eventsCollection:    liveSportsDB.Collection("events"),
+		snapshotsCollection: liveSportsDB.Collection("match_snapshots"),
 	}
 }
 
+// EnsureIndexes creates the indexes the repository relies on, if they don't exist yet.
+// Match IDs are unique, so concurrent imports of a match create it once (see CreateMatch).
+// Event seqs are unique within a match, so inserting an event takes its seq (see AddEvent);
+// events recorded before events were numbered all have seq 0 and are left out.
+func (r *MatchRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.matchesCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "match_id", Value: 1}},
+		Options: options.Index().SetName("match_id").SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create match ID index (duplicate matches must be removed first): %w", err)
+	}
+	_, err = r.eventsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "match_id", Value: 1}, {Key: "seq", Value: 1}},
+		Options: options.Index().
+			SetName("match_id_seq").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"seq": bson.M{"$gt": 0}}),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create event seq index: %w", err)
+	}
+	return nil
+}
+
 // GetMatch retrieves a match by its ID
 func (r *MatchRepository) GetMatch(ctx context.Context, matchID string) (*Match, error) {
 	var match Match
 	err := r.matchesCollection.FindOne(ctx, bson.M{"match_id": matchID}).Decode(&match)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, fmt.Errorf("match with ID %s not found", matchID)
+			return nil, fmt.Errorf("match with ID %s: %w", matchID, ErrMatchNotFound)
 		}
 		return nil, fmt.Errorf("failed to get match: %w", err)
 	}
 	return &match, nil
 }
 
-// CreateMatch inserts a new match into the database
+// CreateMatch inserts a new match into the database. It fails with ErrMatchExists if a
+// match with the same ID exists, e.g. one another replica created since it was looked up.
 func (r *MatchRepository) CreateMatch(ctx context.Context, match *Match) error {
 	_, err := r.matchesCollection.InsertOne(ctx, match)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("match with ID %s: %w", match.MatchID, ErrMatchExists)
+		}
 		return fmt.Errorf("failed to create match: %w", err)
 	}
 	return nil
 }
 
-// UpdateMatch updates an existing match in the database.
-// This is crucial for integrating Sportradar updates and admin event changes.
+// UpdateMatch saves all fields of a match read with GetMatch, unless it was updated since:
+// then it fails with ErrVersionConflict, and the caller reads it again and retries. On
+// success match.Version is the new version.
 func (r *MatchRepository) UpdateMatch(ctx context.Context, match *Match) error {
-	filter := bson.M{"match_id": match.MatchID}
-	update := bson.M{"$set": match}                                                                // Update all fields
-	_, err := r.matchesCollection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)) // Upsert if not exists
+	filter := versionFilter(match.MatchID, match.Version)
+	saved := *match
+	saved.Version++
+	if saved.Overrides == nil {
+		saved.Overrides = map[string]Override{} // Not null, so IncrementCounters can set fields in it
+	}
+	result, err := r.matchesCollection.UpdateOne(ctx, filter, bson.M{"$set": &saved})
 	if err != nil {
 		return fmt.Errorf("failed to update match: %w", err)
 	}
+	if result.MatchedCount == 0 {
+		exists, err := r.matchesCollection.CountDocuments(ctx, bson.M{"match_id": match.MatchID}, options.Count().SetLimit(1))
+		if err != nil {
+			return fmt.Errorf("failed to update match: %w", err)
+		}
+		if exists == 0 {
+			return fmt.Errorf("match with ID %s: %w", match.MatchID, ErrMatchNotFound)
+		}
+		return fmt.Errorf("match with ID %s at version %d: %w", match.MatchID, match.Version, ErrVersionConflict)
+	}
+	match.Version = saved.Version
 	return nil
 }
 
-// AddEvent adds a new event to the events collection
+// versionFilter matches a match at the given version. Matches stored before versions
+// existed are at version 0.
+func versionFilter(matchID string, version int64) bson.M {
+	if version == 0 {
+		return bson.M{"match_id": matchID, "version": bson.M{"$in": bson.A{nil, 0}}}
+	}
+	return bson.M{"match_id": matchID, "version": version}
+}
+
+// CounterUpdate is the effect of an event that only moves the counters of a match, see
+// IncrementCounters.
+type CounterUpdate struct {
+	Seq       int64 // Of the event, which has to be the next one of the match
+	HomeScore int32 // Added to the counters
+	AwayScore int32
+	Fouls     int32
+	LastEvent string              // Replaces LastEvent
+	Overrides map[string]Override // Set on top of the current ones
+}
+
+// IncrementCounters applies update in a single atomic update, whatever the version of the
+// match, and moves its EventSeq to update.Seq. Concurrent writes to other fields, such as
+// SetPeakViewers, don't make it fail this way. It fails with ErrVersionConflict unless the
+// match is at the event before update.Seq, so events are applied once and in order.
+// It returns the match as updated.
+func (r *MatchRepository) IncrementCounters(ctx context.Context, matchID string, update CounterUpdate) (*Match, error) {
+	if len(update.Overrides) > 0 {
+		// Matches saved before UpdateMatch stored empty overrides have null ones, which
+		// fields can't be set in. Bump the version too, so a stale UpdateMatch can't put null back.
+		_, err := r.matchesCollection.UpdateOne(ctx,
+			bson.M{"match_id": matchID, "overrides": nil},
+			bson.M{"$set": bson.M{"overrides": bson.M{}}, "$inc": bson.M{"version": 1}},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to increment match counters: %w", err)
+		}
+	}
+
+	set := bson.M{"last_event": update.LastEvent}
+	for field, override := range update.Overrides {
+		set["overrides."+field] = override
+	}
+	set["event_seq"] = update.Seq
+	filter := bson.M{"match_id": matchID, "event_seq": update.Seq - 1}
+	if update.Seq == 1 {
+		filter["event_seq"] = bson.M{"$in": bson.A{nil, 0}} // Also matches matches without the field
+	}
+	var match Match
+	err := r.matchesCollection.FindOneAndUpdate(ctx,
+		filter,
+		bson.M{
+			"$inc": bson.M{
+				"home_score": update.HomeScore,
+				"away_score": update.AwayScore,
+				"fouls":      update.Fouls,
+				"version":    1,
+			},
+			"$set": set,
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&match)
+	if err == mongo.ErrNoDocuments {
+		exists, err := r.matchesCollection.CountDocuments(ctx, bson.M{"match_id": matchID}, options.Count().SetLimit(1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to increment match counters: %w", err)
+		}
+		if exists == 0 {
+			return nil, fmt.Errorf("match with ID %s: %w", matchID, ErrMatchNotFound)
+		}
+		return nil, fmt.Errorf("match with ID %s before event %d: %w", matchID, update.Seq, ErrVersionConflict)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to increment match counters: %w", err)
+	}
+	return &match, nil
+}
+
+// SetPeakViewers records the peak viewer count of a match, unless a higher one is already
+// recorded. Every replica records the same peak, so this has to be idempotent.
+func (r *MatchRepository) SetPeakViewers(ctx context.Context, matchID string, peak int64, at time.Time) error {
+	filter := bson.M{"match_id": matchID, "$or": bson.A{
+		bson.M{"peak_viewers": bson.M{"$lt": peak}},
+		bson.M{"peak_viewers": bson.M{"$exists": false}},
+	}}
+	update := bson.M{
+		"$set": bson.M{"peak_viewers": peak, "peak_viewers_at": at},
+		"$inc": bson.M{"version": 1}, // So a concurrent UpdateMatch can't put back the old peak
+	}
+	if _, err := r.matchesCollection.UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to record peak viewers: %w", err)
+	}
+	return nil
+}
+
+// AddEvent adds a new event to the events collection. Inserting it takes its Seq: if another
+// event of the match already has it, AddEvent fails with ErrVersionConflict, and the caller
+// catches up with the events it missed and retries with the next seq (see EnsureIndexes).
 func (r *MatchRepository) AddEvent(ctx context.Context, event *Event) error {
 	_, err := r.eventsCollection.InsertOne(ctx, event)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("event %d of match %s: %w", event.Seq, event.MatchID, ErrVersionConflict)
+		}
 		return fmt.Errorf("failed to add event: %w", err)
 	}
 	return nil
 }
 
-// GetMatchListForAdmin retrieves a list of all matches (for admin panel)
-func (r *MatchRepository) GetMatchListForAdmin(ctx context.Context) ([]*Match, error) {
-	cursor, err := r.matchesCollection.Find(ctx, bson.M{})
+// EventCursor is a position in a match timeline.
+type EventCursor struct {
+	Seq     int64  `json:"s"`
+	EventID string `json:"id"`
+}
+
+// TimelineOptions configures GetEvents.
+type TimelineOptions struct {
+	Since            int64        // Only events with a higher Seq, zero for all
+	After            *EventCursor // Start after this event, nil for the first page
+	Limit            int64        // Zero for no limit
+	ExcludeTypes     []string     // Leave out events of these types
+	ExcludeRetracted bool
+}
+
+// GetEvents retrieves the events of a match in the order they were recorded. Pages are
+// keyset-paginated on Seq and event ID. Events recorded before events were numbered have
+// a zero Seq and come first, in event ID order, which is the order they were recorded in.
+func (r *MatchRepository) GetEvents(ctx context.Context, matchID string, opts TimelineOptions) ([]*Event, error) {
+	filter := bson.M{"match_id": matchID}
+	if opts.Since > 0 {
+		filter["seq"] = bson.M{"$gt": opts.Since}
+	}
+	if len(opts.ExcludeTypes) > 0 {
+		filter["event_type"] = bson.M{"$nin": opts.ExcludeTypes}
+	}
+	if opts.ExcludeRetracted {
+		filter["retracted_at"] = bson.M{"$exists": false}
+	}
+	if opts.After != nil {
+		sameSeq := bson.M{"seq": opts.After.Seq, "event_id": bson.M{"$gt": opts.After.EventID}}
+		if opts.After.Seq == 0 {
+			sameSeq["seq"] = bson.M{"$in": bson.A{nil, 0}} // Also matches events without the field
+		}
+		filter = bson.M{"$and": bson.A{filter, bson.M{"$or": bson.A{
+			bson.M{"seq": bson.M{"$gt": opts.After.Seq}},
+			sameSeq,
+		}}}}
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "seq", Value: 1}, {Key: "event_id", Value: 1}})
+	if opts.Limit > 0 {
+		findOptions.SetLimit(opts.Limit)
+	}
+	cursor, err := r.eventsCollection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var events []*Event
+	if err = cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("failed to decode events: %w", err)
+	}
+	return events, nil
+}
+
+// GetEvent retrieves an event of a match.
+func (r *MatchRepository) GetEvent(ctx context.Context, matchID, eventID string) (*Event, error) {
+	var event Event
+	err := r.eventsCollection.FindOne(ctx, bson.M{"match_id": matchID, "event_id": eventID}).Decode(&event)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("event %s of match %s: %w", eventID, matchID, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	return &event, nil
+}
+
+// ReviseEvent replaces previous, as read with GetEvent, with revised, setting the Revision
+// and Revisions of revised to add revision to the audit trail. It fails with
+// ErrEventRevised if the event changed since it was read.
+func (r *MatchRepository) ReviseEvent(ctx context.Context, previous, revised *Event, revision EventRevision) error {
+	revision.Previous = *previous
+	revision.Previous.Revisions = nil
+	revised.Revision = previous.Revision + 1
+	revised.Revisions = append(slices.Clone(previous.Revisions), revision)
+
+	filter := bson.M{"match_id": previous.MatchID, "event_id": previous.EventID, "revision": previous.Revision}
+	if previous.Revision == 0 {
+		filter["revision"] = bson.M{"$in": bson.A{nil, 0}} // Also matches events without the field
+	}
+	result, err := r.eventsCollection.ReplaceOne(ctx, filter, revised)
+	if err != nil {
+		return fmt.Errorf("failed to revise event: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("event %s of match %s: %w", previous.EventID, previous.MatchID, ErrEventRevised)
+	}
+	return nil
+}
+
+// Snapshot is the state of a match after the event with Seq, so folding its events can
+// start from there instead of the first event.
+type Snapshot struct {
+	MatchID   string              `bson:"match_id"`
+	Seq       int64               `bson:"seq"`
+	State     MatchState          `bson:"state"`
+	Overrides map[string]Override `bson:"overrides"`
+	CreatedAt time.Time           `bson:"created_at"`
+}
+
+// SaveSnapshot stores a snapshot, replacing one of the same match and seq.
+func (r *MatchRepository) SaveSnapshot(ctx context.Context, snapshot *Snapshot) error {
+	filter := bson.M{"match_id": snapshot.MatchID, "seq": snapshot.Seq}
+	_, err := r.snapshotsCollection.ReplaceOne(ctx, filter, snapshot, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	return nil
+}
+
+// GetLatestSnapshot returns the snapshot of a match with the highest seq, or nil if it has none.
+func (r *MatchRepository) GetLatestSnapshot(ctx context.Context, matchID string) (*Snapshot, error) {
+	var snapshot Snapshot
+	err := r.snapshotsCollection.FindOne(ctx, bson.M{"match_id": matchID},
+		options.FindOne().SetSort(bson.D{{Key: "seq", Value: -1}}),
+	).Decode(&snapshot)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// DeleteSnapshots removes the snapshots of a match from fromSeq on, e.g. because an event they
+// include was revised. Zero removes all of them.
+func (r *MatchRepository) DeleteSnapshots(ctx context.Context, matchID string, fromSeq int64) error {
+	filter := bson.M{"match_id": matchID, "seq": bson.M{"$gte": fromSeq}}
+	if _, err := r.snapshotsCollection.DeleteMany(ctx, filter); err != nil {
+		return fmt.Errorf("failed to delete snapshots: %w", err)
+	}
+	return nil
+}
+
+// MatchListFilter narrows down the admin match list. Empty fields don't filter.
+type MatchListFilter struct {
+	Status      string // Exact status, case-insensitive
+	Team        string // Part of the home or away team name, case-insensitive
+	Competition string // Exact competition, case-insensitive
+	// Start time range as RFC 3339 strings, compared as stored (see Match.StartTime)
+	StartFrom string // Inclusive
+	StartTo   string // Exclusive
+}
+
+// Fields the admin match list can be sorted by. Ties are broken by match ID.
+var MatchListSortFields = []string{"start_time", "status", "home_team", "competition", "match_id"}
+
+// MatchListCursor is the position after the last match of a page.
+type MatchListCursor struct {
+	SortValue string `json:"v"`
+	MatchID   string `json:"id"`
+}
+
+// MatchListOptions configures GetMatchListForAdmin.
+type MatchListOptions struct {
+	Filter     MatchListFilter
+	SortBy     string // One of MatchListSortFields, defaults to start_time
+	Descending bool
+	Limit      int64            // Zero for no limit
+	After      *MatchListCursor // Start after this match, nil for the first page
+}
+
+// GetMatchListForAdmin retrieves a page of matches (for admin panel), along with the
+// number of matches for the filter across all pages. Pages are keyset-paginated on the
+// sort field and match ID, so matches added in between don't shift later pages.
+func (r *MatchRepository) GetMatchListForAdmin(ctx context.Context, opts MatchListOptions) ([]*Match, int64, error) {
+	sortBy := opts.SortBy
+	if sortBy == "" {
+		sortBy = "start_time"
+	}
+	if !slices.Contains(MatchListSortFields, sortBy) {
+		return nil, 0, fmt.Errorf("cannot sort matches by %q", sortBy)
+	}
+
+	filter := matchListFilter(opts.Filter)
+	total, err := r.matchesCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count matches: %w", err)
+	}
+
+	direction, after := 1, "$gt"
+	if opts.Descending {
+		direction, after = -1, "$lt"
+	}
+	if opts.After != nil {
+		var page bson.M
+		if sortBy == "match_id" {
+			page = bson.M{"match_id": bson.M{after: opts.After.MatchID}}
+		} else {
+			page = bson.M{"$or": bson.A{
+				bson.M{sortBy: bson.M{after: opts.After.SortValue}},
+				bson.M{sortBy: opts.After.SortValue, "match_id": bson.M{after: opts.After.MatchID}},
+			}}
+		}
+		filter = bson.M{"$and": bson.A{filter, page}}
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: sortBy, Value: direction}, {Key: "match_id", Value: direction}})
+	if opts.Limit > 0 {
+		findOptions.SetLimit(opts.Limit)
+	}
+	cursor, err := r.matchesCollection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get match list: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var matches []*Match
+	if err = cursor.All(ctx, &matches); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode match list: %w", err)
+	}
+	return matches, total, nil
+}
+
+// ListCursor returns the cursor positioned after match when sorting by sortBy.
+func (m *Match) ListCursor(sortBy string) *MatchListCursor {
+	cursor := &MatchListCursor{MatchID: m.MatchID}
+	switch sortBy {
+	case "", "start_time":
+		cursor.SortValue = m.StartTime
+	case "status":
+		cursor.SortValue = m.Status
+	case "home_team":
+		cursor.SortValue = m.HomeTeam
+	case "competition":
+		cursor.SortValue = m.Competition
+	}
+	return cursor
+}
+
+func matchListFilter(f MatchListFilter) bson.M {
+	filter := bson.M{}
+	if f.Status != "" {
+		filter["status"] = exactInsensitive(f.Status)
+	}
+	if f.Competition != "" {
+		filter["competition"] = exactInsensitive(f.Competition)
+	}
+	if f.Team != "" {
+		team := bson.M{"$regex": regexp.QuoteMeta(f.Team), "$options": "i"}
+		filter["$or"] = bson.A{bson.M{"home_team": team}, bson.M{"away_team": team}}
+	}
+	if f.StartFrom != "" || f.StartTo != "" {
+		start := bson.M{}
+		if f.StartFrom != "" {
+			start["$gte"] = f.StartFrom
+		}
+		if f.StartTo != "" {
+			start["$lt"] = f.StartTo
+		}
+		filter["start_time"] = start
+	}
+	return filter
+}
+
+func exactInsensitive(value string) bson.M {
+	return bson.M{"$regex": "^" + regexp.QuoteMeta(value) + "$", "$options": "i"}
+}
+
+// GetActiveMatches retrieves all matches that are scheduled or currently in play.
+// Used by the background poller to decide which matches to refresh from Sportradar.
+func (r *MatchRepository) GetActiveMatches(ctx context.Context) ([]*Match, error) {
+	filter := bson.M{"status": bson.M{
+		"$regex":   "^(" + strings.Join(ActiveStatuses, "|") + ")$",
+		"$options": "i", // Case-insensitive, see ActiveStatuses
+	}}
+	cursor, err := r.matchesCollection.Find(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get match list: %w", err)
+		return nil, fmt.Errorf("failed to get active matches: %w", err)
 	}
 	defer cursor.Close(ctx)
 
 	var matches []*Match
 	if err = cursor.All(ctx, &matches); err != nil {
-		return nil, fmt.Errorf("failed to decode match list: %w", err)
+		return nil, fmt.Errorf("failed to decode active matches: %w", err)
 	}
 	return matches, nil
 }
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Page sizes for GetAdminMatchList.
+const (
+	defaultAdminPageSize = 50
+	maxAdminPageSize     = 200
+)
+
+// adminPageToken is the opaque page token of GetAdminMatchList. It carries the sort
+// order it was issued for, so it can't be reused with a different one.
+type adminPageToken struct {
+	SortBy     string                      `json:"s"`
+	Descending bool                        `json:"d,omitempty"`
+	After      *repository.MatchListCursor `json:"a"`
+}
+
+// GetAdminMatchList returns a filtered, sorted page of matches for the admin panel.
+func (s *MatchService) GetAdminMatchList(ctx context.Context, req *proto.AdminMatchListRequest) (*proto.MatchListResponse, error) {
+	opts := repository.MatchListOptions{
+		Filter: repository.MatchListFilter{
+			Status:      req.Status,
+			Team:        req.Team,
+			Competition: req.Competition,
+		},
+		SortBy:     req.SortBy,
+		Descending: req.Descending,
+	}
+	if opts.SortBy == "" {
+		opts.SortBy = "start_time"
+	}
+	if !slices.Contains(repository.MatchListSortFields, opts.SortBy) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid sort_by %q, expected one of %v", req.SortBy, repository.MatchListSortFields)
+	}
+
+	var err error
+	if opts.Filter.StartFrom, err = normalizeStartTime(req.StartFrom); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid start_from: %v", err)
+	}
+	if opts.Filter.StartTo, err = normalizeStartTime(req.StartTo); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid start_to: %v", err)
+	}
+
+	pageSize := int64(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultAdminPageSize
+	}
+	pageSize = min(pageSize, maxAdminPageSize)
+	opts.Limit = pageSize + 1 // One more to know whether there is a next page
+
+	if req.PageToken != "" {
+		token, err := decodeAdminPageToken(req.PageToken)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token: %v", err)
+		}
+		if token.SortBy != opts.SortBy || token.Descending != opts.Descending {
+			return nil, status.Errorf(codes.InvalidArgument, "page_token was issued for a different sort order")
+		}
+		opts.After = token.After
+	}
+
+	matches, total, err := s.repo.GetMatchListForAdmin(ctx, opts)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list matches: %v", err)
+	}
+
+	resp := &proto.MatchListResponse{TotalCount: total}
+	if int64(len(matches)) > pageSize {
+		matches = matches[:pageSize]
+		resp.NextPageToken = encodeAdminPageToken(adminPageToken{
+			SortBy:     opts.SortBy,
+			Descending: opts.Descending,
+			After:      matches[len(matches)-1].ListCursor(opts.SortBy),
+		})
+	}
+	for _, match := range matches {
+		resp.Matches = append(resp.Matches, toMatchResponse(match))
+	}
+	return resp, nil
+}
+
+// normalizeStartTime validates an RFC 3339 filter bound and formats it in UTC, like
+// start times imported from Sportradar, so string comparisons in the DB line up.
+func normalizeStartTime(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return "", err
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+func encodeAdminPageToken(token adminPageToken) string {
+	data, _ := json.Marshal(token) // Plain strings and bools, can't fail
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeAdminPageToken(value string) (*adminPageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	var token adminPageToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, err
+	}
+	if token.After == nil {
+		return nil, errors.New("missing position")
+	}
+	return &token, nil
+}
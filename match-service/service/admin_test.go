@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newAdminListService returns a service with five matches over three days and two competitions.
+func newAdminListService(t *testing.T) *MatchService {
+	t.Helper()
+	store := newMemStore()
+	for _, match := range []*repository.Match{
+		{MatchID: "m-1", HomeTeam: "Real Madrid", AwayTeam: "Barcelona", Competition: "La Liga", Status: "1st_half", StartTime: "2026-05-30T18:00:00Z"},
+		{MatchID: "m-2", HomeTeam: "Arsenal", AwayTeam: "Chelsea", Competition: "Premier League", Status: "not_started", StartTime: "2026-05-30T16:00:00Z"},
+		{MatchID: "m-3", HomeTeam: "Liverpool", AwayTeam: "Everton", Competition: "Premier League", Status: "ended", StartTime: "2026-05-29T20:00:00Z"},
+		{MatchID: "m-4", HomeTeam: "Sevilla", AwayTeam: "Real Sociedad", Competition: "La Liga", Status: "not_started", StartTime: "2026-05-31T18:00:00Z"},
+		{MatchID: "m-5", HomeTeam: "Chelsea", AwayTeam: "Arsenal", Competition: "Premier League", Status: "not_started", StartTime: "2026-05-31T16:00:00Z"},
+	} {
+		if err := store.CreateMatch(context.Background(), match); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return newMatchService(store, newFakeProvider(), nil)
+}
+
+func matchIDs(resp *proto.MatchListResponse) []string {
+	var ids []string
+	for _, match := range resp.Matches {
+		ids = append(ids, match.MatchId)
+	}
+	return ids
+}
+
+func TestGetAdminMatchListFilters(t *testing.T) {
+	s := newAdminListService(t)
+	for _, tc := range []struct {
+		name string
+		req  *proto.AdminMatchListRequest
+		want []string
+	}{
+		{"all by start time", &proto.AdminMatchListRequest{}, []string{"m-3", "m-2", "m-1", "m-5", "m-4"}},
+		{"status", &proto.AdminMatchListRequest{Status: "NOT_STARTED"}, []string{"m-2", "m-5", "m-4"}},
+		{"team", &proto.AdminMatchListRequest{Team: "real"}, []string{"m-1", "m-4"}},
+		{"competition", &proto.AdminMatchListRequest{Competition: "premier league", Descending: true}, []string{"m-5", "m-2", "m-3"}},
+		// Bounds in another time zone are compared in UTC
+		{"start range", &proto.AdminMatchListRequest{StartFrom: "2026-05-30T20:00:00+02:00", StartTo: "2026-05-31T18:00:00Z"}, []string{"m-1", "m-5"}},
+		{"combined", &proto.AdminMatchListRequest{Team: "chelsea", Status: "not_started", SortBy: "match_id"}, []string{"m-2", "m-5"}},
+	} {
+		resp, err := s.GetAdminMatchList(context.Background(), tc.req)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if got := matchIDs(resp); !slices.Equal(got, tc.want) || resp.TotalCount != int64(len(tc.want)) || resp.NextPageToken != "" {
+			t.Errorf("%s: got %v of %d, next page %q, want %v on a single page", tc.name, got, resp.TotalCount, resp.NextPageToken, tc.want)
+		}
+	}
+}
+
+func TestGetAdminMatchListRejectsInvalidRequests(t *testing.T) {
+	s := newAdminListService(t)
+	first, err := s.GetAdminMatchList(context.Background(), &proto.AdminMatchListRequest{PageSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, req := range map[string]*proto.AdminMatchListRequest{
+		"unknown sort field":   {SortBy: "home_score"},
+		"invalid start_from":   {StartFrom: "yesterday"},
+		"invalid start_to":     {StartTo: "2026-05-31"},
+		"garbled page token":   {PageToken: "not a token"},
+		"token of other sort":  {PageToken: first.NextPageToken, SortBy: "home_team"},
+		"token of other order": {PageToken: first.NextPageToken, Descending: true},
+	} {
+		if _, err := s.GetAdminMatchList(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: got %v, want InvalidArgument", name, err)
+		}
+	}
+}
+
+func TestGetAdminMatchListPages(t *testing.T) {
+	s := newAdminListService(t)
+	req := &proto.AdminMatchListRequest{SortBy: "home_team", Descending: true, PageSize: 2}
+	var ids []string
+	for pages := 1; ; pages++ {
+		resp, err := s.GetAdminMatchList(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Matches) > 2 || resp.TotalCount != 5 {
+			t.Fatalf("page %d has %d matches of %d, want at most 2 of 5", pages, len(resp.Matches), resp.TotalCount)
+		}
+		ids = append(ids, matchIDs(resp)...)
+		if resp.NextPageToken == "" {
+			if pages != 3 {
+				t.Errorf("got %d pages, want 3", pages)
+			}
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	if want := []string{"m-4", "m-1", "m-3", "m-5", "m-2"}; !slices.Equal(ids, want) {
+		t.Errorf("pages list %v, want %v", ids, want)
+	}
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Correction is sent to the subscribers of a match as a "correction" frame when an admin
+// amends or retracts one of its events. The recomputed match state follows as a regular update.
+type Correction struct {
+	Action   string       `json:"action"` // repository.RevisionAmend or RevisionRetract
+	EventID  string       `json:"event_id"`
+	Event    *proto.Event `json:"event"`    // As amended or retracted
+	Previous *proto.Event `json:"previous"` // As it was before
+	Reason   string       `json:"reason,omitempty"`
+}
+
+// AmendMatchEvent replaces an admin event, e.g. a goal entered for the wrong team, and
+// recomputes the match from its events.
+func (s *MatchService) AmendMatchEvent(ctx context.Context, req *proto.AmendMatchEventRequest) (*proto.EventCorrectionResponse, error) {
+	if req.Event == nil {
+		return nil, status.Error(codes.InvalidArgument, "event is required")
+	}
+	previous, err := s.revisableEvent(ctx, req.MatchId, req.EventId)
+	if err != nil {
+		return nil, err
+	}
+	if previous.Retracted() {
+		return nil, status.Error(codes.FailedPrecondition, "event was retracted")
+	}
+
+	req.Event.MatchId = req.MatchId
+	revised, err := eventFromRequest(req.Event, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	// Still the same event, at the same place in the log
+	revised.EventID = previous.EventID
+	revised.Seq = previous.Seq
+	revised.Timestamp = previous.Timestamp
+	revised.OverrideTTL = previous.OverrideTTL
+	if req.Event.OverrideTtlSeconds != 0 {
+		revised.OverrideTTL = s.resolveOverrideTTL(req.Event.OverrideTtlSeconds)
+	}
+	if last, ok := lastRevision(previous); ok && last.Action == repository.RevisionAmend && sameEvent(previous, revised) {
+		return s.applyRevision(ctx, &last.Previous, previous, last) // A retry, the event is already amended
+	}
+	return s.reviseEvent(ctx, previous, revised, repository.RevisionAmend, req.Reason)
+}
+
+// RetractMatchEvent voids an admin event and recomputes the match without it.
+func (s *MatchService) RetractMatchEvent(ctx context.Context, req *proto.RetractMatchEventRequest) (*proto.EventCorrectionResponse, error) {
+	previous, err := s.revisableEvent(ctx, req.MatchId, req.EventId)
+	if err != nil {
+		return nil, err
+	}
+	if previous.Retracted() {
+		last, _ := lastRevision(previous) // Retracted events always have one
+		return s.applyRevision(ctx, &last.Previous, previous, last)
+	}
+	revised := *previous
+	revised.RetractedAt = time.Now()
+	return s.reviseEvent(ctx, previous, &revised, repository.RevisionRetract, req.Reason)
+}
+
+// revisableEvent returns an event admins may amend or retract. Retracted events are
+// returned too, as retracting one again retries the recompute of its match.
+func (s *MatchService) revisableEvent(ctx context.Context, matchID, eventID string) (*repository.Event, error) {
+	if matchID == "" || eventID == "" {
+		return nil, status.Error(codes.InvalidArgument, "match_id and event_id are required")
+	}
+	event, err := s.repo.GetEvent(ctx, matchID, eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrEventNotFound) {
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get event: %v", err)
+	}
+	if slices.Contains(repository.InternalEventTypes, event.EventType) {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s events are recorded by the service and can't be corrected", event.EventType)
+	}
+
+	// The match is recomputed by folding its events, which needs them from its creation on
+	first, err := s.repo.GetEvents(ctx, matchID, repository.TimelineOptions{Limit: 1})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get events: %v", err)
+	}
+	if len(first) == 0 || first[0].EventType != repository.EventMatchCreated {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot correct events of match %s: %v", matchID, errNoCreationEvent)
+	}
+	return event, nil
+}
+
+// reviseEvent stores revised in place of previous and applies the revision, see applyRevision.
+func (s *MatchService) reviseEvent(ctx context.Context, previous, revised *repository.Event, action, reason string) (*proto.EventCorrectionResponse, error) {
+	revision := repository.EventRevision{Action: action, Reason: reason, RevisedAt: time.Now()}
+	if err := s.repo.ReviseEvent(ctx, previous, revised, revision); err != nil {
+		if errors.Is(err, repository.ErrEventRevised) {
+			return nil, status.Errorf(codes.Aborted, "%v, try again", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to revise event: %v", err)
+	}
+	return s.applyRevision(ctx, previous, revised, revision)
+}
+
+// applyRevision recomputes the match of a revised event, and then tells subscribers about
+// the correction and the new state. The revision is stored first, so if this fails, sending
+// the same correction again comes back here.
+func (s *MatchService) applyRevision(ctx context.Context, previous, revised *repository.Event, revision repository.EventRevision) (*proto.EventCorrectionResponse, error) {
+	// Snapshots after the event include it as it was
+	if err := s.repo.DeleteSnapshots(ctx, previous.MatchID, previous.Seq); err != nil {
+		fmt.Printf("Warning: Failed to drop snapshots of match %s after revising event %s: %v\n", previous.MatchID, previous.EventID, err)
+	}
+
+	match, err := s.repo.GetMatch(ctx, previous.MatchID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "event was revised but the match could not be read, send the correction again: %v", err)
+	}
+	folded, _, changed, err := s.recomputeMatch(ctx, match)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "event was revised but the match could not be recomputed, send the correction again: %v", err)
+	}
+
+	// Clients get the correction once the match reflects it, followed by the state it led to
+	if s.websocketHub != nil {
+		s.websocketHub.BroadcastMatchUpdate(previous.MatchID, &Correction{
+			Action:   revision.Action,
+			EventID:  previous.EventID,
+			Event:    toEventResponse(revised),
+			Previous: toEventResponse(previous),
+			Reason:   revision.Reason,
+		})
+		s.websocketHub.BroadcastMatchUpdate(previous.MatchID, toMatchResponse(folded))
+	}
+	return &proto.EventCorrectionResponse{
+		Event:         toEventResponse(revised),
+		Match:         toMatchResponse(folded),
+		ChangedFields: changed,
+	}, nil
+}
+
+// lastRevision returns the latest revision of event, if it has any.
+func lastRevision(event *repository.Event) (repository.EventRevision, bool) {
+	if len(event.Revisions) == 0 {
+		return repository.EventRevision{}, false
+	}
+	return event.Revisions[len(event.Revisions)-1], true
+}
+
+// sameEvent reports whether a and b say the same, leaving their revisions aside.
+func sameEvent(a, b *repository.Event) bool {
+	a2, b2 := *a, *b
+	a2.Revision, a2.Revisions = 0, nil
+	b2.Revision, b2.Revisions = 0, nil
+	return reflect.DeepEqual(a2, b2)
+}
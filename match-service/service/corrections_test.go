@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+	"github.com/abaika-abay/live_sports_project/match-service/wsclient"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetractMatchEventBroadcastsCorrectionThenState(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	hub := NewWebSocketHub()
+	s := newMatchService(store, newFakeProvider(), hub)
+	if err := s.createMatch(ctx, &repository.Match{MatchID: "m-1", Status: "1st_half"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, eventID := range []string{"e-1", "e-2"} {
+		match, _ := store.GetMatch(ctx, "m-1")
+		if _, err := s.recordEvent(ctx, match, testEvent("m-1", eventID, repository.EventGoal)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client := dialHub(t, hub, "m-1")
+
+	// Nothing is sent if the match can't be recomputed
+	store.failUpdate = func(string) error { return errors.New("database is down") }
+	if _, err := s.RetractMatchEvent(ctx, &proto.RetractMatchEventRequest{MatchId: "m-1", EventId: "e-1", Reason: "offside"}); err == nil {
+		t.Fatal("RetractMatchEvent() succeeded without storing the match")
+	}
+	if _, err := client.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	if frame := nextFrame(t, client); frame.Type != wsclient.FramePong {
+		t.Fatalf("frame after a failed retraction = %s %s, want the pong", frame.Type, frame.Data)
+	}
+
+	// Sending it again recomputes the match and tells clients this time
+	store.failUpdate = nil
+	resp, err := s.RetractMatchEvent(ctx, &proto.RetractMatchEventRequest{MatchId: "m-1", EventId: "e-1", Reason: "offside"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Match.HomeScore != 1 {
+		t.Errorf("recomputed score %d-%d, want 1-0", resp.Match.HomeScore, resp.Match.AwayScore)
+	}
+	if event, _ := store.GetEvent(ctx, "m-1", "e-1"); len(event.Revisions) != 1 {
+		t.Errorf("event has %d revisions, want the retraction once", len(event.Revisions))
+	}
+
+	frame := nextFrame(t, client)
+	var correction Correction
+	if frame.Type != wsclient.FrameCorrection || json.Unmarshal(frame.Data, &correction) != nil ||
+		correction.EventID != "e-1" || correction.Reason != "offside" || correction.Previous.GetRetracted() || !correction.Event.GetRetracted() {
+		t.Fatalf("first frame = %s %s, want the retraction", frame.Type, frame.Data)
+	}
+	frame = nextFrame(t, client)
+	if state, ok := client.Decoder.Match("m-1"); frame.Type != wsclient.FrameSnapshot || !ok || state.HomeScore != 1 {
+		t.Errorf("second frame = %s with state %v, want the recomputed 1-0", frame.Type, state)
+	}
+}
+
+func TestAmendMatchEventAgain(t *testing.T) {
+	s, store := createTestMatch(t)
+	ctx := context.Background()
+	match, _ := store.GetMatch(ctx, "m-1")
+	if _, err := s.recordEvent(ctx, match, testEvent("m-1", "e-1", repository.EventGoal)); err != nil {
+		t.Fatal(err)
+	}
+
+	amend := &proto.AmendMatchEventRequest{
+		MatchId: "m-1",
+		EventId: "e-1",
+		Event:   &proto.UpdateMatchEventRequest{EventType: "goal", TeamSide: "away", PlayerId: "p-9"},
+		Reason:  "wrong team",
+	}
+	for i := 0; i < 2; i++ {
+		resp, err := s.AmendMatchEvent(ctx, amend)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Match.HomeScore != 0 || resp.Match.AwayScore != 1 {
+			t.Errorf("amendment #%d: score %d-%d, want 0-1", i+1, resp.Match.HomeScore, resp.Match.AwayScore)
+		}
+	}
+	if event, _ := store.GetEvent(ctx, "m-1", "e-1"); len(event.Revisions) != 1 {
+		t.Errorf("event has %d revisions, want the same amendment once", len(event.Revisions))
+	}
+
+	if _, err := s.RetractMatchEvent(ctx, &proto.RetractMatchEventRequest{MatchId: "m-1", EventId: "e-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AmendMatchEvent(ctx, amend); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("amending a retracted event = %v, want FailedPrecondition", err)
+	}
+}
+
+// TestResumeAcrossCorrection has a client miss a correction and the state after it, and
+// resume from the state before.
+func TestResumeAcrossCorrection(t *testing.T) {
+	ctx := context.Background()
+	hub := NewWebSocketHub()
+	s := newMatchService(newMemStore(), newFakeProvider(), hub)
+	if err := s.createMatch(ctx, &repository.Match{MatchID: "m-1", Status: "1st_half"}); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(hub.HandleConnections))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	client, err := wsclient.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Subscribe(MatchTopic("m-1")); err != nil {
+		t.Fatal(err)
+	}
+	if frame := nextFrame(t, client); frame.Type != wsclient.FrameAck {
+		t.Fatalf("first frame = %s, want the ack", frame.Type)
+	}
+	if _, err := s.UpdateMatchEvent(ctx, &proto.UpdateMatchEventRequest{MatchId: "m-1", EventType: "goal", TeamSide: "home"}); err != nil {
+		t.Fatal(err)
+	}
+	if frame := nextFrame(t, client); frame.Type != wsclient.FrameSnapshot || frame.Seq != 1 {
+		t.Fatalf("frame = %s #%d, want the snapshot #1", frame.Type, frame.Seq)
+	}
+	client.Close()
+
+	timeline, err := s.GetMatchTimeline(ctx, &proto.TimelineRequest{MatchId: "m-1"})
+	if err != nil || len(timeline.Events) != 1 {
+		t.Fatalf("timeline = %v, %v, want the goal", timeline, err)
+	}
+	if _, err := s.RetractMatchEvent(ctx, &proto.RetractMatchEventRequest{MatchId: "m-1", EventId: timeline.Events[0].EventId}); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err = wsclient.Dial(url, client.Decoder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if _, err := client.Resubscribe(MatchTopic("m-1")); err != nil {
+		t.Fatal(err)
+	}
+	if frame := nextFrame(t, client); frame.Type != wsclient.FrameCorrection || frame.Seq != 2 {
+		t.Fatalf("first frame after resuming = %s #%d, want the correction #2", frame.Type, frame.Seq)
+	}
+	if frame := nextFrame(t, client); frame.Seq != 3 {
+		t.Fatalf("second frame after resuming = %s #%d, want the state #3", frame.Type, frame.Seq)
+	}
+	if state, ok := client.Decoder.Match("m-1"); !ok || state.HomeScore != 0 || client.Decoder.Seq("m-1") != 3 {
+		t.Errorf("state = %v at seq %d, want 0-0 at seq 3", state, client.Decoder.Seq("m-1"))
+	}
+}
@@ -0,0 +1,457 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+	"github.com/abaika-abay/live_sports_project/match-service/sportradar"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The live fields of a match (see repository.MatchState) are derived from its events.
+// Everything that changes them, admin events as well as provider updates, is appended to
+// the event log first and then applied with foldEvent, so folding the log from the
+// match_created event again yields the stored state. Snapshots of folded states let later
+// folds start from there instead.
+const (
+	snapshotInterval          = 100 // Events between snapshots, and folded before a fold saves one
+	maxConsistencyCheckIDs    = 200
+	overrideTimestampAccuracy = time.Millisecond // What MongoDB keeps of override times
+)
+
+// errNoCreationEvent is returned when a match's event log doesn't start with its creation,
+// as for matches created before events were recorded; their state can't be folded.
+var errNoCreationEvent = errors.New("event log doesn't start with a match_created event")
+
+// newEvent returns an event of the service itself, see repository.InternalEventTypes.
+func newEvent(matchID, eventType string, now time.Time) *repository.Event {
+	return &repository.Event{
+		EventID:   fmt.Sprintf("evt-%s-%d", matchID, now.UnixNano()),
+		MatchID:   matchID,
+		EventType: eventType,
+		Timestamp: now.Format(time.RFC3339),
+	}
+}
+
+// stateEvent returns a match_created or provider_update event carrying the state of match.
+func stateEvent(match *repository.Match, eventType string, now time.Time) *repository.Event {
+	event := newEvent(match.MatchID, eventType, now)
+	state := match.State()
+	event.State = &state
+	return event
+}
+
+// foldEvent applies an event to match as of the time it was recorded. It reports whether
+// the match changed. Retracted events change nothing.
+func foldEvent(match *repository.Match, event *repository.Event) bool {
+	if event.Retracted() {
+		return false
+	}
+	at, _ := time.Parse(time.RFC3339, event.Timestamp) // Always written by us as RFC 3339
+
+	switch event.EventType {
+	case repository.EventMatchCreated:
+		if event.State != nil {
+			match.SetState(*event.State)
+		}
+		match.Overrides = nil
+		return true
+	case repository.EventProviderUpdate:
+		if event.State == nil {
+			return false
+		}
+		var reported repository.Match
+		reported.SetState(*event.State)
+		return applyProviderUpdate(match, &reported, at)
+	case repository.EventOverrideRelease:
+		if event.Detail == "" {
+			match.Overrides = nil
+		}
+		for _, field := range strings.Split(event.Detail, ",") {
+			delete(match.Overrides, field)
+		}
+		return true
+	}
+
+	overrideField := applyEvent(match, event, event.HomeScoreChange, event.AwayScoreChange, at)
+	if overrideField != "" {
+		ttl := time.Duration(event.OverrideTTL) * time.Second
+		if event.OverrideTTL == 0 { // Recorded before TTLs were, when the default was fixed
+			ttl = DefaultOverrideTTL
+		}
+		match.SetOverride(overrideField, at, ttl)
+	}
+	return true
+}
+
+// recordEvent appends event to the event log of match and applies it, if it changes anything.
+// Adding the event takes the next seq (see repository.MatchRepository.AddEvent), so it is
+// checked against the events recorded so far, applied or not, and added again after them if
+// another writer takes the seq first. Then the events are applied to the match in seq order:
+// those that only move counters with an atomic increment, others with a compare-and-swap.
+// A writer that fails in between leaves its event for the next one to apply, so the log
+// has no gaps and the match never gets ahead of it. match ends up as stored. It reports
+// whether match changed.
+func (s *MatchService) recordEvent(ctx context.Context, match *repository.Match, event *repository.Event) (bool, error) {
+	unlock := s.lockMatch(match.MatchID)
+	defer unlock()
+
+	var folded *repository.Match
+	var pending []*repository.Event
+	err := s.retryOnConflict(ctx, match, func(match *repository.Match) error {
+		var err error
+		if folded, pending, err = s.pendingEvents(ctx, match); err != nil {
+			return err
+		}
+		if !foldEvent(folded, event) {
+			return nil
+		}
+		event.Seq = folded.EventSeq + 1
+		if err := s.repo.AddEvent(ctx, event); err != nil {
+			return err
+		}
+		folded.EventSeq = event.Seq
+		pending = append(pending, event)
+		return nil
+	})
+	if err != nil || len(pending) == 0 {
+		return false, err
+	}
+
+	if err := s.applyEvents(ctx, match, pending); err != nil {
+		// The events are in the log, so they still count: the next event applies them
+		log.Printf("Warning: Failed to apply events of match %s up to seq %d: %v", match.MatchID, folded.EventSeq, err)
+		*match = *folded
+	}
+	return true, nil
+}
+
+// eventLogStatus returns the gRPC status for an error recording an event or rebuilding a
+// match: Aborted if the match kept getting new events through every retry, so the caller
+// tries again, and Internal with msg otherwise.
+func eventLogStatus(err error, msg string) error {
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return status.Error(codes.Aborted, "match keeps getting new events, try again")
+	}
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
+
+// pendingEvents returns the events in the log of match that aren't applied to it yet, and
+// a copy of match with them applied.
+func (s *MatchService) pendingEvents(ctx context.Context, match *repository.Match) (*repository.Match, []*repository.Event, error) {
+	events, err := s.repo.GetEvents(ctx, match.MatchID, repository.TimelineOptions{Since: match.EventSeq})
+	if err != nil {
+		return nil, nil, err
+	}
+	events = slices.DeleteFunc(events, func(event *repository.Event) bool {
+		return event.Seq <= match.EventSeq // Since 0 includes events recorded before events were numbered
+	})
+	folded := cloneMatch(match)
+	for _, event := range events {
+		foldEvent(folded, event)
+		folded.EventSeq = event.Seq
+	}
+	return folded, events, nil
+}
+
+// applyEvents applies events, the ones in the log after match.EventSeq in seq order, to
+// match one after another. Those another writer applies meanwhile are skipped. Every
+// snapshotInterval events, the state is saved as a snapshot.
+func (s *MatchService) applyEvents(ctx context.Context, match *repository.Match, events []*repository.Event) error {
+	for _, event := range events {
+		err := s.retryOnConflict(ctx, match, func(match *repository.Match) error {
+			if match.EventSeq >= event.Seq {
+				return nil
+			}
+			if update, ok := counterUpdate(match, event); ok {
+				update.Seq = event.Seq
+				stored, err := s.repo.IncrementCounters(ctx, match.MatchID, update)
+				if err != nil {
+					return err
+				}
+				*match = *stored
+				return nil
+			}
+			foldEvent(match, event)
+			match.EventSeq = event.Seq
+			return s.repo.UpdateMatch(ctx, match)
+		})
+		if err != nil {
+			return err
+		}
+		if event.Seq%snapshotInterval == 0 && match.EventSeq == event.Seq {
+			if err := s.saveSnapshot(ctx, match); err != nil {
+				log.Printf("Warning: Failed to snapshot match %s: %v", match.MatchID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// lockMatch serializes the writers of a match's event log within this replica, which would
+// otherwise keep taking the same seqs. Writers on other replicas still retry, see recordEvent.
+// It returns the unlock function.
+func (s *MatchService) lockMatch(matchID string) func() {
+	hash := fnv.New32a()
+	hash.Write([]byte(matchID))
+	mu := &s.matchLocks[hash.Sum32()%uint32(len(s.matchLocks))]
+	mu.Lock()
+	return mu.Unlock
+}
+
+// cloneMatch returns a copy of match that shares no slices or maps with it.
+func cloneMatch(match *repository.Match) *repository.Match {
+	clone := *match
+	clone.Cards = slices.Clone(match.Cards)
+	clone.Overrides = maps.Clone(match.Overrides)
+	return &clone
+}
+
+// createMatch stores a new match and records its creation, which later folds start from.
+func (s *MatchService) createMatch(ctx context.Context, match *repository.Match) error {
+	if err := s.repo.CreateMatch(ctx, match); err != nil {
+		return err
+	}
+	_, err := s.recordEvent(ctx, match, stateEvent(match, repository.EventMatchCreated, time.Now()))
+	return err
+}
+
+// SeedMatch creates match, or resets it to the given state if it exists, and records that in
+// its event log. Used to set up demo matches on startup.
+func (s *MatchService) SeedMatch(ctx context.Context, match *repository.Match) error {
+	stored, err := s.repo.GetMatch(ctx, match.MatchID)
+	if errors.Is(err, repository.ErrMatchNotFound) {
+		return s.createMatch(ctx, match)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = s.updateMatch(ctx, stored, func(stored *repository.Match) bool {
+		stored.HomeTeam, stored.AwayTeam, stored.StartTime = match.HomeTeam, match.AwayTeam, match.StartTime
+		stored.Sport, stored.Competition = match.Sport, match.Competition
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	_, err = s.recordEvent(ctx, stored, stateEvent(match, repository.EventMatchCreated, time.Now()))
+	return err
+}
+
+// foldMatch folds the event log of match into a copy of it. It starts from the latest
+// snapshot, or from the match_created event if there is none or fromScratch is set.
+// It returns the folded match and the number of events folded.
+func (s *MatchService) foldMatch(ctx context.Context, match *repository.Match, fromScratch bool) (*repository.Match, int, error) {
+	folded := *match
+	folded.SetState(repository.MatchState{})
+	folded.Overrides = nil
+	folded.EventSeq = 0
+
+	if !fromScratch {
+		snapshot, err := s.repo.GetLatestSnapshot(ctx, match.MatchID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if snapshot != nil {
+			folded.SetState(snapshot.State)
+			folded.Overrides = maps.Clone(snapshot.Overrides)
+			folded.EventSeq = snapshot.Seq
+		}
+	}
+
+	events, err := s.repo.GetEvents(ctx, match.MatchID, repository.TimelineOptions{Since: folded.EventSeq})
+	if err != nil {
+		return nil, 0, err
+	}
+	if folded.EventSeq == 0 && (len(events) == 0 || events[0].EventType != repository.EventMatchCreated) {
+		return nil, 0, errNoCreationEvent
+	}
+	for _, event := range events {
+		foldEvent(&folded, event)
+		folded.EventSeq = max(folded.EventSeq, event.Seq)
+	}
+	return &folded, len(events), nil
+}
+
+// saveSnapshot stores the folded state of match.
+func (s *MatchService) saveSnapshot(ctx context.Context, folded *repository.Match) error {
+	if folded.EventSeq == 0 {
+		return nil // Only unnumbered events, which can't be resumed after
+	}
+	return s.repo.SaveSnapshot(ctx, &repository.Snapshot{
+		MatchID:   folded.MatchID,
+		Seq:       folded.EventSeq,
+		State:     folded.State(),
+		Overrides: folded.Overrides,
+		CreatedAt: time.Now(),
+	})
+}
+
+// storeFolded stores the live fields of folded, a fold of match, unless match was updated
+// since it was read (see repository.ErrVersionConflict). It returns the fields that changed.
+func (s *MatchService) storeFolded(ctx context.Context, match, folded *repository.Match) ([]string, error) {
+	stored := *folded
+	stored.EventSeq = max(match.EventSeq, folded.EventSeq) // Events not applied yet are folded in too
+	if err := s.repo.UpdateMatch(ctx, &stored); err != nil {
+		return nil, err
+	}
+	return stateDiff(match, folded), nil
+}
+
+// recomputeMatch folds the whole event log of match and stores the result, folding again
+// if new events come in meanwhile. It returns the new state, the number of events folded
+// and the fields that changed. Sending the new state to clients is up to the caller.
+func (s *MatchService) recomputeMatch(ctx context.Context, match *repository.Match) (*repository.Match, int, []string, error) {
+	unlock := s.lockMatch(match.MatchID)
+	defer unlock()
+
+	var folded *repository.Match
+	var count int
+	var changed []string
+	err := s.retryOnConflict(ctx, match, func(match *repository.Match) error {
+		var err error
+		if folded, count, err = s.foldMatch(ctx, match, true); err != nil {
+			return err
+		}
+		changed, err = s.storeFolded(ctx, match, folded)
+		return err
+	})
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	return folded, count, changed, nil
+}
+
+// stateDiff returns the live fields in which stored and folded differ, named like the
+// sportradar fields, plus "overrides".
+func stateDiff(stored, folded *repository.Match) []string {
+	var fields []string
+	if stored.Status != folded.Status {
+		fields = append(fields, sportradar.FieldStatus)
+	}
+	if stored.HomeScore != folded.HomeScore || stored.AwayScore != folded.AwayScore {
+		fields = append(fields, sportradar.FieldScore)
+	}
+	if stored.Possession != folded.Possession || stored.Shots != folded.Shots || stored.Fouls != folded.Fouls {
+		fields = append(fields, sportradar.FieldStats)
+	}
+	if !slices.Equal(stored.Cards, folded.Cards) {
+		fields = append(fields, sportradar.FieldCards)
+	}
+	if stored.LastEvent != folded.LastEvent {
+		fields = append(fields, sportradar.FieldLastEvent)
+	}
+	if !maps.EqualFunc(stored.Overrides, folded.Overrides, func(a, b repository.Override) bool {
+		return a.SetAt.Truncate(overrideTimestampAccuracy).Equal(b.SetAt.Truncate(overrideTimestampAccuracy)) &&
+			a.ExpiresAt.Truncate(overrideTimestampAccuracy).Equal(b.ExpiresAt.Truncate(overrideTimestampAccuracy))
+	}) {
+		fields = append(fields, "overrides")
+	}
+	return fields
+}
+
+// RebuildMatch recomputes the live fields of a match by folding its whole event log, stores
+// them and replaces its snapshots. Clients are sent the new state if it changed.
+func (s *MatchService) RebuildMatch(ctx context.Context, req *proto.RebuildMatchRequest) (*proto.RebuildMatchResponse, error) {
+	if req.MatchId == "" {
+		return nil, status.Error(codes.InvalidArgument, "match_id is required")
+	}
+	match, err := s.repo.GetMatch(ctx, req.MatchId)
+	if err != nil {
+		if errors.Is(err, repository.ErrMatchNotFound) {
+			return nil, status.Errorf(codes.NotFound, "match not found for rebuild: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get match: %v", err)
+	}
+
+	folded, count, changed, err := s.recomputeMatch(ctx, match)
+	switch {
+	case errors.Is(err, errNoCreationEvent):
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot rebuild match %s: %v", req.MatchId, err)
+	case err != nil:
+		return nil, eventLogStatus(err, "failed to rebuild match")
+	}
+	if len(changed) > 0 {
+		log.Printf("Rebuilt match %s from %d events, changed %v", match.MatchID, count, changed)
+		if s.websocketHub != nil {
+			s.websocketHub.BroadcastMatchUpdate(match.MatchID, toMatchResponse(folded))
+		}
+	}
+	if err := s.repo.DeleteSnapshots(ctx, match.MatchID, 0); err != nil {
+		log.Printf("Warning: Failed to drop snapshots of match %s: %v", match.MatchID, err)
+	} else if err := s.saveSnapshot(ctx, folded); err != nil {
+		log.Printf("Warning: Failed to snapshot match %s: %v", match.MatchID, err)
+	}
+
+	return &proto.RebuildMatchResponse{
+		Match:         toMatchResponse(folded),
+		EventsFolded:  int64(count),
+		ChangedFields: changed,
+	}, nil
+}
+
+// CheckMatchConsistency folds the event log of the given matches, or of every active match,
+// and reports those whose stored state disagrees with it. Folds start from the latest
+// snapshot; RebuildMatch fixes a reported match.
+func (s *MatchService) CheckMatchConsistency(ctx context.Context, req *proto.ConsistencyCheckRequest) (*proto.ConsistencyCheckResponse, error) {
+	if len(req.MatchIds) > maxConsistencyCheckIDs {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot check more than %d matches at once", maxConsistencyCheckIDs)
+	}
+
+	var matches []*repository.Match
+	if len(req.MatchIds) == 0 {
+		var err error
+		if matches, err = s.repo.GetActiveMatches(ctx); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get active matches: %v", err)
+		}
+	}
+	for _, matchID := range req.MatchIds {
+		match, err := s.repo.GetMatch(ctx, matchID)
+		if err != nil {
+			if errors.Is(err, repository.ErrMatchNotFound) {
+				return nil, status.Errorf(codes.NotFound, "match not found: %v", err)
+			}
+			return nil, status.Errorf(codes.Internal, "failed to get match: %v", err)
+		}
+		matches = append(matches, match)
+	}
+
+	resp := &proto.ConsistencyCheckResponse{Checked: int32(len(matches))}
+	for _, match := range matches {
+		folded, count, err := s.foldMatch(ctx, match, false)
+		if errors.Is(err, errNoCreationEvent) {
+			resp.UnsourcedMatchIds = append(resp.UnsourcedMatchIds, match.MatchID)
+			continue
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fold events of match %s: %v", match.MatchID, err)
+		}
+		if count >= snapshotInterval {
+			if err := s.saveSnapshot(ctx, folded); err != nil {
+				log.Printf("Warning: Failed to snapshot match %s: %v", match.MatchID, err)
+			}
+		}
+
+		if fields := stateDiff(match, folded); len(fields) > 0 {
+			log.Printf("Match %s disagrees with its events in %v (state at seq %d, events up to %d)", match.MatchID, fields, match.EventSeq, folded.EventSeq)
+			resp.Inconsistencies = append(resp.Inconsistencies, &proto.MatchInconsistency{
+				MatchId:  match.MatchID,
+				Fields:   fields,
+				StateSeq: match.EventSeq,
+				LogSeq:   folded.EventSeq,
+			})
+		}
+	}
+	return resp, nil
+}
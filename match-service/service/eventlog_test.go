@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+)
+
+// testEvent returns an admin event for the home side, as eventFromRequest would.
+func testEvent(matchID, eventID, eventType string) *repository.Event {
+	event := &repository.Event{
+		EventID:   eventID,
+		MatchID:   matchID,
+		EventType: eventType,
+		Timestamp: time.Now().Format(time.RFC3339),
+		TeamSide:  repository.TeamHome,
+		PlayerID:  "p-" + eventID,
+	}
+	if eventType == repository.EventCard {
+		event.Detail = repository.CardYellow
+	}
+	return event
+}
+
+// createTestMatch creates a live match on a memStore.
+func createTestMatch(t *testing.T) (*MatchService, *memStore) {
+	t.Helper()
+	store := newMemStore()
+	s := newMatchService(store, newFakeProvider(), nil)
+	if err := s.createMatch(context.Background(), &repository.Match{MatchID: "m-1", Status: "1st_half"}); err != nil {
+		t.Fatal(err)
+	}
+	return s, store
+}
+
+// checkEventLog checks the log of m-1 has seqs 1 to want, each once, and folds into the
+// stored state.
+func checkEventLog(t *testing.T, s *MatchService, store *memStore, want int64) {
+	t.Helper()
+	ctx := context.Background()
+	events, _ := store.GetEvents(ctx, "m-1", repository.TimelineOptions{})
+	for i, event := range events {
+		if event.Seq != int64(i+1) {
+			t.Fatalf("event #%d has seq %d", i+1, event.Seq)
+		}
+	}
+	if int64(len(events)) != want {
+		t.Errorf("log has %d events, want %d", len(events), want)
+	}
+	match, _ := store.GetMatch(ctx, "m-1")
+	if match.EventSeq != want {
+		t.Errorf("match is at seq %d, want %d", match.EventSeq, want)
+	}
+	folded, _, err := s.foldMatch(ctx, match, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields := stateDiff(match, folded); len(fields) > 0 {
+		t.Errorf("stored match disagrees with its events in %v", fields)
+	}
+}
+
+func TestRecordEventConcurrently(t *testing.T) {
+	s, store := createTestMatch(t)
+	const n = 60
+	types := []string{repository.EventGoal, repository.EventFoul, repository.EventCard}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			match, err := store.GetMatch(context.Background(), "m-1")
+			if err != nil {
+				errs <- err
+				return
+			}
+			event := testEvent("m-1", fmt.Sprintf("e-%d", i), types[i%len(types)])
+			if _, err := s.recordEvent(context.Background(), match, event); err != nil {
+				errs <- fmt.Errorf("event %d: %w", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	match, _ := store.GetMatch(context.Background(), "m-1")
+	if match.HomeScore != n/3 || match.Fouls != n/3 || len(match.Cards) != n/3 {
+		t.Errorf("score %d, fouls %d, cards %d, want %d of each", match.HomeScore, match.Fouls, len(match.Cards), n/3)
+	}
+	checkEventLog(t, s, store, n+1)
+}
+
+// TestRecordEventAppliesPendingEvents records an event after one a failed writer added to
+// the log without applying it.
+func TestRecordEventAppliesPendingEvents(t *testing.T) {
+	s, store := createTestMatch(t)
+	ctx := context.Background()
+	pending := testEvent("m-1", "e-pending", repository.EventGoal)
+	pending.Seq = 2
+	if err := store.AddEvent(ctx, pending); err != nil {
+		t.Fatal(err)
+	}
+
+	match, _ := store.GetMatch(ctx, "m-1")
+	event := testEvent("m-1", "e-foul", repository.EventFoul)
+	if changed, err := s.recordEvent(ctx, match, event); err != nil || !changed {
+		t.Fatalf("recordEvent() = %v, %v", changed, err)
+	}
+	if event.Seq != 3 || match.HomeScore != 1 || match.Fouls != 1 {
+		t.Errorf("foul got seq %d, match has score %d and %d fouls, want seq 3 after the pending goal", event.Seq, match.HomeScore, match.Fouls)
+	}
+	checkEventLog(t, s, store, 3)
+}
+
+// TestRecordEventAfterSeqTaken has another replica take the seq of an event as it is added.
+func TestRecordEventAfterSeqTaken(t *testing.T) {
+	s, store := createTestMatch(t)
+	ctx := context.Background()
+	taken := false
+	store.failUpdate = func(matchID string) error {
+		if !taken { // Called with store.mu held, on adding the event
+			taken = true
+			other := testEvent(matchID, "e-other", repository.EventCard)
+			other.Seq = 2
+			store.events[matchID] = append(store.events[matchID], other)
+		}
+		return nil
+	}
+
+	match, _ := store.GetMatch(ctx, "m-1")
+	event := testEvent("m-1", "e-goal", repository.EventGoal)
+	if _, err := s.recordEvent(ctx, match, event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Seq != 3 || match.HomeScore != 1 || len(match.Cards) != 1 {
+		t.Errorf("goal got seq %d, match has score %d and cards %v, want seq 3 after the other card", event.Seq, match.HomeScore, match.Cards)
+	}
+	checkEventLog(t, s, store, 3)
+}
+
+func TestRecordEventSavesSnapshots(t *testing.T) {
+	s, store := createTestMatch(t)
+	ctx := context.Background()
+	match, _ := store.GetMatch(ctx, "m-1")
+	for i := 2; i <= snapshotInterval+1; i++ {
+		if _, err := s.recordEvent(ctx, match, testEvent("m-1", fmt.Sprintf("e-%d", i), repository.EventFoul)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snapshot, _ := store.GetLatestSnapshot(ctx, "m-1")
+	if snapshot == nil || snapshot.Seq != snapshotInterval || snapshot.State.Fouls != snapshotInterval-1 {
+		t.Fatalf("latest snapshot = %+v, want one at seq %d with %d fouls", snapshot, snapshotInterval, snapshotInterval-1)
+	}
+	folded, count, err := s.foldMatch(ctx, match, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 || folded.Fouls != snapshotInterval {
+		t.Errorf("fold from the snapshot folded %d events into %d fouls, want 1 into %d", count, folded.Fouls, snapshotInterval)
+	}
+}
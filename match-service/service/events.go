@@ -0,0 +1,224 @@
+package service
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	teamSides        = []string{repository.TeamHome, repository.TeamAway}
+	cardColors       = []string{repository.CardYellow, repository.CardSecondYellow, repository.CardRed}
+	penaltyOutcomes  = []string{repository.PenaltyScored, repository.PenaltyMissed, repository.PenaltySaved}
+	playerEventTypes = []string{repository.EventOwnGoal, repository.EventPenalty, repository.EventSubstitution, repository.EventInjury}
+)
+
+// eventFromRequest validates an admin event and returns it as stored. Goals and cards
+// without a player are still accepted, as older admin clients only send a description.
+func eventFromRequest(req *proto.UpdateMatchEventRequest, now time.Time) (*repository.Event, error) {
+	event := &repository.Event{
+		EventID:         fmt.Sprintf("evt-%s-%d", req.MatchId, now.UnixNano()),
+		MatchID:         req.MatchId,
+		EventType:       strings.ToLower(req.EventType),
+		Description:     req.Description,
+		Timestamp:       now.Format(time.RFC3339),
+		TeamSide:        strings.ToLower(req.TeamSide),
+		PlayerID:        req.PlayerId,
+		RelatedPlayerID: req.RelatedPlayerId,
+		Minute:          req.Minute,
+		AddedTime:       req.AddedTime,
+		Detail:          strings.ToLower(req.Detail),
+		HomeScoreChange: req.HomeScoreChange,
+		AwayScoreChange: req.AwayScoreChange,
+	}
+	if event.EventType == repository.EventCard && event.Detail == "" {
+		event.Detail = strings.ToLower(req.CardColor)
+	}
+
+	switch {
+	case event.EventType == "":
+		return nil, status.Error(codes.InvalidArgument, "event_type is required")
+	case slices.Contains(repository.InternalEventTypes, event.EventType):
+		return nil, status.Errorf(codes.InvalidArgument, "%s events are recorded by the service", event.EventType)
+	case event.TeamSide != "" && !slices.Contains(teamSides, event.TeamSide):
+		return nil, status.Errorf(codes.InvalidArgument, "team_side must be one of %v", teamSides)
+	case event.Minute < 0 || event.AddedTime < 0:
+		return nil, status.Error(codes.InvalidArgument, "minute and added_time can't be negative")
+	case slices.Contains(playerEventTypes, event.EventType) && (event.PlayerID == "" || event.TeamSide == ""):
+		return nil, status.Errorf(codes.InvalidArgument, "%s events need player_id and team_side", event.EventType)
+	}
+
+	switch event.EventType {
+	case repository.EventGoal:
+		if event.TeamSide == "" && req.HomeScoreChange == 0 && req.AwayScoreChange == 0 {
+			return nil, status.Error(codes.InvalidArgument, "goal events need team_side or a score change")
+		}
+	case repository.EventPenalty:
+		if !slices.Contains(penaltyOutcomes, event.Detail) {
+			return nil, status.Errorf(codes.InvalidArgument, "penalty events need a detail of %v", penaltyOutcomes)
+		}
+	case repository.EventCard:
+		if !slices.Contains(cardColors, event.Detail) {
+			return nil, status.Errorf(codes.InvalidArgument, "card events need a card_color or detail of %v", cardColors)
+		}
+		if event.PlayerID == "" && event.Description == "" {
+			return nil, status.Error(codes.InvalidArgument, "card events need player_id or description")
+		}
+	case repository.EventSubstitution:
+		if event.RelatedPlayerID == "" {
+			return nil, status.Error(codes.InvalidArgument, "substitution events need related_player_id, the player coming off")
+		}
+	case repository.EventVARReview:
+		if event.Detail == "" && event.Description == "" {
+			return nil, status.Error(codes.InvalidArgument, "var_review events need detail or description")
+		}
+	case repository.EventStatusChange:
+		if event.Description == "" {
+			return nil, status.Error(codes.InvalidArgument, "status_change events need the new status as description")
+		}
+	}
+
+	if event.Description == "" {
+		event.Description = describeEvent(event)
+	}
+	return event, nil
+}
+
+// describeEvent builds a description of a structured event, e.g. "p-10 (assist p-7), home".
+func describeEvent(event *repository.Event) string {
+	var parts []string
+	switch event.EventType {
+	case repository.EventOwnGoal:
+		parts = append(parts, "own goal by "+event.PlayerID)
+	case repository.EventSubstitution:
+		parts = append(parts, fmt.Sprintf("%s on, %s off", event.PlayerID, event.RelatedPlayerID))
+	default:
+		if event.PlayerID != "" {
+			player := event.PlayerID
+			if event.RelatedPlayerID != "" {
+				player += fmt.Sprintf(" (assist %s)", event.RelatedPlayerID)
+			}
+			parts = append(parts, player)
+		}
+		if event.EventType == repository.EventVARReview && event.Detail != "" {
+			parts = append(parts, event.Detail)
+		}
+	}
+	if event.TeamSide != "" {
+		parts = append(parts, event.TeamSide)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// applyEvent updates match for an admin event. It returns the field to lock against
+// provider updates (see repository.Override), if any. homeChange and awayChange are the explicit
+// score changes of the request; without them the scoring side gets a goal.
+func applyEvent(match *repository.Match, event *repository.Event, homeChange, awayChange int32, now time.Time) (overrideField string) {
+	clock := event.MatchMinute()
+	if clock == "" {
+		clock = now.Format("15:04:05") // Add timestamp for clarity
+	}
+
+	switch event.EventType {
+	case repository.EventGoal, repository.EventOwnGoal, repository.EventPenalty:
+		if event.EventType == repository.EventPenalty && event.Detail != repository.PenaltyScored {
+			match.LastEvent = fmt.Sprintf("PENALTY %s: %s (%s)", strings.ToUpper(event.Detail), event.Description, clock)
+			return ""
+		}
+		if homeChange == 0 && awayChange == 0 {
+			scoringSide := event.TeamSide
+			if event.EventType == repository.EventOwnGoal {
+				scoringSide = oppositeSide(scoringSide)
+			}
+			if scoringSide == repository.TeamHome {
+				homeChange = 1
+			} else {
+				awayChange = 1
+			}
+		}
+		match.HomeScore += homeChange
+		match.AwayScore += awayChange
+		label := "GOAL!"
+		switch event.EventType {
+		case repository.EventOwnGoal:
+			label = "OWN GOAL!"
+		case repository.EventPenalty:
+			label = "PENALTY GOAL!"
+		}
+		match.LastEvent = fmt.Sprintf("%s %s (%s)", label, event.Description, clock)
+		return repository.OverrideScore
+	case repository.EventFoul: // Stats stay with the providers, which count every foul
+		match.Fouls++
+		match.LastEvent = fmt.Sprintf("FOUL: %s (%s)", event.Description, clock)
+	case repository.EventCard:
+		booked := event.PlayerID
+		if booked == "" {
+			booked = event.Description
+		}
+		match.Cards = append(match.Cards, fmt.Sprintf("%s_%s", event.Detail, booked))
+		match.LastEvent = fmt.Sprintf("%s CARD: %s (%s)", event.Detail, event.Description, clock)
+		return repository.OverrideCards
+	case repository.EventStatusChange:
+		match.Status = event.Description
+		match.LastEvent = fmt.Sprintf("STATUS: %s (%s)", event.Description, clock)
+		return repository.OverrideStatus
+	case repository.EventSubstitution:
+		match.LastEvent = fmt.Sprintf("SUBSTITUTION: %s (%s)", event.Description, clock)
+	case repository.EventVARReview:
+		match.LastEvent = fmt.Sprintf("VAR: %s (%s)", event.Description, clock)
+	case repository.EventInjury:
+		match.LastEvent = fmt.Sprintf("INJURY: %s (%s)", event.Description, clock)
+	default:
+		match.LastEvent = fmt.Sprintf("%s: %s", event.EventType, event.Description)
+	}
+	return ""
+}
+
+// counterUpdate returns the effect of an admin event on match as a counter update, if all it
+// does is move the score or fouls and set LastEvent and overrides. That effect doesn't
+// depend on the rest of the match, so it can be applied with an atomic increment instead of
+// a compare-and-swap, see repository.IncrementCounters.
+func counterUpdate(match *repository.Match, event *repository.Event) (repository.CounterUpdate, bool) {
+	if slices.Contains(repository.InternalEventTypes, event.EventType) || event.Retracted() {
+		return repository.CounterUpdate{}, false
+	}
+	probe := *match
+	probe.Cards = slices.Clone(match.Cards)
+	probe.Overrides = maps.Clone(match.Overrides)
+	foldEvent(&probe, event)
+	if probe.Status != match.Status || !slices.Equal(probe.Cards, match.Cards) ||
+		probe.Possession != match.Possession || probe.Shots != match.Shots {
+		return repository.CounterUpdate{}, false
+	}
+
+	update := repository.CounterUpdate{
+		HomeScore: probe.HomeScore - match.HomeScore,
+		AwayScore: probe.AwayScore - match.AwayScore,
+		Fouls:     probe.Fouls - match.Fouls,
+		LastEvent: probe.LastEvent,
+	}
+	for field, override := range probe.Overrides {
+		current, ok := match.Overrides[field]
+		if !ok || !current.SetAt.Equal(override.SetAt) || !current.ExpiresAt.Equal(override.ExpiresAt) {
+			if update.Overrides == nil {
+				update.Overrides = make(map[string]repository.Override)
+			}
+			update.Overrides[field] = override
+		}
+	}
+	return update, true
+}
+
+func oppositeSide(side string) string {
+	if side == repository.TeamHome {
+		return repository.TeamAway
+	}
+	return repository.TeamHome
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	natsw "github.com/abaika-abay/live_sports_project/common/pkg/nats"
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+)
+
+// With fan-out enabled, broadcasts are published to NATS instead of being delivered
+// directly, and every replica's hub, this one included, delivers them to its local clients
+// when they come back. Going through NATS for local clients too keeps the order of updates
+// the same on every replica.
+//
+// Sequence numbers are still assigned per replica, so clients resuming after a reconnect
+// should get back to the same replica (sticky sessions) to avoid needless snapshots.
+const matchUpdateSubjects = "match.*.updated"
+
+// MatchUpdateSubject returns the NATS subject updates of a match are published on.
+// Characters with a meaning in subjects are replaced, the payload has the real match ID.
+func MatchUpdateSubject(matchID string) string {
+	return "match." + subjectToken.Replace(matchID) + ".updated"
+}
+
+var subjectToken = strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_")
+
+// fanoutMessage is a broadcast as published to NATS.
+type fanoutMessage struct {
+	MatchID    string          `json:"match_id"`
+	State      bool            `json:"state,omitempty"`      // Data is a MatchResponse
+	Correction bool            `json:"correction,omitempty"` // Data is a Correction
+	Data       json.RawMessage `json:"data"`
+	Origin     string          `json:"origin"` // Replica that published it, for debugging
+}
+
+// EnableFanout makes the hub publish broadcasts to NATS and deliver the broadcasts of all
+// replicas to its clients, and share viewer counts with them. Call it before the first
+// broadcast and before Run.
+func (h *WebSocketHub) EnableFanout(broker *natsw.NATS) error {
+	// Unique per process: replicas sharing a host would otherwise take each other's viewer
+	// counts for their own, see handlePresenceReport
+	hostname, _ := os.Hostname()
+	h.replicaID = fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), time.Now().UnixNano())
+	sub, err := broker.Subscribe(matchUpdateSubjects, h.handleFanoutMessage)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to match updates: %w", err)
+	}
+	presenceSub, err := broker.Subscribe(presenceSubject, h.handlePresenceReport)
+	if err != nil {
+		sub.Unsubscribe()
+		return fmt.Errorf("failed to subscribe to viewer counts: %w", err)
+	}
+	h.fanout = broker
+	h.fanoutSub = sub
+	h.presenceSub = presenceSub
+	return nil
+}
+
+// publish sends a broadcast to every replica. It reports whether that worked; if not,
+// the caller delivers it locally so at least this replica's clients get it.
+func (h *WebSocketHub) publish(matchID string, data interface{}) bool {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("Error marshalling match update for NATS: %v", err)
+		return false
+	}
+	_, isState := data.(*proto.MatchResponse)
+	_, isCorrection := data.(*Correction)
+	message, err := json.Marshal(fanoutMessage{MatchID: matchID, State: isState, Correction: isCorrection, Data: raw, Origin: h.replicaID})
+	if err != nil {
+		log.Printf("Error marshalling match update for NATS: %v", err)
+		return false
+	}
+	if err := h.fanout.Publish(MatchUpdateSubject(matchID), message); err != nil {
+		log.Printf("Failed to publish update for match %s to NATS, delivering locally only: %v", matchID, err)
+		return false
+	}
+	return true
+}
+
+func (h *WebSocketHub) handleFanoutMessage(subject string, data []byte) {
+	var message fanoutMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		log.Printf("Ignoring malformed match update on %s: %v", subject, err)
+		return
+	}
+
+	if message.Correction {
+		var correction Correction
+		if err := json.Unmarshal(message.Data, &correction); err != nil {
+			log.Printf("Ignoring malformed correction on %s: %v", subject, err)
+			return
+		}
+		h.deliver(message.MatchID, &correction)
+		return
+	}
+	if !message.State {
+		h.deliver(message.MatchID, message.Data)
+		return
+	}
+	var match proto.MatchResponse
+	if err := json.Unmarshal(message.Data, &match); err != nil {
+		log.Printf("Ignoring malformed match state on %s: %v", subject, err)
+		return
+	}
+	h.deliver(message.MatchID, &match)
+}
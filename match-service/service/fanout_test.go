@@ -0,0 +1,120 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	natsw "github.com/abaika-abay/live_sports_project/common/pkg/nats"
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/abaika-abay/live_sports_project/match-service/wsclient"
+	natsserver "github.com/nats-io/nats-server/v2/test"
+)
+
+// newFanoutHub starts a hub sharing its broadcasts through the NATS server at url.
+func newFanoutHub(t *testing.T, url string) *WebSocketHub {
+	t.Helper()
+	broker, err := natsw.NewNATS(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(broker.Close)
+	hub := NewWebSocketHub()
+	if err := hub.EnableFanout(broker); err != nil {
+		t.Fatal(err)
+	}
+	if err := broker.Conn.Flush(); err != nil { // The server knows about the subscriptions now
+		t.Fatal(err)
+	}
+	return hub
+}
+
+// dialHub connects a WebSocket client to hub, following matchID.
+func dialHub(t *testing.T, hub *WebSocketHub, matchID string) *wsclient.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(hub.HandleConnections))
+	t.Cleanup(server.Close)
+	client, err := wsclient.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"?match_id="+matchID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	// Subscribed once the pong arrives, as match_id is handled before control messages
+	if _, err := client.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	if frame, err := client.Next(); err != nil || frame.Type != wsclient.FramePong {
+		t.Fatalf("first frame = %+v, %v, want a pong", frame, err)
+	}
+	return client
+}
+
+// nextFrame reads the next frame from client, failing the test if none comes.
+func nextFrame(t *testing.T, client *wsclient.Client) *wsclient.Frame {
+	t.Helper()
+	type result struct {
+		frame *wsclient.Frame
+		err   error
+	}
+	read := make(chan result, 1)
+	go func() {
+		frame, err := client.Next()
+		read <- result{frame, err}
+	}()
+	select {
+	case r := <-read:
+		if r.err != nil {
+			t.Fatal(r.err)
+		}
+		return r.frame
+	case <-time.After(5 * time.Second):
+		t.Fatal("no frame arrived")
+		return nil
+	}
+}
+
+// TestFanout broadcasts every kind of update on one replica and checks clients of both
+// replicas get it.
+func TestFanout(t *testing.T) {
+	server := natsserver.RunRandClientPortServer()
+	defer server.Shutdown()
+	hubA := newFanoutHub(t, server.ClientURL())
+	hubB := newFanoutHub(t, server.ClientURL())
+	clientA := dialHub(t, hubA, "m-1")
+	clientB := dialHub(t, hubB, "m-1")
+
+	hubA.BroadcastMatchUpdate("m-1", &proto.MatchResponse{MatchId: "m-1", Status: "2nd_half", HomeScore: 2, AwayScore: 1})
+	hubA.BroadcastMatchUpdate("m-1", &Correction{
+		Action:   "retract",
+		EventID:  "e-7",
+		Event:    &proto.Event{EventId: "e-7", MatchId: "m-1", EventType: "goal"},
+		Previous: &proto.Event{EventId: "e-7", MatchId: "m-1", EventType: "goal"},
+		Reason:   "offside",
+	})
+	hubA.BroadcastMatchUpdate("m-1", map[string]string{"alert": "source_conflict"})
+
+	for name, client := range map[string]*wsclient.Client{"replica A": clientA, "replica B": clientB} {
+		if frame := nextFrame(t, client); frame.Type != wsclient.FrameSnapshot || frame.Seq != 1 {
+			t.Errorf("%s: first frame = %s #%d, want the snapshot #1", name, frame.Type, frame.Seq)
+		}
+		if match, ok := client.Decoder.Match("m-1"); !ok || match.Status != "2nd_half" || match.HomeScore != 2 || match.AwayScore != 1 {
+			t.Errorf("%s: match state = %v, want 2nd_half 2-1", name, match)
+		}
+
+		frame := nextFrame(t, client)
+		var correction Correction
+		if frame.Type != wsclient.FrameCorrection || json.Unmarshal(frame.Data, &correction) != nil ||
+			correction.EventID != "e-7" || correction.Reason != "offside" || correction.Event.GetEventId() != "e-7" {
+			t.Errorf("%s: second frame = %s %s, want the correction", name, frame.Type, frame.Data)
+		}
+
+		frame = nextFrame(t, client)
+		var alert map[string]string
+		if frame.Type != wsclient.FrameUpdate || json.Unmarshal(frame.Data, &alert) != nil || alert["alert"] != "source_conflict" {
+			t.Errorf("%s: third frame = %s %s, want the alert", name, frame.Type, frame.Data)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+)
+
+// SyncFixtures imports the matches Sportradar has scheduled on the given day.
+// Unknown matches are created, known ones get missing metadata (teams, start time,
+// competition) filled in. It returns the number of matches created.
+func (s *MatchService) SyncFixtures(ctx context.Context, date time.Time) (int, error) {
+	matches, err := s.sportradarClient.FetchSchedule(ctx, date)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch schedule for %s: %w", date.Format("2006-01-02"), err)
+	}
+	return s.importMatches(ctx, matches), nil
+}
+
+// SyncLiveMatches imports every match Sportradar reports as live, so matches that were
+// never scheduled through us (or were missed by SyncFixtures) still get polled.
+func (s *MatchService) SyncLiveMatches(ctx context.Context) (int, error) {
+	matches, err := s.sportradarClient.FetchLiveMatches(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch live matches: %w", err)
+	}
+	return s.importMatches(ctx, matches), nil
+}
+
+// RunFixtureSync imports today's and tomorrow's fixtures plus the live matches every interval,
+// until ctx is cancelled. It is meant to be started in its own goroutine.
+func (s *MatchService) RunFixtureSync(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		for _, day := range []time.Time{now, now.AddDate(0, 0, 1)} {
+			created, err := s.SyncFixtures(ctx, day)
+			if err != nil {
+				log.Printf("Fixture sync: %v", err)
+				continue
+			}
+			log.Printf("Fixture sync: %d new matches for %s", created, day.Format("2006-01-02"))
+		}
+		if created, err := s.SyncLiveMatches(ctx); err != nil {
+			log.Printf("Fixture sync: %v", err)
+		} else if created > 0 {
+			log.Printf("Fixture sync: %d new live matches", created)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// importMatches creates or enriches the given provider matches and returns how many were created.
+func (s *MatchService) importMatches(ctx context.Context, matches []*repository.Match) int {
+	created := 0
+	for _, srMatch := range matches {
+		existing, err := s.repo.GetMatch(ctx, srMatch.MatchID)
+		if errors.Is(err, repository.ErrMatchNotFound) {
+			match := *srMatch
+			if match.Cards == nil {
+				match.Cards = []string{}
+			}
+			err = s.createMatch(ctx, &match)
+			if err == nil {
+				created++
+				continue
+			}
+			if !errors.Is(err, repository.ErrMatchExists) {
+				fmt.Printf("Warning: Failed to create match %s from Sportradar schedule: %v\n", match.MatchID, err)
+				continue
+			}
+			// Another replica or sync created it since the lookup: enrich that one instead
+			existing, err = s.repo.GetMatch(ctx, srMatch.MatchID)
+		}
+		if err != nil {
+			fmt.Printf("Warning: Failed to look up match %s during fixture sync: %v\n", srMatch.MatchID, err)
+			continue
+		}
+		err = s.updateMatch(ctx, existing, func(existing *repository.Match) bool {
+			return enrichMatch(existing, srMatch)
+		})
+		if err != nil {
+			fmt.Printf("Warning: Failed to enrich match %s from Sportradar schedule: %v\n", existing.MatchID, err)
+		}
+	}
+	return created
+}
+
+// enrichMatch fills in metadata missing on match from srMatch. Live fields are left to the poller.
+// It reports whether anything changed.
+func enrichMatch(match, srMatch *repository.Match) bool {
+	changed := false
+	fill := func(dst *string, src string) {
+		if *dst == "" && src != "" {
+			*dst = src
+			changed = true
+		}
+	}
+	fill(&match.HomeTeam, srMatch.HomeTeam)
+	fill(&match.AwayTeam, srMatch.AwayTeam)
+	fill(&match.StartTime, srMatch.StartTime)
+	fill(&match.Sport, srMatch.Sport)
+	fill(&match.Competition, srMatch.Competition)
+	return changed
+}
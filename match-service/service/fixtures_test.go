@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+)
+
+// racingStore hides matches from their next lookup, as if another replica created them
+// right after it.
+type racingStore struct {
+	*memStore
+	hidden map[string]bool
+}
+
+func (s *racingStore) GetMatch(ctx context.Context, matchID string) (*repository.Match, error) {
+	if s.hidden[matchID] {
+		delete(s.hidden, matchID)
+		return nil, fmt.Errorf("match with ID %s: %w", matchID, repository.ErrMatchNotFound)
+	}
+	return s.memStore.GetMatch(ctx, matchID)
+}
+
+func TestImportMatchesEnrichesMatchCreatedConcurrently(t *testing.T) {
+	ctx := context.Background()
+	_, store := createTestMatch(t)
+	racing := &racingStore{memStore: store, hidden: map[string]bool{"m-1": true}}
+	s := newMatchService(racing, newFakeProvider(), nil)
+
+	created := s.importMatches(ctx, []*repository.Match{
+		{MatchID: "m-1", HomeTeam: "Home", AwayTeam: "Away", Status: "not_started"},
+		{MatchID: "m-2", HomeTeam: "Home", AwayTeam: "Away", Status: "not_started"},
+	})
+	if created != 1 {
+		t.Errorf("importMatches() = %d, want only m-2 created", created)
+	}
+	match, _ := store.GetMatch(ctx, "m-1")
+	if match.HomeTeam != "Home" || match.AwayTeam != "Away" || match.Status != "1st_half" {
+		t.Errorf("m-1 = %s v %s, %s, want it enriched with the teams and still live", match.HomeTeam, match.AwayTeam, match.Status)
+	}
+	events, _ := store.GetEvents(ctx, "m-1", repository.TimelineOptions{})
+	if len(events) != 1 || events[0].EventType != repository.EventMatchCreated {
+		t.Errorf("m-1 has events %v, want only its creation", events)
+	}
+}
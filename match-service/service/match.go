@@ -2,7 +2,12 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/abaika-abay/live_sports_project/common/pkg/db"
@@ -13,20 +18,105 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// matchStore is the storage MatchService works with, implemented by repository.MatchRepository.
+type matchStore interface {
+	GetMatch(ctx context.Context, matchID string) (*repository.Match, error)
+	CreateMatch(ctx context.Context, match *repository.Match) error
+	UpdateMatch(ctx context.Context, match *repository.Match) error
+	IncrementCounters(ctx context.Context, matchID string, update repository.CounterUpdate) (*repository.Match, error)
+	SetPeakViewers(ctx context.Context, matchID string, peak int64, at time.Time) error
+	GetActiveMatches(ctx context.Context) ([]*repository.Match, error)
+	GetMatchListForAdmin(ctx context.Context, opts repository.MatchListOptions) ([]*repository.Match, int64, error)
+
+	AddEvent(ctx context.Context, event *repository.Event) error
+	GetEvents(ctx context.Context, matchID string, opts repository.TimelineOptions) ([]*repository.Event, error)
+	GetEvent(ctx context.Context, matchID, eventID string) (*repository.Event, error)
+	ReviseEvent(ctx context.Context, previous, revised *repository.Event, revision repository.EventRevision) error
+
+	SaveSnapshot(ctx context.Context, snapshot *repository.Snapshot) error
+	GetLatestSnapshot(ctx context.Context, matchID string) (*repository.Snapshot, error)
+	DeleteSnapshots(ctx context.Context, matchID string, fromSeq int64) error
+}
+
 type MatchService struct {
 	proto.UnimplementedMatchServiceServer
-	repo             *repository.MatchRepository
+	repo             matchStore
 	sportradarClient sportradar.SportradarClientI
-	websocketHub     *WebSocketHub // Added WebSocket hub
+	websocketHub     *WebSocketHub  // Added WebSocket hub
+	overrideTTL      time.Duration  // Default lifetime of admin overrides
+	matchLocks       [64]sync.Mutex // Striped by match ID, see lockMatch
 	// notificationProducer *kafka.Producer // Placeholder for Kafka/NATS
 }
 
 // NewMatchService initializes the MatchService.
 func NewMatchService(database *db.MongoDB, srClient sportradar.SportradarClientI, wsHub *WebSocketHub) *MatchService {
+	return newMatchService(repository.NewMatchRepository(database), srClient, wsHub)
+}
+
+func newMatchService(store matchStore, srClient sportradar.SportradarClientI, wsHub *WebSocketHub) *MatchService {
 	return &MatchService{
-		repo:             repository.NewMatchRepository(database),
+		repo:             store,
 		sportradarClient: srClient,
 		websocketHub:     wsHub, // Pass the hub
+		overrideTTL:      DefaultOverrideTTL,
+	}
+}
+
+// DefaultOverrideTTL is how long an admin correction wins over provider data,
+// unless the admin asks for a different duration.
+const DefaultOverrideTTL = 30 * time.Minute
+
+// SetOverrideTTL changes the default lifetime of admin overrides.
+func (s *MatchService) SetOverrideTTL(ttl time.Duration) {
+	s.overrideTTL = ttl
+}
+
+// maxUpdateAttempts bounds how often an update of a match is retried on version conflicts.
+const maxUpdateAttempts = 5
+
+// retryOnConflict calls update with match until it doesn't fail with a version conflict
+// (see repository.UpdateMatch), reading match again before every retry, at most
+// maxUpdateAttempts times.
+func (s *MatchService) retryOnConflict(ctx context.Context, match *repository.Match, update func(*repository.Match) error) error {
+	for attempt := 1; ; attempt++ {
+		err := update(match)
+		if !errors.Is(err, repository.ErrVersionConflict) || attempt == maxUpdateAttempts {
+			return err
+		}
+		fresh, err := s.repo.GetMatch(ctx, match.MatchID)
+		if err != nil {
+			return err
+		}
+		*match = *fresh
+	}
+}
+
+// updateMatch lets change modify match and saves it if change reports that it did, applying
+// change again to a fresh read on version conflicts.
+func (s *MatchService) updateMatch(ctx context.Context, match *repository.Match, change func(*repository.Match) bool) error {
+	return s.retryOnConflict(ctx, match, func(match *repository.Match) error {
+		if !change(match) {
+			return nil
+		}
+		return s.repo.UpdateMatch(ctx, match)
+	})
+}
+
+// AdminAlertsChannel is the WebSocket channel admin alerts are broadcast on;
+// admin clients subscribe to it like to a match (ws?match_id=admin-alerts).
+const AdminAlertsChannel = "admin-alerts"
+
+// ReportConflict alerts admins that match data sources keep disagreeing.
+func (s *MatchService) ReportConflict(conflict sportradar.Conflict) {
+	log.Printf("ALERT: %s", conflict)
+	if s.websocketHub != nil {
+		s.websocketHub.BroadcastMatchUpdate(AdminAlertsChannel, map[string]interface{}{
+			"type":     "source_conflict",
+			"match_id": conflict.MatchID,
+			"field":    conflict.Field,
+			"values":   conflict.Values,
+			"since":    conflict.Since.Format(time.RFC3339),
+		})
 	}
 }
 
@@ -53,9 +143,9 @@ func (s *MatchService) GetMatchUpdates(ctx context.Context, req *proto.MatchRequ
 			Shots:      srMatch.Shots,
 			Fouls:      srMatch.Fouls,
 			Cards:      srMatch.Cards,
-			StartTime:  time.Now().Format(time.RFC3339), // Placeholder if not in SR initial fetch
+			StartTime:  time.Now().UTC().Format(time.RFC3339), // Placeholder if not in SR initial fetch
 		}
-		if err := s.repo.CreateMatch(ctx, match); err != nil {
+		if err := s.createMatch(ctx, match); err != nil {
 			fmt.Printf("Warning: Failed to create match %s in DB after fetching from Sportradar: %v\n", req.MatchId, err)
 			// Proceed with SR data even if DB create fails, but log
 		}
@@ -64,112 +154,162 @@ func (s *MatchService) GetMatchUpdates(ctx context.Context, req *proto.MatchRequ
 	// 2. Fetch real-time data from Sportradar
 	srMatch, err := s.sportradarClient.FetchMatchData(ctx, req.MatchId)
 	if err != nil {
-		fmt.Printf("Warning: Could not fetch real-time data from Sportradar for match %s: %v\n", req.MatchId, err)
+		if !errors.Is(err, sportradar.ErrCircuitOpen) { // Provider known to be down, no need to log every request
+			fmt.Printf("Warning: Could not fetch real-time data from Sportradar for match %s: %v\n", req.MatchId, err)
+		}
 		// Return internal data if Sportradar call fails
-		return &proto.MatchResponse{
-			MatchId:    match.MatchID,
-			Status:     match.Status,
-			HomeScore:  match.HomeScore,
-			AwayScore:  match.AwayScore,
-			LastEvent:  match.LastEvent,
-			Possession: match.Possession,
-			Shots:      match.Shots,
-			Fouls:      match.Fouls,
-			Cards:      match.Cards,
-		}, nil
-	}
-
-	// 3. Combine/Merge data: Prioritize Sportradar for live scores/events/stats
-	// You might have more complex merging logic based on data freshness/completeness
-	match.Status = srMatch.Status
-	match.HomeScore = srMatch.HomeScore
-	match.AwayScore = srMatch.AwayScore
-	match.LastEvent = srMatch.LastEvent
-	match.Possession = srMatch.Possession
-	match.Shots = srMatch.Shots
-	match.Fouls = srMatch.Fouls
-	match.Cards = srMatch.Cards // Assuming SR also provides card info, or merge
+		return toMatchResponse(match), nil
+	}
 
-	// Optional: Update internal DB with latest Sportradar data
-	// This keeps your internal data fresh but adds a write operation
-	if err := s.repo.UpdateMatch(ctx, match); err != nil {
+	// 3. Combine/Merge data: fields an admin has overridden keep their value (see applyProviderUpdate).
+	// If anything changed, it is recorded as a provider update, which keeps the DB fresh
+	if _, err := s.recordEvent(ctx, match, stateEvent(srMatch, repository.EventProviderUpdate, time.Now())); err != nil {
 		fmt.Printf("Warning: Failed to update internal match data from Sportradar for match %s: %v\n", req.MatchId, err)
 	}
 
-	return &proto.MatchResponse{
-		MatchId:    match.MatchID,
-		Status:     match.Status,
-		HomeScore:  match.HomeScore,
-		AwayScore:  match.AwayScore,
-		LastEvent:  match.LastEvent,
-		Possession: match.Possession,
-		Shots:      match.Shots,
-		Fouls:      match.Fouls,
-		Cards:      match.Cards,
-	}, nil
+	return toMatchResponse(match), nil
 }
 
 // UpdateMatchEvent handles admin-submitted events.
 func (s *MatchService) UpdateMatchEvent(ctx context.Context, req *proto.UpdateMatchEventRequest) (*proto.MatchResponse, error) {
+	now := time.Now()
+	event, err := eventFromRequest(req, now)
+	if err != nil {
+		return nil, err
+	}
+
 	match, err := s.repo.GetMatch(ctx, req.MatchId)
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "match not found for event update: %v", err)
 	}
 
-	eventDescription := fmt.Sprintf("%s: %s", req.EventType, req.Description)
-	switch req.EventType {
-	case "goal":
-		match.HomeScore += req.HomeScoreChange
-		match.AwayScore += req.AwayScoreChange
-		match.LastEvent = fmt.Sprintf("GOAL! %s (%s)", req.Description, time.Now().Format("15:04:05")) // Add timestamp for clarity
-	case "foul":
-		match.Fouls++
-		match.LastEvent = fmt.Sprintf("FOUL: %s (%s)", req.Description, time.Now().Format("15:04:05"))
-	case "card":
-		match.Cards = append(match.Cards, fmt.Sprintf("%s_%s", req.CardColor, req.Description))
-		match.LastEvent = fmt.Sprintf("%s CARD: %s (%s)", req.CardColor, req.Description, time.Now().Format("15:04:05"))
-	case "status_change":
-		match.Status = req.Description
-		match.LastEvent = fmt.Sprintf("STATUS: %s (%s)", req.Description, time.Now().Format("15:04:05"))
-	default:
-		match.LastEvent = eventDescription
+	event.OverrideTTL = s.resolveOverrideTTL(req.OverrideTtlSeconds)
+
+	if _, err := s.recordEvent(ctx, match, event); err != nil {
+		return nil, eventLogStatus(err, "failed to record match event")
 	}
 
-	if err := s.repo.UpdateMatch(ctx, match); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update match after event: %v", err)
+	// Trigger WebSocket update here!
+	if s.websocketHub != nil {
+		s.websocketHub.BroadcastMatchUpdate(match.MatchID, toMatchResponse(match))
+		fmt.Printf("WebSocket: Broadcasted update for match %s (admin event).\n", match.MatchID)
 	}
 
-	event := &repository.Event{
-		EventID:     fmt.Sprintf("evt-%s-%d", req.MatchId, time.Now().UnixNano()),
-		MatchID:     req.MatchId,
-		EventType:   req.EventType,
-		Description: req.Description,
-		Timestamp:   time.Now().Format(time.RFC3339),
+	// ... (notification producer placeholder) ...
+
+	return toMatchResponse(match), nil
+}
+
+// resolveOverrideTTL returns how long, in seconds, the fields an admin event corrects are
+// locked against provider updates (see repository.Override), given the TTL requested, or
+// -1 for until released. It is resolved when the event is recorded, so folding the event
+// again gives the same result.
+func (s *MatchService) resolveOverrideTTL(requestedSeconds int64) int64 {
+	ttl := s.overrideTTL
+	if requestedSeconds != 0 {
+		ttl = time.Duration(requestedSeconds) * time.Second // Negative keeps it until released
 	}
-	if err := s.repo.AddEvent(ctx, event); err != nil {
-		fmt.Printf("Warning: Failed to add event record for match %s: %v\n", req.MatchId, err)
+	if ttl <= 0 {
+		return -1
 	}
+	return max(int64(ttl/time.Second), 1)
+}
 
-	// Trigger WebSocket update here!
-	if s.websocketHub != nil {
-		// Convert repository.Match to proto.MatchResponse for broadcasting
-		protoMatch := &proto.MatchResponse{
-			MatchId:    match.MatchID,
-			Status:     match.Status,
-			HomeScore:  match.HomeScore,
-			AwayScore:  match.AwayScore,
-			LastEvent:  match.LastEvent,
-			Possession: match.Possession,
-			Shots:      match.Shots,
-			Fouls:      match.Fouls,
-			Cards:      match.Cards,
+// ReleaseMatchOverride removes admin overrides from a match and resyncs it from the providers,
+// so the released fields show provider data again right away.
+func (s *MatchService) ReleaseMatchOverride(ctx context.Context, req *proto.ReleaseMatchOverrideRequest) (*proto.MatchResponse, error) {
+	for _, field := range req.Fields {
+		if !slices.Contains(repository.OverridableFields, field) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown override field %q, expected one of %v", field, repository.OverridableFields)
 		}
-		s.websocketHub.BroadcastMatchUpdate(match.MatchID, protoMatch)
-		fmt.Printf("WebSocket: Broadcasted update for match %s (admin event).\n", match.MatchID)
 	}
 
-	// ... (notification producer placeholder) ...
+	match, err := s.repo.GetMatch(ctx, req.MatchId)
+	if err != nil {
+		if errors.Is(err, repository.ErrMatchNotFound) {
+			return nil, status.Errorf(codes.NotFound, "match not found for override release: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get match: %v", err)
+	}
+	release := newEvent(req.MatchId, repository.EventOverrideRelease, time.Now())
+	release.Detail = strings.Join(req.Fields, ",")
+	if _, err := s.recordEvent(ctx, match, release); err != nil {
+		return nil, eventLogStatus(err, "failed to release overrides")
+	}
 
+	srMatch, err := s.sportradarClient.FetchMatchData(ctx, req.MatchId)
+	if err != nil {
+		fmt.Printf("Warning: Could not resync match %s after releasing overrides: %v\n", req.MatchId, err)
+	} else if _, err := s.recordEvent(ctx, match, stateEvent(srMatch, repository.EventProviderUpdate, time.Now())); err != nil {
+		fmt.Printf("Warning: Failed to update match %s after releasing overrides: %v\n", req.MatchId, err)
+	}
+
+	// Broadcast even if no value changed, clients still need to see the override is gone
+	if s.websocketHub != nil {
+		s.websocketHub.BroadcastMatchUpdate(match.MatchID, toMatchResponse(match))
+	}
+	return toMatchResponse(match), nil
+}
+
+// SyncMatchFromProvider pulls the latest Sportradar state for a match, persists it and
+// broadcasts it to WebSocket subscribers if anything changed. Used by the background poller,
+// which gets the synced match back to decide when to poll next.
+func (s *MatchService) SyncMatchFromProvider(ctx context.Context, matchID string) (*repository.Match, error) {
+	match, err := s.repo.GetMatch(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get match from DB: %w", err)
+	}
+
+	srMatch, err := s.sportradarClient.FetchMatchData(ctx, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch real-time data from Sportradar: %w", err)
+	}
+
+	changed, err := s.recordEvent(ctx, match, stateEvent(srMatch, repository.EventProviderUpdate, time.Now()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to record provider update: %w", err)
+	}
+	if !changed {
+		return match, nil
+	}
+
+	if s.websocketHub != nil {
+		s.websocketHub.BroadcastMatchUpdate(match.MatchID, toMatchResponse(match))
+	}
+	return match, nil
+}
+
+// applyProviderUpdate copies the live fields Sportradar is authoritative for onto match,
+// except for fields an admin has overridden. It reports whether anything changed,
+// including overrides expiring.
+func applyProviderUpdate(match, srMatch *repository.Match, now time.Time) bool {
+	changed := match.DropExpiredOverrides(now)
+
+	if !match.IsOverridden(repository.OverrideStatus, now) {
+		changed = changed || srMatch.Status != match.Status
+		match.Status = srMatch.Status
+	}
+	if !match.IsOverridden(repository.OverrideScore, now) {
+		changed = changed || srMatch.HomeScore != match.HomeScore || srMatch.AwayScore != match.AwayScore
+		match.HomeScore = srMatch.HomeScore
+		match.AwayScore = srMatch.AwayScore
+	}
+	if !match.IsOverridden(repository.OverrideCards, now) {
+		changed = changed || !slices.Equal(srMatch.Cards, match.Cards)
+		match.Cards = slices.Clone(srMatch.Cards) // Don't share the slice with the client's cache
+	}
+
+	changed = changed || srMatch.LastEvent != match.LastEvent ||
+		srMatch.Possession != match.Possession || srMatch.Shots != match.Shots || srMatch.Fouls != match.Fouls
+	match.LastEvent = srMatch.LastEvent
+	match.Possession = srMatch.Possession
+	match.Shots = srMatch.Shots
+	match.Fouls = srMatch.Fouls
+	return changed
+}
+
+// toMatchResponse converts a stored match into its gRPC/WebSocket representation.
+func toMatchResponse(match *repository.Match) *proto.MatchResponse {
 	return &proto.MatchResponse{
 		MatchId:    match.MatchID,
 		Status:     match.Status,
@@ -180,5 +320,12 @@ func (s *MatchService) UpdateMatchEvent(ctx context.Context, req *proto.UpdateMa
 		Shots:      match.Shots,
 		Fouls:      match.Fouls,
 		Cards:      match.Cards,
-	}, nil
+
+		OverriddenFields: match.OverriddenFields(time.Now()),
+		HomeTeam:         match.HomeTeam,
+		AwayTeam:         match.AwayTeam,
+		StartTime:        match.StartTime,
+		Competition:      match.Competition,
+		PeakViewers:      match.PeakViewers,
+	}
 }
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestUpdateMatchEventConcurrently has admins post goals and fouls to a match at once and
+// checks none of them gets lost.
+func TestUpdateMatchEventConcurrently(t *testing.T) {
+	s, store := createTestMatch(t)
+	ctx := context.Background()
+	before, _ := store.GetMatch(ctx, "m-1")
+	const n = 40
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*n)
+	for i := 0; i < n; i++ {
+		for _, eventType := range []string{repository.EventGoal, repository.EventFoul} {
+			wg.Add(1)
+			go func(i int, eventType string) {
+				defer wg.Done()
+				_, err := s.UpdateMatchEvent(ctx, &proto.UpdateMatchEventRequest{
+					MatchId:     "m-1",
+					EventType:   eventType,
+					TeamSide:    "home",
+					Description: fmt.Sprintf("%s #%d", eventType, i),
+				})
+				if err != nil {
+					errs <- fmt.Errorf("%s #%d: %w", eventType, i, err)
+				}
+			}(i, eventType)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	match, _ := store.GetMatch(ctx, "m-1")
+	if match.HomeScore != n || match.Fouls != n {
+		t.Errorf("score %d, fouls %d, want %d of each", match.HomeScore, match.Fouls, n)
+	}
+	if match.Version != before.Version+2*n || match.EventSeq != before.EventSeq+2*n {
+		t.Errorf("version %d, event seq %d, want both %d updates further than %d and %d", match.Version, match.EventSeq, 2*n, before.Version, before.EventSeq)
+	}
+	checkEventLog(t, s, store, 2*n+1)
+}
+
+func TestUpdateMatchEventGivesUpOnConflicts(t *testing.T) {
+	s, store := createTestMatch(t)
+	ctx := context.Background()
+	attempts := 0
+	store.failUpdate = func(matchID string) error {
+		attempts++
+		return fmt.Errorf("match with ID %s: %w", matchID, repository.ErrVersionConflict)
+	}
+
+	_, err := s.UpdateMatchEvent(ctx, &proto.UpdateMatchEventRequest{MatchId: "m-1", EventType: "goal", TeamSide: "home"})
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("UpdateMatchEvent() = %v, want Aborted", err)
+	}
+	if attempts != maxUpdateAttempts {
+		t.Errorf("tried %d times, want %d", attempts, maxUpdateAttempts)
+	}
+	store.failUpdate = nil
+	match, _ := store.GetMatch(ctx, "m-1")
+	if match.HomeScore != 0 {
+		t.Errorf("score %d after giving up, want 0", match.HomeScore)
+	}
+	checkEventLog(t, s, store, 1)
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+)
+
+// Clock abstracts the current time and tickers so poll scheduling can be driven by a fake
+// clock in tests.
+type Clock interface {
+	Now() time.Time
+	// NewTicker returns a ticker firing every d, like time.NewTicker.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker is a ticker of a Clock.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) NewTicker(d time.Duration) Ticker { return realTicker{time.NewTicker(d)} }
+
+type realTicker struct{ *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time { return t.Ticker.C }
+
+// PollPolicy decides how often a match should be polled from Sportradar.
+type PollPolicy interface {
+	// NextInterval returns how long to wait before polling the match again.
+	// ok is false when the match should not be polled anymore (e.g. after full time).
+	NextInterval(match *repository.Match, now time.Time) (interval time.Duration, ok bool)
+}
+
+// PhaseIntervals holds the poll intervals for each phase of a match.
+type PhaseIntervals struct {
+	Far            time.Duration // Kick-off is more than UpcomingWindow away
+	Upcoming       time.Duration // Kick-off is within UpcomingWindow
+	KickOff        time.Duration // Kick-off is within KickOffWindow, or overdue but not yet live
+	Live           time.Duration // Ball in play
+	Break          time.Duration // Half-time, the breaks before extra time and penalties, or interrupted
+	Unknown        time.Duration // Status or start time we can't interpret
+	UpcomingWindow time.Duration
+	KickOffWindow  time.Duration
+}
+
+// DefaultPhaseIntervals returns intervals that keep a trial Sportradar key within quota
+// while still picking up goals within a few seconds.
+func DefaultPhaseIntervals() PhaseIntervals {
+	return PhaseIntervals{
+		Far:            30 * time.Minute,
+		Upcoming:       5 * time.Minute,
+		KickOff:        30 * time.Second,
+		Live:           5 * time.Second,
+		Break:          30 * time.Second,
+		Unknown:        1 * time.Minute,
+		UpcomingWindow: 24 * time.Hour,
+		KickOffWindow:  15 * time.Minute,
+	}
+}
+
+// Statuses of matches in progress (see repository.ActiveStatuses), by phase.
+var (
+	liveStatuses  = []string{"live", "1st_half", "2nd_half", "1st_extra", "2nd_extra", "overtime", "penalties"}
+	breakStatuses = []string{"halftime", "awaiting_extra", "extra_time_halftime", "awaiting_penalties", "interrupted", "pause"}
+)
+
+// PhasePollPolicy is a PollPolicy based on the match phase (time to kick-off, live, break,
+// finished). Intervals can be overridden per sport or per sport and competition.
+type PhasePollPolicy struct {
+	defaults  PhaseIntervals
+	overrides map[string]PhaseIntervals // Keyed by "sport" or "sport/competition", lower-case
+}
+
+// NewPhasePollPolicy creates a PhasePollPolicy using defaults for every match without an override.
+func NewPhasePollPolicy(defaults PhaseIntervals) *PhasePollPolicy {
+	return &PhasePollPolicy{
+		defaults:  defaults,
+		overrides: make(map[string]PhaseIntervals),
+	}
+}
+
+// SetOverride configures the intervals for a sport, or for a single competition
+// of a sport when competition is not empty. It is not safe to call while polling.
+func (p *PhasePollPolicy) SetOverride(sport, competition string, intervals PhaseIntervals) {
+	p.overrides[overrideKey(sport, competition)] = intervals
+}
+
+// NextInterval implements PollPolicy.
+func (p *PhasePollPolicy) NextInterval(match *repository.Match, now time.Time) (time.Duration, bool) {
+	intervals := p.intervalsFor(match)
+
+	status := strings.ToLower(match.Status)
+	switch {
+	case repository.IsFinishedStatus(status):
+		return 0, false
+	case slices.Contains(breakStatuses, status):
+		return intervals.Break, true
+	case status == "scheduled" || status == "not_started":
+		startTime, err := time.Parse(time.RFC3339, match.StartTime)
+		if err != nil {
+			return intervals.Unknown, true
+		}
+		untilKickOff := startTime.Sub(now)
+		switch {
+		case untilKickOff > intervals.UpcomingWindow:
+			return intervals.Far, true
+		case untilKickOff > intervals.KickOffWindow:
+			return intervals.Upcoming, true
+		default:
+			return intervals.KickOff, true // Also covers late kick-offs the provider hasn't reported yet
+		}
+	case slices.Contains(liveStatuses, status):
+		return intervals.Live, true
+	default:
+		return intervals.Unknown, true
+	}
+}
+
+func (p *PhasePollPolicy) intervalsFor(match *repository.Match) PhaseIntervals {
+	if match.Competition != "" {
+		if intervals, ok := p.overrides[overrideKey(match.Sport, match.Competition)]; ok {
+			return intervals
+		}
+	}
+	if intervals, ok := p.overrides[overrideKey(match.Sport, "")]; ok {
+		return intervals
+	}
+	return p.defaults
+}
+
+func overrideKey(sport, competition string) string {
+	if competition == "" {
+		return strings.ToLower(sport)
+	}
+	return strings.ToLower(sport + "/" + competition)
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+)
+
+// MatchPoller periodically refreshes every scheduled or live match from Sportradar.
+// Active matches are rediscovered from the DB regularly, so newly created matches
+// are picked up without a restart. How often each match is polled is decided by a
+// PollPolicy, and matches the policy considers done drop out of the rotation.
+type MatchPoller struct {
+	service     *MatchService
+	policy      PollPolicy
+	clock       Clock
+	tick        time.Duration // Resolution at which due matches are dispatched
+	discovery   time.Duration // How often the DB is scanned for active matches
+	timeout     time.Duration // Per-match timeout for a single poll
+	concurrency int           // Maximum number of matches polled at the same time
+
+	mu            sync.Mutex
+	scheduled     map[string]*scheduledMatch
+	lastDiscovery time.Time
+}
+
+// scheduledMatch is the poller's view of a single match.
+type scheduledMatch struct {
+	match    *repository.Match // Last known state, fed to the PollPolicy
+	nextPoll time.Time
+	inFlight bool // Set while a worker polls the match, so slow polls don't pile up
+}
+
+// NewMatchPoller creates a poller that syncs matches through the given MatchService.
+func NewMatchPoller(s *MatchService, policy PollPolicy, concurrency int) *MatchPoller {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &MatchPoller{
+		service:     s,
+		policy:      policy,
+		clock:       realClock{},
+		tick:        1 * time.Second,
+		discovery:   10 * time.Second,
+		timeout:     3 * time.Second,
+		concurrency: concurrency,
+		scheduled:   make(map[string]*scheduledMatch),
+	}
+}
+
+// SetClock replaces the clock used for scheduling and ticking. Meant for tests; call it before Run.
+func (p *MatchPoller) SetClock(c Clock) {
+	p.clock = c
+}
+
+// Run polls until ctx is cancelled. It is meant to be started in its own goroutine.
+func (p *MatchPoller) Run(ctx context.Context) {
+	jobs := make(chan string, p.concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < p.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for matchID := range jobs {
+				p.poll(ctx, matchID)
+			}
+		}()
+	}
+	defer func() {
+		close(jobs)
+		wg.Wait()
+	}()
+
+	ticker := p.clock.NewTicker(p.tick)
+	defer ticker.Stop()
+
+	log.Printf("Starting Sportradar background polling with %d workers", p.concurrency)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Polling: stopped")
+			return
+		case <-ticker.C():
+			if p.clock.Now().Sub(p.lastDiscovery) >= p.discovery {
+				p.discover(ctx)
+			}
+			p.dispatchDue(ctx, jobs)
+		}
+	}
+}
+
+// discover syncs the schedule with the active matches in the DB. New matches are due
+// immediately; matches that are no longer active are dropped.
+func (p *MatchPoller) discover(ctx context.Context) {
+	listCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	matches, err := p.service.repo.GetActiveMatches(listCtx)
+	if err != nil {
+		log.Printf("Polling: Could not list active matches from DB: %v", err)
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lastDiscovery = p.clock.Now()
+
+	active := make(map[string]bool, len(matches))
+	for _, match := range matches {
+		active[match.MatchID] = true
+		if _, ok := p.scheduled[match.MatchID]; !ok {
+			p.scheduled[match.MatchID] = &scheduledMatch{match: match, nextPoll: p.lastDiscovery}
+		}
+	}
+	for matchID, sm := range p.scheduled {
+		if !active[matchID] && !sm.inFlight {
+			delete(p.scheduled, matchID)
+		}
+	}
+}
+
+// dispatchDue queues every match whose next poll time has passed.
+func (p *MatchPoller) dispatchDue(ctx context.Context, jobs chan<- string) {
+	now := p.clock.Now()
+	var due []string
+
+	p.mu.Lock()
+	for matchID, sm := range p.scheduled {
+		if !sm.inFlight && !now.Before(sm.nextPoll) {
+			sm.inFlight = true
+			due = append(due, matchID)
+		}
+	}
+	p.mu.Unlock()
+
+	for i, matchID := range due {
+		select {
+		case jobs <- matchID:
+		case <-ctx.Done():
+			for _, id := range due[i:] {
+				p.reschedule(id, nil)
+			}
+			return
+		}
+	}
+}
+
+// poll syncs a single match and schedules its next poll.
+func (p *MatchPoller) poll(ctx context.Context, matchID string) {
+	pollCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	match, err := p.service.SyncMatchFromProvider(pollCtx, matchID)
+	if err != nil {
+		log.Printf("Polling: Failed to sync match %s: %v", matchID, err)
+	}
+	p.reschedule(matchID, match)
+}
+
+// reschedule asks the policy when match should be polled next. A nil match keeps the
+// last known state, e.g. when the poll failed.
+func (p *MatchPoller) reschedule(matchID string, match *repository.Match) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	sm, ok := p.scheduled[matchID]
+	if !ok {
+		return
+	}
+	sm.inFlight = false
+	if match != nil {
+		sm.match = match
+	}
+
+	now := p.clock.Now()
+	interval, ok := p.policy.NextInterval(sm.match, now)
+	if !ok {
+		delete(p.scheduled, matchID)
+		log.Printf("Polling: Match %s is %s, no longer polling it.", matchID, sm.match.Status)
+		return
+	}
+	sm.nextPoll = now.Add(interval)
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+)
+
+// fakeClock is a Clock that only moves when told to. Advance fires its tickers once, however
+// far it moves, like a real ticker that fell behind.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+type fakeTicker struct {
+	c       chan time.Time
+	stopped chan struct{}
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.c }
+func (t *fakeTicker) Stop()               { close(t.stopped) }
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTicker(time.Duration) Ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ticker := &fakeTicker{c: make(chan time.Time), stopped: make(chan struct{})}
+	c.tickers = append(c.tickers, ticker)
+	return ticker
+}
+
+// Advance moves the clock forward by d and waits until every ticker's tick was received.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	now, tickers := c.now, c.tickers
+	c.mu.Unlock()
+	for _, ticker := range tickers {
+		select {
+		case ticker.c <- now:
+		case <-ticker.stopped:
+		}
+	}
+}
+
+// waitForTicker waits until something took a ticker from the clock.
+func (c *fakeClock) waitForTicker(t *testing.T) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		c.mu.Lock()
+		n := len(c.tickers)
+		c.mu.Unlock()
+		if n > 0 {
+			return
+		}
+	}
+	t.Fatal("no ticker was started")
+}
+
+// pollerRun is a MatchPoller running on a fakeClock.
+type pollerRun struct {
+	t      *testing.T
+	poller *MatchPoller
+	clock  *fakeClock
+}
+
+// runPoller starts poller on clock until the test ends.
+func runPoller(t *testing.T, poller *MatchPoller, clock *fakeClock) *pollerRun {
+	t.Helper()
+	poller.SetClock(clock)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		poller.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	clock.waitForTicker(t)
+	return &pollerRun{t: t, poller: poller, clock: clock}
+}
+
+// step advances the clock by d and waits until the polls that made due are done.
+func (r *pollerRun) step(d time.Duration) {
+	r.t.Helper()
+	r.clock.Advance(d)
+	r.clock.Advance(0) // Received once the previous tick's matches are dispatched
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(time.Millisecond) {
+		r.poller.mu.Lock()
+		busy := false
+		for _, sm := range r.poller.scheduled {
+			busy = busy || sm.inFlight
+		}
+		r.poller.mu.Unlock()
+		if !busy {
+			return
+		}
+		if time.Now().After(deadline) {
+			r.t.Fatal("polls didn't finish")
+		}
+	}
+}
+
+// TestMatchPollerPhases follows a match from far off through kick-off, both halves, extra
+// time and penalties to full time, checking it is polled at the interval of each phase.
+func TestMatchPollerPhases(t *testing.T) {
+	intervals := DefaultPhaseIntervals()
+	start := time.Date(2026, 5, 30, 10, 0, 0, 0, time.UTC)
+	kickOff := start.Add(48 * time.Hour)
+
+	match := &repository.Match{
+		MatchID:   "m-1",
+		HomeTeam:  "Home",
+		AwayTeam:  "Away",
+		StartTime: kickOff.Format(time.RFC3339),
+		Status:    "not_started",
+		LastEvent: "Match scheduled",
+	}
+	store := newMemStore()
+	provider := newFakeProvider()
+	provider.set(match)
+	s := newMatchService(store, provider, nil)
+	if err := s.createMatch(context.Background(), match); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := &fakeClock{now: start}
+	run := runPoller(t, NewMatchPoller(s, NewPhasePollPolicy(intervals), 2), clock)
+	step := run.step
+	// expectPollAfter checks the match isn't polled before interval has passed, but right then.
+	expectPollAfter := func(phase string, interval time.Duration) {
+		t.Helper()
+		polls := provider.fetchCount()
+		step(interval - time.Second)
+		if got := provider.fetchCount(); got != polls {
+			t.Fatalf("%s: match polled %d times within %v, want none", phase, got-polls, interval-time.Second)
+		}
+		step(time.Second)
+		if got := provider.fetchCount(); got != polls+1 {
+			t.Fatalf("%s: match polled %d times after %v, want once", phase, got-polls, interval)
+		}
+	}
+	report := func(status string) {
+		reported := *match
+		reported.Status = status
+		provider.set(&reported)
+	}
+
+	step(0) // Discovered and polled right away
+	if got := provider.fetchCount(); got != 1 {
+		t.Fatalf("match polled %d times on discovery, want once", got)
+	}
+
+	expectPollAfter("far", intervals.Far)
+	step(kickOff.Add(-intervals.UpcomingWindow).Sub(clock.Now())) // Polled at the first tick within the window
+	expectPollAfter("upcoming", intervals.Upcoming)
+	step(kickOff.Add(-intervals.KickOffWindow).Sub(clock.Now()))
+	expectPollAfter("kick-off", intervals.KickOff)
+
+	// Each status is picked up by one poll, which schedules the next one for its phase
+	for _, phase := range []struct {
+		status   string
+		interval time.Duration
+	}{
+		{"1st_half", intervals.Live},
+		{"halftime", intervals.Break},
+		{"2nd_half", intervals.Live},
+		{"awaiting_extra", intervals.Break},
+		{"1st_extra", intervals.Live},
+		{"extra_time_halftime", intervals.Break},
+		{"2nd_extra", intervals.Live},
+		{"awaiting_penalties", intervals.Break},
+		{"penalties", intervals.Live},
+	} {
+		report(phase.status)
+		step(intervals.KickOff) // Longest interval before this loop and within it
+		expectPollAfter(phase.status, phase.interval)
+		expectPollAfter(phase.status, phase.interval)
+	}
+
+	report("ended")
+	step(intervals.Live)
+	polls := provider.fetchCount()
+	if stored, _ := store.GetMatch(context.Background(), match.MatchID); stored.Status != "ended" {
+		t.Fatalf("stored status = %q, want ended", stored.Status)
+	}
+	step(time.Hour) // Discovery runs too, and doesn't pick the match up again
+	step(time.Hour)
+	if got := provider.fetchCount(); got != polls {
+		t.Fatalf("finished match polled %d more times", got-polls)
+	}
+}
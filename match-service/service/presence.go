@@ -0,0 +1,274 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// Viewers of a match are the WebSocket and SSE clients following its match topic, plus the
+// gRPC streams following it. Every presenceInterval each replica counts its own viewers and,
+// with fan-out enabled, publishes the counts to the others, so every replica knows the
+// totals. Subscribers of a match get a "viewers" frame when its total changes; it has no
+// seq and isn't replayed.
+const (
+	presenceInterval   = 10 * time.Second
+	presenceStaleAfter = 3 * presenceInterval // Counts of replicas we haven't heard from for this long are dropped
+	presenceSubject    = "presence.viewers"
+
+	FrameViewers = "viewers"
+
+	defaultMostWatched = 10
+	maxMostWatched     = 50
+)
+
+// ViewersFrame tells subscribers of a match how many viewers it has across all replicas.
+type ViewersFrame struct {
+	Type    string `json:"type"`
+	MatchID string `json:"match_id"`
+	Viewers int64  `json:"viewers"`
+}
+
+// MatchViewerCount is a match with its viewers, see MostWatched.
+type MatchViewerCount struct {
+	MatchID     string
+	Viewers     int64
+	PeakViewers int64
+}
+
+// presenceReport is a replica's viewer counts as published to NATS.
+type presenceReport struct {
+	Replica string           `json:"replica"`
+	Viewers map[string]int64 `json:"viewers"` // Per match ID
+}
+
+// presence is the hub's viewer bookkeeping, guarded by its mu.
+type presence struct {
+	replicas map[string]replicaViewers // By replica ID, this one included
+	totals   map[string]int64          // Per match across replicas, as of the last update
+	peaks    map[string]*peakViewers
+	// Persists the peak of a match after full time, see SetPeakRecorder
+	recordPeak func(matchID string, peak int64, at time.Time)
+}
+
+type replicaViewers struct {
+	viewers map[string]int64
+	at      time.Time
+}
+
+type peakViewers struct {
+	viewers  int64
+	at       time.Time
+	recorded bool // Handed to recordPeak, which happens once the match is finished
+}
+
+func newPresence() presence {
+	return presence{
+		replicas: make(map[string]replicaViewers),
+		totals:   make(map[string]int64),
+		peaks:    make(map[string]*peakViewers),
+	}
+}
+
+// SetPeakRecorder sets the function persisting the peak viewers of a match once it is
+// finished. Every replica calls it with the same peak, so it must be idempotent.
+func (h *WebSocketHub) SetPeakRecorder(record func(matchID string, peak int64, at time.Time)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.presence.recordPeak = record
+}
+
+// MostWatched returns the matches with viewers, most viewers first.
+func (h *WebSocketHub) MostWatched() []MatchViewerCount {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	matches := make([]MatchViewerCount, 0, len(h.presence.totals))
+	for matchID, viewers := range h.presence.totals {
+		watched := MatchViewerCount{MatchID: matchID, Viewers: viewers}
+		if peak, ok := h.presence.peaks[matchID]; ok {
+			watched.PeakViewers = peak.viewers
+		}
+		matches = append(matches, watched)
+	}
+	slices.SortFunc(matches, func(a, b MatchViewerCount) int {
+		return cmp.Or(cmp.Compare(b.Viewers, a.Viewers), strings.Compare(a.MatchID, b.MatchID))
+	})
+	return matches
+}
+
+// updatePresence counts this replica's viewers, shares them with the other replicas, pushes
+// changed totals to subscribers and records the peaks of finished matches.
+func (h *WebSocketHub) updatePresence() {
+	h.mu.Lock()
+	local := h.localViewersLocked()
+	h.presence.replicas[h.replicaID] = replicaViewers{viewers: local, at: time.Now()}
+	finished := h.aggregatePresenceLocked(time.Now())
+	recordPeak := h.presence.recordPeak
+	h.mu.Unlock()
+
+	if h.fanout != nil {
+		report, err := json.Marshal(presenceReport{Replica: h.replicaID, Viewers: local})
+		if err == nil {
+			err = h.fanout.Publish(presenceSubject, report)
+		}
+		if err != nil {
+			log.Printf("Failed to publish viewer counts to NATS: %v", err)
+		}
+	}
+	if recordPeak != nil {
+		for matchID, peak := range finished {
+			recordPeak(matchID, peak.viewers, peak.at)
+		}
+	}
+}
+
+// localViewersLocked counts the viewers connected to this replica. The caller must hold h.mu.
+func (h *WebSocketHub) localViewersLocked() map[string]int64 {
+	viewers := make(map[string]int64)
+	for topic, clients := range h.topicSubscriptions {
+		if matchID, ok := strings.CutPrefix(topic, TopicMatch+":"); ok {
+			viewers[matchID] += int64(len(clients))
+		}
+	}
+	for sub := range h.streams {
+		for matchID := range sub.matchIDs {
+			viewers[matchID]++
+		}
+	}
+	return viewers
+}
+
+// aggregatePresenceLocked sums the counts of all replicas. It returns the peaks of the
+// matches that finished since the last call. The caller must hold h.mu.
+func (h *WebSocketHub) aggregatePresenceLocked(now time.Time) map[string]peakViewers {
+	totals := make(map[string]int64)
+	for replica, counts := range h.presence.replicas {
+		if now.Sub(counts.at) > presenceStaleAfter {
+			delete(h.presence.replicas, replica)
+			continue
+		}
+		for matchID, viewers := range counts.viewers {
+			totals[matchID] += viewers
+		}
+	}
+
+	for matchID, viewers := range totals {
+		if viewers != h.presence.totals[matchID] {
+			h.pushViewersLocked(matchID, viewers)
+		}
+		peak, ok := h.presence.peaks[matchID]
+		if !ok {
+			peak = &peakViewers{}
+			h.presence.peaks[matchID] = peak
+		}
+		if viewers > peak.viewers {
+			*peak = peakViewers{viewers: viewers, at: now}
+		}
+	}
+	h.presence.totals = totals
+
+	finished := make(map[string]peakViewers)
+	for matchID, peak := range h.presence.peaks {
+		replay := h.replays[matchID]
+		if !peak.recorded && replay != nil && replay.latestState != nil && repository.IsFinishedStatus(replay.latestState.Status) {
+			finished[matchID] = *peak
+			peak.recorded = true
+		}
+		if totals[matchID] == 0 && (peak.recorded || replay == nil) {
+			delete(h.presence.peaks, matchID) // Nobody left, and nothing left to record
+		}
+	}
+	return finished
+}
+
+// pushViewersLocked sends a match's viewer count to this replica's subscribers of the match.
+// The caller must hold h.mu.
+func (h *WebSocketHub) pushViewersLocked(matchID string, viewers int64) {
+	clients := h.topicSubscriptions[MatchTopic(matchID)]
+	if len(clients) == 0 {
+		return
+	}
+
+	var frame encodedFrame
+	var err error
+	if frame[EncodingJSON], err = json.Marshal(ViewersFrame{Type: FrameViewers, MatchID: matchID, Viewers: viewers}); err == nil {
+		frame[EncodingProtobuf], err = protobuf.Marshal(&proto.UpdateFrame{Type: FrameViewers, MatchId: matchID, Viewers: viewers})
+	}
+	if err != nil {
+		log.Printf("Error encoding viewer count of match %s: %v", matchID, err)
+		return
+	}
+	for client := range clients {
+		// No matchID: viewer counts are outside the match's seq order
+		h.sendLocked(client, outgoingFrame{data: frame[client.encoding], binary: client.encoding != EncodingJSON}, nil)
+	}
+}
+
+func (h *WebSocketHub) handlePresenceReport(subject string, data []byte) {
+	var report presenceReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		log.Printf("Ignoring malformed viewer counts on %s: %v", subject, err)
+		return
+	}
+	if report.Replica == h.replicaID {
+		return // Our own, already counted
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.presence.replicas[report.Replica] = replicaViewers{viewers: report.Viewers, at: time.Now()}
+}
+
+// RecordPeakViewers persists the peak viewers of a finished match. Pass it to
+// WebSocketHub.SetPeakRecorder.
+func (s *MatchService) RecordPeakViewers(matchID string, peak int64, at time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.repo.SetPeakViewers(ctx, matchID, peak, at); err != nil {
+		log.Printf("Failed to record peak viewers of match %s: %v", matchID, err)
+	}
+}
+
+// GetMostWatchedMatches returns the matches with the most live viewers across all replicas.
+func (s *MatchService) GetMostWatchedMatches(ctx context.Context, req *proto.MostWatchedRequest) (*proto.MostWatchedResponse, error) {
+	if s.websocketHub == nil {
+		return nil, status.Error(codes.Unavailable, "viewers are not being tracked")
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultMostWatched
+	}
+	limit = min(limit, maxMostWatched)
+
+	resp := &proto.MostWatchedResponse{}
+	for _, watched := range s.websocketHub.MostWatched() {
+		if len(resp.Matches) == limit {
+			break
+		}
+		match, err := s.repo.GetMatch(ctx, watched.MatchID)
+		if err != nil {
+			if errors.Is(err, repository.ErrMatchNotFound) {
+				continue // Not a match, like AdminAlertsChannel
+			}
+			return nil, status.Errorf(codes.Internal, "failed to get match: %v", err)
+		}
+		resp.Matches = append(resp.Matches, &proto.MatchViewers{
+			Match:       toMatchResponse(match),
+			Viewers:     watched.Viewers,
+			PeakViewers: watched.PeakViewers,
+		})
+	}
+	return resp, nil
+}
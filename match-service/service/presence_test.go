@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+	"github.com/abaika-abay/live_sports_project/match-service/wsclient"
+	natsserver "github.com/nats-io/nats-server/v2/test"
+)
+
+// expectViewers reads frames until a viewers frame for matchID, and checks its count.
+func expectViewers(t *testing.T, client *wsclient.Client, matchID string, want int64) {
+	t.Helper()
+	for {
+		frame := nextFrame(t, client)
+		if frame.Type != wsclient.FrameViewers || frame.MatchID != matchID {
+			continue
+		}
+		if frame.Viewers == want {
+			return
+		}
+	}
+}
+
+// settlePresence updates the presence of every hub until each one sees the want totals.
+func settlePresence(t *testing.T, want map[string]int64, hubs ...*WebSocketHub) {
+	t.Helper()
+	waitUntil(t, "the replicas agree on viewer counts", func() bool {
+		settled := true
+		for _, hub := range hubs {
+			hub.updatePresence()
+			totals := make(map[string]int64)
+			for _, watched := range hub.MostWatched() {
+				totals[watched.MatchID] = watched.Viewers
+			}
+			settled = settled && len(totals) == len(want)
+			for matchID, viewers := range want {
+				settled = settled && totals[matchID] == viewers
+			}
+		}
+		return settled
+	})
+}
+
+func TestViewerCountsAcrossReplicas(t *testing.T) {
+	server := natsserver.RunRandClientPortServer()
+	defer server.Shutdown()
+	hubA := newFanoutHub(t, server.ClientURL())
+	hubB := newFanoutHub(t, server.ClientURL())
+
+	viewerA := dialHub(t, hubA, "m-1")
+	leaving := dialHub(t, hubA, "m-1")
+	viewerB := dialHub(t, hubB, "m-1")
+	dialHub(t, hubB, "m-2")
+	dialHub(t, hubA, AdminAlertsChannel)
+
+	settlePresence(t, map[string]int64{"m-1": 3, "m-2": 1, AdminAlertsChannel: 1}, hubA, hubB)
+	expectViewers(t, viewerA, "m-1", 3)
+	expectViewers(t, viewerB, "m-1", 3)
+
+	store := newMemStore()
+	for _, matchID := range []string{"m-1", "m-2", "m-3"} {
+		if err := store.CreateMatch(context.Background(), &repository.Match{MatchID: matchID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s := newMatchService(store, newFakeProvider(), hubB)
+	mostWatched := func(limit int32) []string {
+		t.Helper()
+		resp, err := s.GetMostWatchedMatches(context.Background(), &proto.MostWatchedRequest{Limit: limit})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, watched := range resp.Matches {
+			ids = append(ids, watched.Match.MatchId)
+		}
+		return ids
+	}
+	// The admin alerts channel isn't a match, and matches nobody watches aren't listed
+	if ids := mostWatched(0); !slices.Equal(ids, []string{"m-1", "m-2"}) {
+		t.Errorf("most watched = %v, want m-1 then m-2", ids)
+	}
+	if ids := mostWatched(1); !slices.Equal(ids, []string{"m-1"}) {
+		t.Errorf("most watched with limit 1 = %v, want m-1", ids)
+	}
+
+	leaving.Close()
+	settlePresence(t, map[string]int64{"m-1": 2, "m-2": 1, AdminAlertsChannel: 1}, hubA, hubB)
+	expectViewers(t, viewerB, "m-1", 2)
+	resp, err := s.GetMostWatchedMatches(context.Background(), &proto.MostWatchedRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if top := resp.Matches[0]; top.Match.MatchId != "m-1" || top.Viewers != 2 || top.PeakViewers != 3 {
+		t.Errorf("top match = %s with %d viewers, peak %d, want m-1 with 2, peak 3", top.Match.MatchId, top.Viewers, top.PeakViewers)
+	}
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+)
+
+// Every broadcast gets a per-match sequence number, and the last replayBufferSize updates
+// of each match are kept, so reconnecting clients can pass the last seq they saw as
+// resume_from and get what they missed. If the gap is too large they get a snapshot instead.
+// Sequence numbers restart at 1 when the service restarts; a client seeing a seq lower
+// than expected should treat the frame as the new state.
+//
+// Match states are sent as a snapshot when a client starts following a match, and as
+// JSON Merge Patches (RFC 7386) against the previous seq after that. A client that can't
+// apply a patch because it missed the previous seq should resubscribe with resume_from.
+// Clients using the protobuf encoding get every state whole instead, see Encoding.
+// See the wsclient package for a reference decoder.
+const (
+	replayBufferSize  = 256
+	replayIdleTimeout = 6 * time.Hour // Replay state of matches without updates for this long is dropped
+)
+
+// Update frame types.
+const (
+	FrameSnapshot   = "snapshot"   // Full match state
+	FramePatch      = "patch"      // Merge patch of the match state at seq-1
+	FrameUpdate     = "update"     // Anything else broadcast on a match channel, like admin alerts, sent in full
+	FrameCorrection = "correction" // An admin amended or retracted an event, see Correction
+)
+
+// UpdateFrame wraps every broadcast sent to WebSocket clients.
+type UpdateFrame struct {
+	Type    string          `json:"type"`
+	MatchID string          `json:"match_id"`
+	Seq     int64           `json:"seq"`  // Version of the match state after this frame
+	Data    json.RawMessage `json:"data"` // A MatchResponse, or a patch of one
+}
+
+// matchReplay is the replay state of a single match.
+type matchReplay struct {
+	seq         int64
+	frames      []encodedFrame       // Encoded frames, the last one has seq
+	latest      json.RawMessage      // Last match state, nil if none was broadcast
+	latestState *proto.MatchResponse // The same, for the protobuf encoding
+	latestSeq   int64                // Seq latest was broadcast with
+	snapshot    encodedFrame         // Snapshot of latest at seq, built on demand, see snapshotFrames
+	topics      []string             // Topics of the match, from its last state
+	updated     time.Time
+}
+
+// broadcastFrames is what a single broadcast sends.
+type broadcastFrames struct {
+	seq      int64
+	state    bool         // The update is a match state
+	frame    encodedFrame // For clients that have the state at seq-1
+	snapshot encodedFrame // For clients that don't, only set for match states
+}
+
+// recordUpdate assigns the next sequence number to an update, buffers it and returns the
+// encoded frames. The caller must hold h.mu.
+func (h *WebSocketHub) recordUpdate(matchID string, data interface{}) (*broadcastFrames, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	replay, ok := h.replays[matchID]
+	if !ok {
+		replay = &matchReplay{}
+		h.replays[matchID] = replay
+	}
+	seq := replay.seq + 1
+	frames := &broadcastFrames{seq: seq}
+
+	state, isState := data.(*proto.MatchResponse)
+	if !isState {
+		frameType := FrameUpdate
+		if _, ok := data.(*Correction); ok {
+			frameType = FrameCorrection
+		}
+		if frames.frame, err = encodeFrames(frameType, matchID, seq, raw, nil); err != nil {
+			return nil, err
+		}
+		replay.snapshot = encodedFrame{} // Out of date now that seq moved on
+	} else {
+		frames.state = true
+		if frames.snapshot, err = encodeFrames(FrameSnapshot, matchID, seq, raw, state); err != nil {
+			return nil, err
+		}
+		frames.frame = frames.snapshot
+		// Patches are only buffered right after the state they apply to: clients resuming
+		// across a correction or other update would otherwise get a patch of an older seq
+		if replay.latest != nil && replay.latestSeq == seq-1 {
+			patch, err := mergePatch(replay.latest, raw)
+			if err != nil {
+				return nil, fmt.Errorf("failed to diff match state: %w", err)
+			}
+			// Only JSON has patches, protobuf clients always get the whole state
+			if frames.frame[EncodingJSON], err = json.Marshal(UpdateFrame{Type: FramePatch, MatchID: matchID, Seq: seq, Data: patch}); err != nil {
+				return nil, err
+			}
+		}
+		replay.latest = raw
+		replay.latestState = state
+		replay.latestSeq = seq
+		replay.snapshot = frames.snapshot
+		replay.topics = updateTopics(matchID, data)
+	}
+
+	replay.seq = seq
+	replay.frames = append(replay.frames, frames.frame)
+	if len(replay.frames) > replayBufferSize {
+		replay.frames = replay.frames[len(replay.frames)-replayBufferSize:]
+	}
+	replay.updated = time.Now()
+	return frames, nil
+}
+
+// missedFrames returns the frames of a match after resumeFrom, or a snapshot frame if some
+// of them are no longer buffered, in c's encoding. The caller must hold h.mu.
+func (h *WebSocketHub) missedFrames(c *Client, matchID string, resumeFrom int64) ([]outgoingFrame, error) {
+	replay, ok := h.replays[matchID]
+	if !ok || resumeFrom == replay.seq {
+		return nil, nil // Nothing broadcast since, or nothing we know about
+	}
+
+	oldest := replay.seq - int64(len(replay.frames)) + 1
+	from := int64(-1)
+	if resumeFrom >= oldest-1 && resumeFrom < replay.seq {
+		from = resumeFrom + 1
+	} else if replay.latest == nil && resumeFrom < oldest {
+		from = oldest // Not a match state, send what we still have
+	}
+	if from >= 0 {
+		frames := make([]outgoingFrame, 0, replay.seq-from+1)
+		for seq := from; seq <= replay.seq; seq++ {
+			frames = append(frames, c.updateFrame(matchID, seq, replay.frames[seq-oldest]))
+		}
+		return frames, nil
+	}
+	if replay.latest == nil {
+		return nil, nil
+	}
+
+	// Too far behind, or ahead of us because we restarted
+	snapshot, err := replay.snapshotFrames(matchID)
+	if err != nil {
+		return nil, err
+	}
+	return []outgoingFrame{c.updateFrame(matchID, replay.seq, snapshot)}, nil
+}
+
+// snapshotFrames returns a snapshot of the latest state at the current seq. It is encoded
+// once and reused until the next update.
+func (r *matchReplay) snapshotFrames(matchID string) (encodedFrame, error) {
+	if r.snapshot[EncodingJSON] == nil {
+		snapshot, err := encodeFrames(FrameSnapshot, matchID, r.seq, r.latest, r.latestState)
+		if err != nil {
+			return encodedFrame{}, fmt.Errorf("failed to encode snapshot: %w", err)
+		}
+		r.snapshot = snapshot
+	}
+	return r.snapshot, nil
+}
+
+// subscribeAndResume subscribes c to topics and sends the current state of the matches
+// they cover. For the matches in resumeFrom (match ID to the last seq the client saw) it
+// sends the updates the client missed instead. Both happen under h.mu, so no broadcast
+// can slip in between and arrive out of order.
+func (h *WebSocketHub) subscribeAndResume(c *Client, topics []string, resumeFrom map[string]int64) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := h.subscribeLocked(c, topics); err != nil {
+		return err
+	}
+
+	for matchID, seq := range resumeFrom {
+		if !c.topics[MatchTopic(matchID)] {
+			continue // Only resume matches the client actually follows
+		}
+		frames, err := h.missedFrames(c, matchID, seq)
+		if err != nil {
+			return err
+		}
+		delivered := true
+		for _, frame := range frames {
+			delivered = h.sendLocked(c, frame, nil) && delivered
+		}
+		if replay, ok := h.replays[matchID]; ok && delivered {
+			c.known[matchID] = replay.seq
+		}
+	}
+
+	for matchID, replay := range h.replays {
+		if _, resumed := resumeFrom[matchID]; resumed || replay.latest == nil || c.known[matchID] == replay.seq {
+			continue
+		}
+		if !slices.ContainsFunc(topics, func(topic string) bool { return slices.Contains(replay.topics, topic) }) {
+			continue
+		}
+		snapshot, err := replay.snapshotFrames(matchID)
+		if err != nil {
+			return err
+		}
+		if h.sendLocked(c, c.updateFrame(matchID, replay.seq, snapshot), snapshot[c.encoding]) {
+			c.known[matchID] = replay.seq
+		}
+	}
+	return nil
+}
+
+// pruneReplays drops the replay state of matches that haven't been updated for a while.
+func (h *WebSocketHub) pruneReplays() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for matchID, replay := range h.replays {
+		if time.Since(replay.updated) > replayIdleTimeout {
+			delete(h.replays, matchID)
+		}
+	}
+}
+
+// mergePatch returns the JSON Merge Patch (RFC 7386) turning the object from into to.
+// Arrays are replaced as a whole, as the RFC requires.
+func mergePatch(from, to json.RawMessage) (json.RawMessage, error) {
+	var before, after map[string]interface{}
+	if err := json.Unmarshal(from, &before); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(to, &after); err != nil {
+		return nil, err
+	}
+	return json.Marshal(diffObjects(before, after))
+}
+
+func diffObjects(before, after map[string]interface{}) map[string]interface{} {
+	patch := make(map[string]interface{})
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			patch[key] = nil // Removed, e.g. a field that went back to its zero value
+		}
+	}
+	for key, value := range after {
+		old, ok := before[key]
+		if !ok {
+			patch[key] = value
+			continue
+		}
+		oldObject, oldIsObject := old.(map[string]interface{})
+		newObject, newIsObject := value.(map[string]interface{})
+		if oldIsObject && newIsObject {
+			if nested := diffObjects(oldObject, newObject); len(nested) > 0 {
+				patch[key] = nested
+			}
+			continue
+		}
+		if !jsonEqual(old, value) {
+			patch[key] = value
+		}
+	}
+	return patch
+}
+
+func jsonEqual(a, b interface{}) bool {
+	aJSON, _ := json.Marshal(a) // Decoded JSON values always encode again
+	bJSON, _ := json.Marshal(b)
+	return string(aJSON) == string(bJSON)
+}
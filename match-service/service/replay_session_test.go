@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+	"github.com/abaika-abay/live_sports_project/match-service/sportradar"
+	"github.com/abaika-abay/live_sports_project/match-service/wsclient"
+)
+
+// TestReplaySession plays a recorded Sportradar session, a provider error and a rate limit
+// included, through the poller, the event log and the hub into a WebSocket client.
+func TestReplaySession(t *testing.T) {
+	const matchID = "sr:sport_event:41762701"
+	f, err := os.Open("testdata/replay_session.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	replay, err := sportradar.NewReplayClient(f, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := &fakeClock{now: time.Date(2024, 5, 25, 15, 58, 0, 0, time.UTC)}
+	replay.SetClock(clock.Now)
+
+	// The match as the schedule sync created it, before kick-off
+	store := newMemStore()
+	hub := NewWebSocketHub()
+	s := newMatchService(store, replay, hub)
+	scheduled, err := replay.FetchMatchData(context.Background(), matchID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.createMatch(context.Background(), scheduled); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(hub.HandleConnections))
+	defer server.Close()
+	client, err := wsclient.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"?match_id="+matchID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	// Subscribed once the pong arrives, as match_id is handled before control messages
+	if _, err := client.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	if frame, err := client.Next(); err != nil || frame.Type != wsclient.FramePong {
+		t.Fatalf("first frame = %+v, %v, want a pong", frame, err)
+	}
+	states := make(chan string, 100)
+	go func() {
+		for {
+			frame, err := client.Next()
+			if err != nil {
+				close(states)
+				return
+			}
+			if frame.Type != wsclient.FrameSnapshot && frame.Type != wsclient.FramePatch {
+				continue
+			}
+			if match, ok := client.Decoder.Match(matchID); ok {
+				states <- fmt.Sprintf("%s %d-%d %s", match.Status, match.HomeScore, match.AwayScore, match.LastEvent)
+			}
+		}
+	}()
+
+	run := runPoller(t, NewMatchPoller(s, NewPhasePollPolicy(DefaultPhaseIntervals()), 2), clock)
+	for !replay.Done() {
+		run.step(5 * time.Second)
+	}
+	run.step(time.Hour) // Not polled anymore, nothing else is sent
+
+	for _, want := range []string{
+		"1st_half 0-0 MATCH STARTED (0')",
+		"1st_half 0-1 GOAL! Garnacho, Alejandro (30') 0-1",
+		"1st_half 0-1 yellow CARD: Rodri (41')",
+		"halftime 0-1 yellow CARD: Rodri (41')",
+		"2nd_half 1-1 GOAL! Foden, Phil (57') 1-1",
+		"closed 2-1 GOAL! Haaland, Erling (90+3') 2-1",
+	} {
+		select {
+		case got := <-states:
+			if got != want {
+				t.Fatalf("client got state %q, want %q", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("client didn't get state %q", want)
+		}
+	}
+	client.Close()
+	for got := range states {
+		t.Errorf("client got unexpected state %q", got)
+	}
+
+	stored, err := store.GetMatch(context.Background(), matchID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != "closed" || stored.HomeScore != 2 || stored.AwayScore != 1 || len(stored.Cards) != 1 {
+		t.Errorf("stored match = %s %d-%d, cards %v, want closed 2-1 with one card", stored.Status, stored.HomeScore, stored.AwayScore, stored.Cards)
+	}
+	events, err := store.GetEvents(context.Background(), matchID, repository.TimelineOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []string
+	for _, event := range events {
+		types = append(types, event.EventType)
+	}
+	want := "match_created provider_update provider_update provider_update provider_update provider_update provider_update"
+	if got := strings.Join(types, " "); got != want {
+		t.Errorf("event log = %s, want %s", got, want)
+	}
+}
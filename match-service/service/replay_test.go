@@ -0,0 +1,105 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/abaika-abay/live_sports_project/match-service/wsclient"
+)
+
+// broadcastGoals broadcasts states of m-1 with home scores from to goals, one per seq.
+func broadcastGoals(hub *WebSocketHub, from, goals int32) {
+	for score := from; score <= goals; score++ {
+		hub.BroadcastMatchUpdate("m-1", &proto.MatchResponse{MatchId: "m-1", HomeScore: score})
+	}
+}
+
+// resumeHub connects to hub following m-1 from seq resumeFrom, and returns the frames it
+// gets until the reply to a ping, which comes after the resumed ones.
+func resumeHub(t *testing.T, hub *WebSocketHub, decoder *wsclient.Decoder, resumeFrom int64) []*wsclient.Frame {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(hub.HandleConnections))
+	t.Cleanup(server.Close)
+	url := fmt.Sprintf("ws%s?match_id=m-1&resume_from=%d", strings.TrimPrefix(server.URL, "http"), resumeFrom)
+	client, err := wsclient.Dial(url, decoder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	if _, err := client.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	var frames []*wsclient.Frame
+	for {
+		frame := nextFrame(t, client)
+		if frame.Type == wsclient.FramePong {
+			return frames
+		}
+		frames = append(frames, frame)
+	}
+}
+
+// followGoals follows m-1 on hub while it broadcasts goals states, and returns the decoder
+// of the client, which then disconnects.
+func followGoals(t *testing.T, hub *WebSocketHub, goals int32) *wsclient.Decoder {
+	t.Helper()
+	client := dialHub(t, hub, "m-1")
+	defer client.Close()
+	broadcastGoals(hub, 1, goals)
+	for client.Decoder.Seq("m-1") != int64(goals) {
+		nextFrame(t, client)
+	}
+	return client.Decoder
+}
+
+func TestResumeInsideReplayBuffer(t *testing.T) {
+	hub := NewWebSocketHub()
+	decoder := followGoals(t, hub, 3)
+	broadcastGoals(hub, 4, 5)
+
+	frames := resumeHub(t, hub, decoder, 3)
+	if len(frames) != 2 || frames[0].Type != wsclient.FramePatch || frames[0].Seq != 4 || frames[1].Seq != 5 {
+		t.Fatalf("resumed with %d frames, want patches #4 and #5: %+v", len(frames), frames)
+	}
+	if match, _ := decoder.Match("m-1"); match.HomeScore != 5 || decoder.Seq("m-1") != 5 {
+		t.Errorf("state = %v at seq %d, want 5 goals at seq 5", match, decoder.Seq("m-1"))
+	}
+
+	if frames := resumeHub(t, hub, decoder, 5); len(frames) != 0 {
+		t.Errorf("resuming from the latest seq sent %+v, want nothing", frames)
+	}
+}
+
+func TestResumeFromBeforeReplayBuffer(t *testing.T) {
+	hub := NewWebSocketHub()
+	decoder := followGoals(t, hub, 1)
+	broadcastGoals(hub, 2, replayBufferSize+10)
+
+	frames := resumeHub(t, hub, decoder, 1)
+	if len(frames) != 1 || frames[0].Type != wsclient.FrameSnapshot || frames[0].Seq != replayBufferSize+10 {
+		t.Fatalf("resumed with %+v, want a single snapshot of seq %d", frames, replayBufferSize+10)
+	}
+	if match, _ := decoder.Match("m-1"); match.HomeScore != replayBufferSize+10 {
+		t.Errorf("state = %v, want the latest one", match)
+	}
+}
+
+// TestResumeAheadOfHub resumes from a seq of a hub that restarted since.
+func TestResumeAheadOfHub(t *testing.T) {
+	before := NewWebSocketHub()
+	decoder := followGoals(t, before, 10)
+
+	hub := NewWebSocketHub()
+	broadcastGoals(hub, 1, 2)
+	frames := resumeHub(t, hub, decoder, 10)
+	if len(frames) != 1 || frames[0].Type != wsclient.FrameSnapshot || frames[0].Seq != 2 {
+		t.Fatalf("resumed with %+v, want a snapshot of seq 2", frames)
+	}
+	if match, _ := decoder.Match("m-1"); match.HomeScore != 2 || decoder.Seq("m-1") != 2 {
+		t.Errorf("state = %v at seq %d, want the restarted hub's at seq 2", match, decoder.Seq("m-1"))
+	}
+}
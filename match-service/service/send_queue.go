@@ -0,0 +1,128 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DropPolicy decides what happens when a WebSocket client can't keep up and its send
+// queue is full. Clients pick one with the 'drop_policy' query parameter.
+type DropPolicy string
+
+const (
+	// DropOldest drops the oldest queued frame. The client sees a seq gap and resumes.
+	DropOldest DropPolicy = "drop_oldest"
+	// CoalesceLatest replaces the queued frames of a match with a snapshot of its latest
+	// state, so a slow client skips intermediate states but stays consistent.
+	CoalesceLatest DropPolicy = "coalesce"
+	// Disconnect closes the connection. The client reconnects and resumes.
+	Disconnect DropPolicy = "disconnect"
+)
+
+// DefaultDropPolicy is used for clients that don't ask for one.
+const DefaultDropPolicy = CoalesceLatest
+
+// sendQueueSize is the number of frames a client can have queued.
+const sendQueueSize = 256
+
+// ParseDropPolicy validates a drop policy name.
+func ParseDropPolicy(name string) (DropPolicy, error) {
+	switch policy := DropPolicy(name); policy {
+	case DropOldest, CoalesceLatest, Disconnect:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown drop policy %q, expected %s, %s or %s", name, DropOldest, CoalesceLatest, Disconnect)
+}
+
+// outgoingFrame is a frame waiting to be written to a client.
+type outgoingFrame struct {
+	matchID string // Empty for control replies
+	seq     int64  // Seq of the match after this frame, 0 for control replies
+	data    []byte
+	binary  bool // Sent as a binary message, see EncodingProtobuf
+}
+
+// sendQueue is a client's bounded queue of outgoing frames. The hub pushes without ever
+// blocking; the client's writePump drains it.
+type sendQueue struct {
+	policy DropPolicy
+	limit  int
+	ready  chan struct{} // Signalled when frames are waiting
+	done   chan struct{} // Closed by close
+
+	mu     sync.Mutex
+	frames []outgoingFrame
+	closed bool
+}
+
+func newSendQueue(policy DropPolicy, limit int) *sendQueue {
+	return &sendQueue{
+		policy: policy,
+		limit:  limit,
+		ready:  make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+// push queues a frame. snapshot, if set, is the latest full state of the frame's match,
+// which CoalesceLatest queues instead when the queue is full. It returns the matches that
+// lost frames, so their next update is sent as a snapshot, and false if the client has to
+// be disconnected.
+func (q *sendQueue) push(frame outgoingFrame, snapshot []byte) (dropped []string, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return nil, false
+	}
+
+	if len(q.frames) >= q.limit {
+		switch q.policy {
+		case Disconnect:
+			return nil, false
+		case CoalesceLatest:
+			if snapshot != nil {
+				kept := q.frames[:0]
+				for _, queued := range q.frames {
+					if queued.matchID != frame.matchID {
+						kept = append(kept, queued)
+					}
+				}
+				q.frames = kept
+				frame.data = snapshot
+			}
+		}
+		if len(q.frames) >= q.limit { // DropOldest, or nothing to coalesce
+			if oldest := q.frames[0]; oldest.matchID != "" {
+				dropped = append(dropped, oldest.matchID)
+			}
+			q.frames = q.frames[1:]
+		}
+	}
+	q.frames = append(q.frames, frame)
+
+	select {
+	case q.ready <- struct{}{}:
+	default: // Already signalled
+	}
+	return dropped, true
+}
+
+// drain returns and removes all queued frames.
+func (q *sendQueue) drain() []outgoingFrame {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	frames := q.frames
+	q.frames = nil
+	return frames
+}
+
+// close stops the queue; the writePump closes the connection. Safe to call more than once.
+func (q *sendQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.closed {
+		q.closed = true
+		q.frames = nil
+		close(q.done)
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func frameOf(matchID string, seq int64) outgoingFrame {
+	return outgoingFrame{matchID: matchID, seq: seq, data: []byte(fmt.Sprintf("%s#%d", matchID, seq))}
+}
+
+// queued renders the frames in q, e.g. "m-1#3".
+func queued(q *sendQueue) []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var frames []string
+	for _, frame := range q.frames {
+		frames = append(frames, string(frame.data))
+	}
+	return frames
+}
+
+func TestSendQueueDropOldest(t *testing.T) {
+	q := newSendQueue(DropOldest, 3)
+	for _, frame := range []outgoingFrame{frameOf("m-1", 1), frameOf("m-2", 1), frameOf("m-1", 2)} {
+		if dropped, ok := q.push(frame, nil); !ok || dropped != nil {
+			t.Fatalf("push to a queue with room = %v, %v", dropped, ok)
+		}
+	}
+
+	dropped, ok := q.push(frameOf("m-2", 2), []byte("snapshot"))
+	if !ok || !slices.Equal(dropped, []string{"m-1"}) {
+		t.Errorf("push to a full queue = %v, %v, want m-1 dropped", dropped, ok)
+	}
+	if got, want := queued(q), []string{"m-2#1", "m-1#2", "m-2#2"}; !slices.Equal(got, want) {
+		t.Errorf("queue = %v, want %v", got, want)
+	}
+
+	// Control replies make room too, without any match losing a frame
+	q = newSendQueue(DropOldest, 1)
+	q.push(outgoingFrame{data: []byte("ack")}, nil)
+	if dropped, ok := q.push(frameOf("m-1", 1), nil); !ok || dropped != nil {
+		t.Errorf("push after a control reply = %v, %v, want nothing dropped", dropped, ok)
+	}
+}
+
+func TestSendQueueCoalesceLatest(t *testing.T) {
+	q := newSendQueue(CoalesceLatest, 3)
+	for _, frame := range []outgoingFrame{frameOf("m-1", 1), frameOf("m-2", 1), frameOf("m-1", 2)} {
+		q.push(frame, nil)
+	}
+
+	// The queued frames of m-1 make way for a snapshot of its latest state
+	dropped, ok := q.push(frameOf("m-1", 3), []byte("m-1 snapshot#3"))
+	if !ok || dropped != nil {
+		t.Errorf("push to a full queue = %v, %v, want the frames coalesced", dropped, ok)
+	}
+	if got, want := queued(q), []string{"m-2#1", "m-1 snapshot#3"}; !slices.Equal(got, want) {
+		t.Errorf("queue = %v, want %v", got, want)
+	}
+	q.mu.Lock()
+	if last := q.frames[len(q.frames)-1]; last.matchID != "m-1" || last.seq != 3 {
+		t.Errorf("snapshot queued for %s #%d, want m-1 #3", last.matchID, last.seq)
+	}
+	q.mu.Unlock()
+
+	// Without a snapshot, e.g. for admin alerts, the oldest frame goes
+	q.push(frameOf("m-3", 1), nil)
+	dropped, ok = q.push(frameOf("m-3", 2), nil)
+	if !ok || !slices.Equal(dropped, []string{"m-2"}) {
+		t.Errorf("push without a snapshot = %v, %v, want m-2 dropped", dropped, ok)
+	}
+	if got, want := queued(q), []string{"m-1 snapshot#3", "m-3#1", "m-3#2"}; !slices.Equal(got, want) {
+		t.Errorf("queue = %v, want %v", got, want)
+	}
+}
+
+func TestSendQueueDisconnect(t *testing.T) {
+	q := newSendQueue(Disconnect, 2)
+	q.push(frameOf("m-1", 1), nil)
+	q.push(frameOf("m-1", 2), nil)
+	if _, ok := q.push(frameOf("m-1", 3), []byte("snapshot")); ok {
+		t.Error("push to a full queue succeeded, want the client disconnected")
+	}
+	if got, want := queued(q), []string{"m-1#1", "m-1#2"}; !slices.Equal(got, want) {
+		t.Errorf("queue = %v, want %v", got, want)
+	}
+
+	q.close()
+	q.close()
+	if _, ok := q.push(frameOf("m-2", 1), nil); ok {
+		t.Error("push to a closed queue succeeded")
+	}
+	select {
+	case <-q.done:
+	default:
+		t.Error("done isn't closed")
+	}
+}
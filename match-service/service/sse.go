@@ -0,0 +1,114 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// sseKeepAliveInterval is how often an idle SSE stream gets a comment, so proxies don't
+// time it out.
+const sseKeepAliveInterval = 30 * time.Second
+
+// HandleEvents streams the updates of a match as Server-Sent Events, for consumers behind
+// proxies that break WebSockets. SSE clients are hub clients like WebSocket ones: they get
+// the same UpdateFrames, with the same sequence numbers, and the same DropPolicy handling.
+// Each event's data is an UpdateFrame and its id is the frame's seq, so a reconnecting
+// EventSource sends it back as Last-Event-ID and gets the updates it missed, as with
+// resume_from on WebSockets. 'resume_from' works here too, for clients that can't set headers.
+// Authentication works as for WebSockets, see RequireAuth; when the token expires the stream ends.
+// Example: http://localhost:8080/events?match_id=match-123&drop_policy=coalesce&token=...
+func (h *WebSocketHub) HandleEvents(w http.ResponseWriter, r *http.Request) {
+	matchID := r.URL.Query().Get("match_id")
+	if matchID == "" {
+		http.Error(w, "match_id is required", http.StatusBadRequest)
+		return
+	}
+	policy, err := h.clientDropPolicy(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	resume := make(map[string]int64)
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("resume_from")
+	}
+	if lastEventID != "" {
+		seq, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || seq < 0 {
+			http.Error(w, "Last-Event-ID and resume_from must be a sequence number", http.StatusBadRequest)
+			return
+		}
+		resume[matchID] = seq
+	}
+
+	claims, _, err := h.admit(w, r)
+	if err != nil {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Stop nginx from buffering the stream
+	w.WriteHeader(http.StatusOK)
+
+	client := newClient(nil, policy)
+	if claims != nil {
+		client.userID = claims.UserID
+	}
+	h.addClient(client)
+	defer h.removeClient(client)
+	if claims != nil {
+		defer h.expireWithToken(client, claims).Stop()
+	}
+	if err := h.subscribeAndResume(client, []string{MatchTopic(matchID)}, resume); err != nil {
+		log.Printf("Failed to subscribe SSE client to match %s: %v", matchID, err)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+	ticker := time.NewTicker(h.sseKeepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-client.queue.ready:
+			rc.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			for _, frame := range client.queue.drain() {
+				if err := writeEvent(w, frame); err != nil {
+					return
+				}
+			}
+		case <-client.queue.done:
+			return // The hub removed the client, it reconnects with Last-Event-ID
+		case <-ticker.C:
+			rc.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return // Client went away
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeEvent writes a frame as an SSE event. Frames are single-line JSON, so they fit in
+// one data field.
+func writeEvent(w http.ResponseWriter, frame outgoingFrame) error {
+	if frame.seq > 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", frame.seq); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "data: %s\n\n", frame.data)
+	return err
+}
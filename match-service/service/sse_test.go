@@ -0,0 +1,133 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sseEvent is an event, or a comment, read from an SSE stream.
+type sseEvent struct {
+	id      string
+	frame   UpdateFrame
+	comment string
+}
+
+// sseStream is an open /events stream.
+type sseStream struct {
+	t      *testing.T
+	reader *bufio.Reader
+}
+
+// openEvents opens an SSE stream on hub with the given query and Last-Event-ID, and
+// returns it with the response status.
+func openEvents(t *testing.T, hub *WebSocketHub, query, lastEventID string) (*sseStream, int) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(hub.HandleEvents))
+	t.Cleanup(server.Close)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"?"+query, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return &sseStream{t: t, reader: bufio.NewReader(resp.Body)}, resp.StatusCode
+}
+
+// next reads the next event or comment.
+func (s *sseStream) next() sseEvent {
+	s.t.Helper()
+	var event sseEvent
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			s.t.Fatalf("stream ended: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return event
+		case strings.HasPrefix(line, ":"):
+			event.comment = strings.TrimSpace(line[1:])
+		case strings.HasPrefix(line, "id: "):
+			event.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event.frame); err != nil {
+				s.t.Fatalf("event data %q: %v", line, err)
+			}
+		}
+	}
+}
+
+// expect checks the next event is a frame of frameType with the given id, its seq.
+func (s *sseStream) expect(frameType, id string) {
+	s.t.Helper()
+	if event := s.next(); event.frame.Type != frameType || event.id != id {
+		s.t.Fatalf("event = %+v, want a %s with id %s", event, frameType, id)
+	}
+}
+
+func TestSSEResumesFromLastEventID(t *testing.T) {
+	hub := NewWebSocketHub()
+	broadcastGoals(hub, 1, 3)
+
+	stream, _ := openEvents(t, hub, "match_id=m-1", "1")
+	stream.expect(FramePatch, "2")
+	stream.expect(FramePatch, "3")
+	broadcastGoals(hub, 4, 4)
+	stream.expect(FramePatch, "4")
+
+	// resume_from is the same for clients that can't set headers; Last-Event-ID wins
+	stream, _ = openEvents(t, hub, "match_id=m-1&resume_from=3", "")
+	stream.expect(FramePatch, "4")
+	stream, _ = openEvents(t, hub, "match_id=m-1&resume_from=1", "3")
+	stream.expect(FramePatch, "4")
+
+	stream, _ = openEvents(t, hub, "match_id=m-1", "")
+	stream.expect(FrameSnapshot, "4")
+}
+
+func TestSSERejectsInvalidRequests(t *testing.T) {
+	hub := NewWebSocketHub()
+	for _, tc := range []struct{ query, lastEventID string }{
+		{"", ""},
+		{"match_id=m-1", "abc"},
+		{"match_id=m-1", "-1"},
+		{"match_id=m-1&resume_from=x", ""},
+		{"match_id=m-1&drop_policy=never", ""},
+	} {
+		if _, status := openEvents(t, hub, tc.query, tc.lastEventID); status != http.StatusBadRequest {
+			t.Errorf("query %q, Last-Event-ID %q: status %d, want %d", tc.query, tc.lastEventID, status, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestSSEKeepAlive(t *testing.T) {
+	hub := NewWebSocketHub()
+	hub.sseKeepAlive = 10 * time.Millisecond
+	stream, _ := openEvents(t, hub, "match_id=m-1", "")
+	for i := 0; i < 2; i++ {
+		if event := stream.next(); event.comment != "keep-alive" || event.frame.Type != "" {
+			t.Fatalf("event = %+v on an idle stream, want a keep-alive comment", event)
+		}
+	}
+	broadcastGoals(hub, 1, 1)
+	for {
+		if event := stream.next(); event.comment == "" {
+			if event.frame.Type != FrameSnapshot || event.id != "1" {
+				t.Fatalf("event = %+v, want the snapshot #1", event)
+			}
+			return
+		}
+	}
+}
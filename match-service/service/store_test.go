@@ -0,0 +1,347 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+	"github.com/abaika-abay/live_sports_project/match-service/sportradar"
+)
+
+// memStore is an in-memory matchStore with the semantics of repository.MatchRepository,
+// version checks included, for tests that don't have a MongoDB.
+type memStore struct {
+	mu        sync.Mutex
+	matches   map[string]*repository.Match
+	events    map[string][]*repository.Event
+	snapshots map[string][]*repository.Snapshot
+
+	// Called before writes to the match or its event log, to make them fail, e.g. with
+	// ErrVersionConflict
+	failUpdate func(matchID string) error
+}
+
+func newMemStore() *memStore {
+	return &memStore{
+		matches:   make(map[string]*repository.Match),
+		events:    make(map[string][]*repository.Event),
+		snapshots: make(map[string][]*repository.Snapshot),
+	}
+}
+
+func cloneEvent(event *repository.Event) *repository.Event {
+	clone := *event
+	if event.State != nil {
+		state := *event.State
+		state.Cards = slices.Clone(state.Cards)
+		clone.State = &state
+	}
+	clone.Revisions = slices.Clone(event.Revisions)
+	return &clone
+}
+
+func (s *memStore) checkUpdate(matchID string) error {
+	if s.failUpdate != nil {
+		return s.failUpdate(matchID)
+	}
+	return nil
+}
+
+func (s *memStore) GetMatch(_ context.Context, matchID string) (*repository.Match, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	match, ok := s.matches[matchID]
+	if !ok {
+		return nil, fmt.Errorf("match with ID %s: %w", matchID, repository.ErrMatchNotFound)
+	}
+	return cloneMatch(match), nil
+}
+
+func (s *memStore) CreateMatch(_ context.Context, match *repository.Match) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.matches[match.MatchID]; ok {
+		return fmt.Errorf("match with ID %s: %w", match.MatchID, repository.ErrMatchExists)
+	}
+	s.matches[match.MatchID] = cloneMatch(match)
+	return nil
+}
+
+func (s *memStore) UpdateMatch(_ context.Context, match *repository.Match) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkUpdate(match.MatchID); err != nil {
+		return err
+	}
+	stored, ok := s.matches[match.MatchID]
+	if !ok {
+		return fmt.Errorf("match with ID %s: %w", match.MatchID, repository.ErrMatchNotFound)
+	}
+	if stored.Version != match.Version {
+		return fmt.Errorf("match with ID %s at version %d: %w", match.MatchID, match.Version, repository.ErrVersionConflict)
+	}
+	saved := cloneMatch(match)
+	saved.Version++
+	if saved.Overrides == nil {
+		saved.Overrides = map[string]repository.Override{}
+	}
+	s.matches[match.MatchID] = saved
+	match.Version = saved.Version
+	return nil
+}
+
+func (s *memStore) IncrementCounters(_ context.Context, matchID string, update repository.CounterUpdate) (*repository.Match, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkUpdate(matchID); err != nil {
+		return nil, err
+	}
+	stored, ok := s.matches[matchID]
+	if !ok {
+		return nil, fmt.Errorf("match with ID %s: %w", matchID, repository.ErrMatchNotFound)
+	}
+	stored.HomeScore += update.HomeScore
+	stored.AwayScore += update.AwayScore
+	stored.Fouls += update.Fouls
+	stored.LastEvent = update.LastEvent
+	if stored.EventSeq != update.Seq-1 {
+		return nil, fmt.Errorf("match with ID %s before event %d: %w", matchID, update.Seq, repository.ErrVersionConflict)
+	}
+	if stored.Overrides == nil {
+		stored.Overrides = map[string]repository.Override{}
+	}
+	maps.Copy(stored.Overrides, update.Overrides)
+	stored.EventSeq = update.Seq
+	stored.Version++
+	return cloneMatch(stored), nil
+}
+
+func (s *memStore) SetPeakViewers(_ context.Context, matchID string, peak int64, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stored, ok := s.matches[matchID]; ok && stored.PeakViewers < peak {
+		stored.PeakViewers, stored.PeakViewersAt = peak, at
+		stored.Version++
+	}
+	return nil
+}
+
+func (s *memStore) GetActiveMatches(context.Context) ([]*repository.Match, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matches []*repository.Match
+	for _, match := range s.matches {
+		if slices.ContainsFunc(repository.ActiveStatuses, func(status string) bool { return strings.EqualFold(status, match.Status) }) {
+			matches = append(matches, cloneMatch(match))
+		}
+	}
+	return matches, nil
+}
+
+func (s *memStore) GetMatchListForAdmin(_ context.Context, opts repository.MatchListOptions) ([]*repository.Match, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if opts.SortBy == "" {
+		opts.SortBy = "start_time"
+	}
+	if !slices.Contains(repository.MatchListSortFields, opts.SortBy) {
+		return nil, 0, fmt.Errorf("cannot sort matches by %q", opts.SortBy)
+	}
+	f := opts.Filter
+	var matches []*repository.Match
+	for _, match := range s.matches {
+		if (f.Status != "" && !strings.EqualFold(f.Status, match.Status)) ||
+			(f.Competition != "" && !strings.EqualFold(f.Competition, match.Competition)) ||
+			(f.Team != "" && !strings.Contains(strings.ToLower(match.HomeTeam+"\n"+match.AwayTeam), strings.ToLower(f.Team))) ||
+			(f.StartFrom != "" && match.StartTime < f.StartFrom) ||
+			(f.StartTo != "" && match.StartTime >= f.StartTo) {
+			continue
+		}
+		matches = append(matches, cloneMatch(match))
+	}
+	total := int64(len(matches))
+
+	compare := func(a, b *repository.MatchListCursor) int {
+		c := cmp.Or(cmp.Compare(a.SortValue, b.SortValue), cmp.Compare(a.MatchID, b.MatchID))
+		if opts.Descending {
+			return -c
+		}
+		return c
+	}
+	slices.SortFunc(matches, func(a, b *repository.Match) int {
+		return compare(a.ListCursor(opts.SortBy), b.ListCursor(opts.SortBy))
+	})
+	if opts.After != nil {
+		matches = slices.DeleteFunc(matches, func(match *repository.Match) bool {
+			return compare(match.ListCursor(opts.SortBy), opts.After) <= 0
+		})
+	}
+	if opts.Limit > 0 && int64(len(matches)) > opts.Limit {
+		matches = matches[:opts.Limit]
+	}
+	return matches, total, nil
+}
+
+func (s *memStore) AddEvent(_ context.Context, event *repository.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkUpdate(event.MatchID); err != nil {
+		return err
+	}
+	if event.Seq > 0 && slices.ContainsFunc(s.events[event.MatchID], func(existing *repository.Event) bool { return existing.Seq == event.Seq }) {
+		return fmt.Errorf("event %d of match %s: %w", event.Seq, event.MatchID, repository.ErrVersionConflict)
+	}
+	s.events[event.MatchID] = append(s.events[event.MatchID], cloneEvent(event))
+	return nil
+}
+
+func (s *memStore) GetEvents(_ context.Context, matchID string, opts repository.TimelineOptions) ([]*repository.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []*repository.Event
+	for _, event := range s.events[matchID] {
+		switch {
+		case opts.Since > 0 && event.Seq <= opts.Since,
+			slices.Contains(opts.ExcludeTypes, event.EventType),
+			opts.ExcludeRetracted && event.Retracted(),
+			opts.After != nil && (event.Seq < opts.After.Seq || event.Seq == opts.After.Seq && event.EventID <= opts.After.EventID):
+			continue
+		}
+		events = append(events, cloneEvent(event))
+	}
+	slices.SortStableFunc(events, func(a, b *repository.Event) int {
+		return cmp.Or(cmp.Compare(a.Seq, b.Seq), strings.Compare(a.EventID, b.EventID))
+	})
+	if opts.Limit > 0 && int64(len(events)) > opts.Limit {
+		events = events[:opts.Limit]
+	}
+	return events, nil
+}
+
+func (s *memStore) GetEvent(_ context.Context, matchID, eventID string) (*repository.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, event := range s.events[matchID] {
+		if event.EventID == eventID {
+			return cloneEvent(event), nil
+		}
+	}
+	return nil, fmt.Errorf("event %s of match %s: %w", eventID, matchID, repository.ErrEventNotFound)
+}
+
+func (s *memStore) ReviseEvent(_ context.Context, previous, revised *repository.Event, revision repository.EventRevision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	revision.Previous = *previous
+	revision.Previous.Revisions = nil
+	revised.Revision = previous.Revision + 1
+	revised.Revisions = append(slices.Clone(previous.Revisions), revision)
+	for i, event := range s.events[previous.MatchID] {
+		if event.EventID == previous.EventID && event.Revision == previous.Revision {
+			s.events[previous.MatchID][i] = cloneEvent(revised)
+			return nil
+		}
+	}
+	return fmt.Errorf("event %s of match %s: %w", previous.EventID, previous.MatchID, repository.ErrEventRevised)
+}
+
+func (s *memStore) SaveSnapshot(_ context.Context, snapshot *repository.Snapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	saved := *snapshot
+	saved.Overrides = maps.Clone(snapshot.Overrides)
+	snapshots := slices.DeleteFunc(s.snapshots[snapshot.MatchID], func(existing *repository.Snapshot) bool {
+		return existing.Seq == snapshot.Seq
+	})
+	s.snapshots[snapshot.MatchID] = append(snapshots, &saved)
+	return nil
+}
+
+func (s *memStore) GetLatestSnapshot(_ context.Context, matchID string) (*repository.Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var latest *repository.Snapshot
+	for _, snapshot := range s.snapshots[matchID] {
+		if latest == nil || snapshot.Seq > latest.Seq {
+			latest = snapshot
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	clone := *latest
+	clone.Overrides = maps.Clone(latest.Overrides)
+	return &clone, nil
+}
+
+func (s *memStore) DeleteSnapshots(_ context.Context, matchID string, fromSeq int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshots[matchID] = slices.DeleteFunc(s.snapshots[matchID], func(snapshot *repository.Snapshot) bool {
+		return snapshot.Seq >= fromSeq
+	})
+	return nil
+}
+
+// fakeProvider is a sportradar.SportradarClientI reporting whatever state a test sets.
+type fakeProvider struct {
+	mu      sync.Mutex
+	matches map[string]*repository.Match
+	fetches int
+}
+
+func newFakeProvider() *fakeProvider {
+	return &fakeProvider{matches: make(map[string]*repository.Match)}
+}
+
+// set makes the provider report match from now on.
+func (p *fakeProvider) set(match *repository.Match) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.matches[match.MatchID] = cloneMatch(match)
+}
+
+// fetchCount returns how often FetchMatchData was called.
+func (p *fakeProvider) fetchCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.fetches
+}
+
+func (p *fakeProvider) FetchMatchData(_ context.Context, matchID string) (*repository.Match, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.fetches++
+	match, ok := p.matches[matchID]
+	if !ok {
+		return nil, fmt.Errorf("provider doesn't know match %s", matchID)
+	}
+	return cloneMatch(match), nil
+}
+
+func (p *fakeProvider) FetchLiveMatches(context.Context) ([]*repository.Match, error) {
+	return nil, nil
+}
+
+func (p *fakeProvider) FetchSchedule(context.Context, time.Time) ([]*repository.Match, error) {
+	return nil, nil
+}
+
+func (p *fakeProvider) FetchMatchTimeline(context.Context, string) ([]*repository.Event, error) {
+	return nil, nil
+}
+
+func (p *fakeProvider) FetchLineups(context.Context, string) (*sportradar.Lineups, error) {
+	return nil, errors.New("fakeProvider has no lineups")
+}
+
+func (p *fakeProvider) FetchStandings(context.Context, string) ([]*sportradar.Standing, error) {
+	return nil, errors.New("fakeProvider has no standings")
+}
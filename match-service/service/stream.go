@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxStreamMatches caps the matches a single StreamMultiMatchUpdates call can follow.
+const maxStreamMatches = 50
+
+// MatchSubscription receives match updates in-process, from the same broadcasts as
+// WebSocket clients. Updates are coalesced per match: a slow consumer skips intermediate
+// states and gets the latest state of every match it missed, so it can never hold up the hub.
+type MatchSubscription struct {
+	matchIDs map[string]bool
+	notify   chan struct{} // Signalled when pending updates are waiting
+
+	mu        sync.Mutex
+	pending   map[string]*proto.MatchResponse
+	order     []string // Match IDs in pending, oldest first
+	coalesced int      // Updates replaced before the consumer got them
+}
+
+// SubscribeMatches registers a subscription for updates of the given matches.
+// Call UnsubscribeMatches when done.
+func (h *WebSocketHub) SubscribeMatches(matchIDs ...string) *MatchSubscription {
+	sub := &MatchSubscription{
+		matchIDs: make(map[string]bool, len(matchIDs)),
+		notify:   make(chan struct{}, 1),
+		pending:  make(map[string]*proto.MatchResponse),
+	}
+	for _, matchID := range matchIDs {
+		sub.matchIDs[matchID] = true
+	}
+
+	h.mu.Lock()
+	h.streams[sub] = true
+	h.mu.Unlock()
+	return sub
+}
+
+// UnsubscribeMatches stops delivering updates to sub.
+func (h *WebSocketHub) UnsubscribeMatches(sub *MatchSubscription) {
+	h.mu.Lock()
+	delete(h.streams, sub)
+	h.mu.Unlock()
+}
+
+// publishToStreams hands a match update to in-process subscribers. Only match states
+// are streamed; other broadcasts (like admin alerts) are WebSocket-only.
+// The caller must hold h.mu.
+func (h *WebSocketHub) publishToStreams(matchID string, data interface{}) {
+	update, ok := data.(*proto.MatchResponse)
+	if !ok {
+		return
+	}
+
+	for sub := range h.streams {
+		if sub.matchIDs[matchID] {
+			sub.push(matchID, update)
+		}
+	}
+}
+
+func (s *MatchSubscription) push(matchID string, update *proto.MatchResponse) {
+	s.mu.Lock()
+	if _, ok := s.pending[matchID]; ok {
+		s.coalesced++
+	} else {
+		s.order = append(s.order, matchID)
+	}
+	s.pending[matchID] = update
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default: // Already signalled
+	}
+}
+
+// Next blocks until updates are available and returns them, oldest first, along with
+// the number of updates skipped since the last call because the consumer was too slow.
+func (s *MatchSubscription) Next(ctx context.Context) ([]*proto.MatchResponse, int, error) {
+	select {
+	case <-s.notify:
+	case <-ctx.Done():
+		return nil, 0, ctx.Err()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	updates := make([]*proto.MatchResponse, 0, len(s.order))
+	for _, matchID := range s.order {
+		updates = append(updates, s.pending[matchID])
+	}
+	coalesced := s.coalesced
+	s.pending = make(map[string]*proto.MatchResponse)
+	s.order = nil
+	s.coalesced = 0
+	return updates, coalesced, nil
+}
+
+// StreamMatchUpdates streams the current state of a match, then every update to it.
+func (s *MatchService) StreamMatchUpdates(req *proto.MatchRequest, stream proto.MatchService_StreamMatchUpdatesServer) error {
+	return s.streamMatches(stream.Context(), []string{req.MatchId}, stream.Send)
+}
+
+// StreamMultiMatchUpdates streams the current state of several matches, then every update to them.
+func (s *MatchService) StreamMultiMatchUpdates(req *proto.MultiMatchRequest, stream proto.MatchService_StreamMultiMatchUpdatesServer) error {
+	if len(req.MatchIds) == 0 {
+		return status.Error(codes.InvalidArgument, "at least one match_id is required")
+	}
+	if len(req.MatchIds) > maxStreamMatches {
+		return status.Errorf(codes.InvalidArgument, "cannot stream more than %d matches at once", maxStreamMatches)
+	}
+	return s.streamMatches(stream.Context(), req.MatchIds, stream.Send)
+}
+
+func (s *MatchService) streamMatches(ctx context.Context, matchIDs []string, send func(*proto.MatchResponse) error) error {
+	if s.websocketHub == nil {
+		return status.Error(codes.Unavailable, "match updates are not being broadcast")
+	}
+	slices.Sort(matchIDs)
+	matchIDs = slices.Compact(matchIDs)
+
+	// Subscribe before reading the current state, so no update falls in between
+	sub := s.websocketHub.SubscribeMatches(matchIDs...)
+	defer s.websocketHub.UnsubscribeMatches(sub)
+
+	for _, matchID := range matchIDs {
+		match, err := s.repo.GetMatch(ctx, matchID)
+		if err != nil {
+			if errors.Is(err, repository.ErrMatchNotFound) {
+				return status.Errorf(codes.NotFound, "match not found: %v", err)
+			}
+			return status.Errorf(codes.Internal, "failed to get match: %v", err)
+		}
+		if err := send(toMatchResponse(match)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		updates, coalesced, err := sub.Next(ctx)
+		if err != nil {
+			return nil // Client went away
+		}
+		if coalesced > 0 {
+			log.Printf("Stream for matches %v is falling behind, skipped %d intermediate updates", matchIDs, coalesced)
+		}
+		for _, update := range updates {
+			if err := send(update); err != nil {
+				return fmt.Errorf("failed to send match update: %w", err)
+			}
+		}
+	}
+}
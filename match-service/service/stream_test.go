@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+)
+
+func TestMatchSubscriptionCoalesces(t *testing.T) {
+	hub := NewWebSocketHub()
+	sub := hub.SubscribeMatches("m-1", "m-2")
+	defer hub.UnsubscribeMatches(sub)
+
+	for goals := int32(1); goals <= 3; goals++ {
+		hub.BroadcastMatchUpdate("m-2", &proto.MatchResponse{MatchId: "m-2", HomeScore: goals})
+		hub.BroadcastMatchUpdate("m-1", &proto.MatchResponse{MatchId: "m-1", AwayScore: goals})
+		hub.BroadcastMatchUpdate("m-3", &proto.MatchResponse{MatchId: "m-3", HomeScore: goals}) // Not followed
+	}
+
+	updates, coalesced, err := sub.Next(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 2 || updates[0].MatchId != "m-2" || updates[0].HomeScore != 3 || updates[1].MatchId != "m-1" || updates[1].AwayScore != 3 {
+		t.Errorf("updates = %v, want the latest state of m-2, then of m-1", updates)
+	}
+	if coalesced != 4 {
+		t.Errorf("coalesced = %d, want 4", coalesced)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if updates, _, err := sub.Next(ctx); err == nil {
+		t.Errorf("Next() = %v after everything was taken, want to wait", updates)
+	}
+}
+
+// TestStreamMatchesSlowConsumer holds up a stream while a match changes several times,
+// and checks the stream carries on with the latest state and ends when its client goes away.
+func TestStreamMatchesSlowConsumer(t *testing.T) {
+	store := newMemStore()
+	hub := NewWebSocketHub()
+	s := newMatchService(store, newFakeProvider(), hub)
+	if err := s.createMatch(context.Background(), &repository.Match{MatchID: "m-1", Status: "1st_half"}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sent := make(chan *proto.MatchResponse) // Unbuffered, so the stream waits for the test
+	done := make(chan error)
+	go func() {
+		done <- s.streamMatches(ctx, []string{"m-1"}, func(update *proto.MatchResponse) error {
+			select {
+			case sent <- update:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	receive := func() *proto.MatchResponse {
+		t.Helper()
+		select {
+		case update := <-sent:
+			return update
+		case <-time.After(5 * time.Second):
+			t.Fatal("stream sent nothing")
+			return nil
+		}
+	}
+
+	if initial := receive(); initial.Status != "1st_half" {
+		t.Fatalf("initial state = %v", initial)
+	}
+	hub.BroadcastMatchUpdate("m-1", &proto.MatchResponse{MatchId: "m-1", HomeScore: 1})
+	waitUntil(t, "the stream took the first update", func() bool {
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+		for sub := range hub.streams {
+			sub.mu.Lock()
+			defer sub.mu.Unlock()
+			return len(sub.pending) == 0
+		}
+		return false
+	})
+	for goals := int32(2); goals <= 5; goals++ { // While the first one is being sent
+		hub.BroadcastMatchUpdate("m-1", &proto.MatchResponse{MatchId: "m-1", HomeScore: goals})
+	}
+
+	if update := receive(); update.HomeScore != 1 {
+		t.Errorf("first update has score %d, want 1", update.HomeScore)
+	}
+	if update := receive(); update.HomeScore != 5 {
+		t.Errorf("update after falling behind has score %d, want the latest, 5", update.HomeScore)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("stream ended with %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream didn't end with its context")
+	}
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if len(hub.streams) != 0 {
+		t.Errorf("%d subscriptions left after the stream ended", len(hub.streams))
+	}
+}
+
+// waitUntil polls cond until it holds, failing the test after a while.
+func waitUntil(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); !cond(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting until %s", what)
+		}
+	}
+}
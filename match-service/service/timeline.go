@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Page sizes for GetMatchTimeline.
+const (
+	defaultTimelinePageSize = 100
+	maxTimelinePageSize     = 500
+)
+
+// timelinePageToken is the opaque page token of GetMatchTimeline. It carries the match and
+// since it was issued for, so it can't be reused with different ones.
+type timelinePageToken struct {
+	MatchID string                  `json:"m"`
+	Since   int64                   `json:"s,omitempty"`
+	After   *repository.EventCursor `json:"a"`
+}
+
+// GetMatchTimeline returns a page of the events of a match, oldest first. Clients polling
+// for new events pass the latest_seq of their last response as since.
+func (s *MatchService) GetMatchTimeline(ctx context.Context, req *proto.TimelineRequest) (*proto.TimelineResponse, error) {
+	if req.MatchId == "" {
+		return nil, status.Error(codes.InvalidArgument, "match_id is required")
+	}
+	if req.Since < 0 {
+		return nil, status.Error(codes.InvalidArgument, "since can't be negative")
+	}
+	match, err := s.repo.GetMatch(ctx, req.MatchId)
+	if err != nil {
+		if errors.Is(err, repository.ErrMatchNotFound) {
+			return nil, status.Errorf(codes.NotFound, "match not found: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get match: %v", err)
+	}
+
+	pageSize := int64(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultTimelinePageSize
+	}
+	pageSize = min(pageSize, maxTimelinePageSize)
+	opts := repository.TimelineOptions{
+		Since:            req.Since,
+		Limit:            pageSize + 1, // One more to know whether there is a next page
+		ExcludeTypes:     repository.InternalEventTypes,
+		ExcludeRetracted: true,
+	}
+
+	if req.PageToken != "" {
+		token, err := decodeTimelinePageToken(req.PageToken)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token: %v", err)
+		}
+		if token.MatchID != req.MatchId || token.Since != req.Since {
+			return nil, status.Errorf(codes.InvalidArgument, "page_token was issued for a different match or since")
+		}
+		opts.After = token.After
+	}
+
+	events, err := s.repo.GetEvents(ctx, req.MatchId, opts)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get match timeline: %v", err)
+	}
+
+	// Events past the match's EventSeq are in the log but not applied to the match yet (see
+	// recordEvent). They are left for the next request, so the timeline never gets ahead of
+	// the match state.
+	if i := slices.IndexFunc(events, func(event *repository.Event) bool { return event.Seq > match.EventSeq }); i >= 0 {
+		events = events[:i]
+	}
+
+	resp := &proto.TimelineResponse{LatestSeq: req.Since}
+	if int64(len(events)) > pageSize {
+		events = events[:pageSize]
+		last := events[len(events)-1]
+		resp.NextPageToken = encodeTimelinePageToken(timelinePageToken{
+			MatchID: req.MatchId,
+			Since:   req.Since,
+			After:   &repository.EventCursor{Seq: last.Seq, EventID: last.EventID},
+		})
+	}
+	for _, event := range events {
+		resp.Events = append(resp.Events, toEventResponse(event))
+		resp.LatestSeq = max(resp.LatestSeq, event.Seq)
+	}
+	return resp, nil
+}
+
+func encodeTimelinePageToken(token timelinePageToken) string {
+	data, _ := json.Marshal(token) // Plain strings and numbers, can't fail
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeTimelinePageToken(value string) (*timelinePageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	var token timelinePageToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, err
+	}
+	if token.After == nil {
+		return nil, errors.New("missing position")
+	}
+	return &token, nil
+}
+
+// toEventResponse converts a stored event for the API.
+func toEventResponse(event *repository.Event) *proto.Event {
+	return &proto.Event{
+		EventId:         event.EventID,
+		MatchId:         event.MatchID,
+		EventType:       event.EventType,
+		Description:     event.Description,
+		Timestamp:       event.Timestamp,
+		TeamSide:        event.TeamSide,
+		PlayerId:        event.PlayerID,
+		RelatedPlayerId: event.RelatedPlayerID,
+		Minute:          event.Minute,
+		AddedTime:       event.AddedTime,
+		Detail:          event.Detail,
+		Seq:             event.Seq,
+		Revision:        event.Revision,
+		Retracted:       event.Retracted(),
+	}
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+)
+
+// TestGetMatchTimelineStopsAtAppliedSeq checks the timeline leaves out events that are in the
+// log but not applied to the match yet, so polling clients don't skip past them.
+func TestGetMatchTimelineStopsAtAppliedSeq(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	s := newMatchService(store, newFakeProvider(), nil)
+	if err := s.createMatch(ctx, &repository.Match{MatchID: "m-1", Status: "1st_half"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.UpdateMatchEvent(ctx, &proto.UpdateMatchEventRequest{MatchId: "m-1", EventType: "goal", TeamSide: "home"}); err != nil {
+		t.Fatal(err)
+	}
+	match, _ := store.GetMatch(ctx, "m-1")
+	pending := &repository.Event{EventID: "evt-pending", MatchID: "m-1", EventType: "foul", Seq: match.EventSeq + 1}
+	if err := store.AddEvent(ctx, pending); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := s.GetMatchTimeline(ctx, &proto.TimelineRequest{MatchId: "m-1", PageSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Events) != 1 || resp.Events[0].EventType != "goal" {
+		t.Fatalf("events = %v, want only the goal", resp.Events)
+	}
+	if resp.LatestSeq != match.EventSeq || resp.NextPageToken != "" {
+		t.Errorf("latest_seq = %d, next page %q, want %d and no next page", resp.LatestSeq, resp.NextPageToken, match.EventSeq)
+	}
+
+	// Taking the pending event's seq again fails, so the caller catches up instead
+	taken := &repository.Event{EventID: "evt-taken", MatchID: "m-1", EventType: "foul", Seq: pending.Seq}
+	if err := store.AddEvent(ctx, taken); !errors.Is(err, repository.ErrVersionConflict) {
+		t.Errorf("AddEvent with a taken seq = %v, want ErrVersionConflict", err)
+	}
+}
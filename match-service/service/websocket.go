@@ -1,143 +1,307 @@
 package service
 
 import (
-	"encoding/json"
 	"log"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/nats-io/nats.go"
+
+	"github.com/abaika-abay/live_sports_project/common/pkg/auth"
+	natsw "github.com/abaika-abay/live_sports_project/common/pkg/nats"
 )
 
-// Client represents a single WebSocket connection.
+// Client represents a single WebSocket or SSE connection. A WebSocket connection is only
+// read by readPump and only written by writePump.
 type Client struct {
-	conn    *websocket.Conn
-	send    chan []byte // Buffered channel for outgoing messages
-	matchID string      // The match this client is interested in
+	conn     *websocket.Conn // Nil for SSE clients, see HandleEvents
+	queue    *sendQueue      // Outgoing frames, see DropPolicy
+	userID   string          // From the client's token, empty if authentication isn't required
+	encoding Encoding        // Negotiated when connecting, always JSON for SSE clients
+
+	closeMessage []byte // Sent when the hub disconnects the client, see disconnect
+
+	// Guarded by the hub's mu
+	topics map[string]bool  // Topics this client follows, see Topic
+	known  map[string]int64 // Last seq queued per match, so patches only go to clients that have the state they apply to
 }
 
 // WebSocketHub manages all WebSocket connections and broadcasting.
+//
+// mu guards all hub state: the clients, the topic subscriptions, each client's topics and
+// known seqs, the replay state and the in-process subscribers. It is held for a whole
+// broadcast, so sequence numbers go out in order, but never while doing I/O or waiting on a
+// client: pushing to a client's send queue never blocks, whatever its DropPolicy.
 type WebSocketHub struct {
+	mu      sync.Mutex
 	clients map[*Client]bool // Registered clients
-	// Mapping from matchID to clients interested in that match
-	matchSubscriptions map[string]map[*Client]bool
-	broadcast          chan []byte // Inbound messages from clients (if any)
-	register           chan *Client
-	unregister         chan *Client
-	mu                 sync.RWMutex // Mutex to protect client maps
+	// Mapping from topic to clients following it
+	topicSubscriptions map[string]map[*Client]bool
+	// In-process subscribers, like gRPC streams, see SubscribeMatches
+	streams map[*MatchSubscription]bool
+	// Sequence numbers and recent updates per match, see recordUpdate
+	replays map[string]*matchReplay
+	// Viewer counts across replicas, see updatePresence
+	presence presence
+
+	dropPolicy   DropPolicy // For clients that don't pick one
+	upgrader     websocket.Upgrader
+	sseKeepAlive time.Duration // sseKeepAliveInterval, shorter in tests
+	// Authentication, see RequireAuth. Nil tokens means anyone can connect
+	tokens          *auth.Signer
+	maxConnsPerUser int
+	connsPerUser    map[string]int // Open connections per user ID
+	allowedOrigins  []string
+	// Cross-replica fan-out, see EnableFanout. Nil when running a single replica
+	fanout      *natsw.NATS
+	fanoutSub   *nats.Subscription
+	presenceSub *nats.Subscription // Viewer counts of the other replicas
+	replicaID   string
 }
 
 // NewWebSocketHub creates a new WebSocketHub.
 func NewWebSocketHub() *WebSocketHub {
-	return &WebSocketHub{
-		broadcast:          make(chan []byte),
-		register:           make(chan *Client),
-		unregister:         make(chan *Client),
+	h := &WebSocketHub{
 		clients:            make(map[*Client]bool),
-		matchSubscriptions: make(map[string]map[*Client]bool),
+		topicSubscriptions: make(map[string]map[*Client]bool),
+		streams:            make(map[*MatchSubscription]bool),
+		replays:            make(map[string]*matchReplay),
+		presence:           newPresence(),
+		dropPolicy:         DefaultDropPolicy,
+		sseKeepAlive:       sseKeepAliveInterval,
+		connsPerUser:       make(map[string]int),
+	}
+	h.upgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     h.checkOrigin,
+		// No Subprotocols: HandleConnections picks one, see negotiateEncoding
 	}
+	return h
 }
 
-// Run starts the hub's goroutine for managing clients and messages.
+// SetDropPolicy sets the policy for clients that don't pick one. Call it before serving.
+func (h *WebSocketHub) SetDropPolicy(policy DropPolicy) {
+	h.dropPolicy = policy
+}
+
+// Run does the hub's periodic housekeeping: viewer counts and pruning. Clients and
+// broadcasts are handled by the goroutines calling into the hub.
 func (h *WebSocketHub) Run() {
+	presenceTicker := time.NewTicker(presenceInterval)
+	defer presenceTicker.Stop()
+	pruneTicker := time.NewTicker(time.Hour)
+	defer pruneTicker.Stop()
 	for {
 		select {
-		case client := <-h.register:
-			h.mu.Lock()
-			h.clients[client] = true
-			if client.matchID != "" {
-				if _, ok := h.matchSubscriptions[client.matchID]; !ok {
-					h.matchSubscriptions[client.matchID] = make(map[*Client]bool)
-				}
-				h.matchSubscriptions[client.matchID][client] = true
-			}
-			h.mu.Unlock()
-			log.Printf("Client registered for match %s. Total clients: %d\n", client.matchID, len(h.clients))
-		case client := <-h.unregister:
-			h.mu.Lock()
-			if _, ok := h.clients[client]; ok {
-				delete(h.clients, client)
-				if client.matchID != "" {
-					if subs, ok := h.matchSubscriptions[client.matchID]; ok {
-						delete(subs, client)
-						if len(subs) == 0 {
-							delete(h.matchSubscriptions, client.matchID) // Clean up empty subscriptions
-						}
-					}
-				}
-				close(client.send)
-			}
-			h.mu.Unlock()
-			log.Printf("Client unregistered. Total clients: %d\n", len(h.clients))
-		case message := <-h.broadcast:
-			h.mu.RLock()
-			for client := range h.clients {
-				select {
-				case client.send <- message:
-				default:
-					close(client.send)
-					delete(h.clients, client)
-				}
-			}
-			h.mu.RUnlock()
+		case <-presenceTicker.C:
+			h.updatePresence()
+		case <-pruneTicker.C:
+			h.pruneReplays()
+		}
+	}
+}
+
+func newClient(conn *websocket.Conn, policy DropPolicy) *Client {
+	return &Client{
+		conn:   conn,
+		queue:  newSendQueue(policy, sendQueueSize),
+		topics: make(map[string]bool),
+		known:  make(map[string]int64),
+	}
+}
+
+// clientDropPolicy returns the DropPolicy a client asked for with the 'drop_policy' query
+// parameter, or the hub's default.
+func (h *WebSocketHub) clientDropPolicy(r *http.Request) (DropPolicy, error) {
+	name := r.URL.Query().Get("drop_policy")
+	if name == "" {
+		return h.dropPolicy, nil
+	}
+	return ParseDropPolicy(name)
+}
+
+// addClient registers a client.
+func (h *WebSocketHub) addClient(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.clients[client] = true
+	log.Printf("Client registered. Total clients: %d\n", len(h.clients))
+}
+
+// removeClient unregisters a client and closes its send queue, which makes its writePump
+// close the connection. Safe to call more than once.
+func (h *WebSocketHub) removeClient(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeClientLocked(client)
+}
+
+func (h *WebSocketHub) removeClientLocked(client *Client) {
+	if !h.clients[client] {
+		return
+	}
+	delete(h.clients, client)
+	for topic := range client.topics {
+		h.removeSubscription(client, topic)
+	}
+	if client.userID != "" {
+		h.releaseConnectionLocked(client.userID)
+	}
+	client.queue.close()
+	log.Printf("Client unregistered. Total clients: %d\n", len(h.clients))
+}
+
+// disconnect removes a client, closing its WebSocket with the given close code and reason.
+func (h *WebSocketHub) disconnect(client *Client, code int, reason string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.disconnectLocked(client, code, reason)
+}
+
+func (h *WebSocketHub) disconnectLocked(client *Client, code int, reason string) {
+	if h.clients[client] {
+		client.closeMessage = websocket.FormatCloseMessage(code, reason)
+		h.removeClientLocked(client)
+	}
+}
+
+// addSubscription adds client to the subscribers of topic. The caller must hold h.mu.
+func (h *WebSocketHub) addSubscription(client *Client, topic string) {
+	if _, ok := h.topicSubscriptions[topic]; !ok {
+		h.topicSubscriptions[topic] = make(map[*Client]bool)
+	}
+	h.topicSubscriptions[topic][client] = true
+}
+
+// removeSubscription removes client from the subscribers of topic. The caller must hold h.mu.
+func (h *WebSocketHub) removeSubscription(client *Client, topic string) {
+	if subs, ok := h.topicSubscriptions[topic]; ok {
+		delete(subs, client)
+		if len(subs) == 0 {
+			delete(h.topicSubscriptions, topic) // Clean up empty subscriptions
 		}
 	}
 }
 
-// BroadcastMatchUpdate sends a match update to all clients subscribed to that match.
-// This is called by the MatchService when data changes.
+// BroadcastMatchUpdate sends a match update, wrapped in an UpdateFrame with the match's
+// next sequence number, to all clients following the match, or its competition or teams
+// if data is a match state. Each client gets it once, in its Encoding: as a patch if it has
+// the previous state, as a snapshot otherwise.
+// This is called by the MatchService when data changes. With fan-out enabled, clients
+// connected to other replicas get it as well.
 func (h *WebSocketHub) BroadcastMatchUpdate(matchID string, data interface{}) {
-	h.mu.RLock()
-	defer h.mu.RUnlock()
+	if h.fanout != nil && h.publish(matchID, data) {
+		return // Delivered when it comes back from NATS
+	}
+	h.deliver(matchID, data)
+}
 
-	message, err := json.Marshal(data)
+// deliver sends a broadcast to this replica's clients and in-process subscribers.
+func (h *WebSocketHub) deliver(matchID string, data interface{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.publishToStreams(matchID, data)
+
+	frames, err := h.recordUpdate(matchID, data)
 	if err != nil {
 		log.Printf("Error marshalling match update for broadcast: %v", err)
 		return
 	}
 
-	if subs, ok := h.matchSubscriptions[matchID]; ok {
-		for client := range subs {
-			select {
-			case client.send <- message:
-			default:
-				log.Printf("Client send channel full for match %s, unregistering.", matchID)
-				// Handle slow client by unregistering or logging
-				h.unregister <- client // Send to unregister channel to clean up
+	sent := make(map[*Client]bool)
+	for _, topic := range updateTopics(matchID, data) {
+		for client := range h.topicSubscriptions[topic] {
+			if sent[client] {
+				continue
+			}
+			sent[client] = true
+
+			frame := frames.frame
+			if frames.state && client.known[matchID] != frames.seq-1 {
+				frame = frames.snapshot // Missed the previous state, a patch won't apply
+			}
+			if h.sendLocked(client, client.updateFrame(matchID, frames.seq, frame), frames.snapshot[client.encoding]) && frames.state {
+				client.known[matchID] = frames.seq
 			}
 		}
 	}
 }
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		// Allow all origins for development, but restrict in production!
-		return true
-	},
+// sendLocked queues a frame for a client, applying its DropPolicy if the client is behind.
+// It reports whether the frame was queued. The caller must hold h.mu.
+func (h *WebSocketHub) sendLocked(client *Client, frame outgoingFrame, snapshot []byte) bool {
+	if !h.clients[client] {
+		return false
+	}
+	dropped, ok := client.queue.push(frame, snapshot)
+	if !ok {
+		log.Printf("Client can't keep up, disconnecting (%s policy)", client.queue.policy)
+		h.disconnectLocked(client, websocket.CloseTryAgainLater, "client too slow")
+		return false
+	}
+	for _, matchID := range dropped {
+		delete(client.known, matchID) // Its next update has to be a snapshot
+	}
+	return true
 }
 
 // HandleConnections handles new WebSocket connections.
-// It expects a 'match_id' query parameter to subscribe clients to specific matches.
-// Example: ws://localhost:8080/ws?match_id=match-123
+// Clients follow matches, competitions and teams with subscribe messages (see ControlMessage).
+// The 'match_id' query parameter subscribes to a single match right away, for older clients;
+// with 'resume_from' the client also gets the updates it missed after that seq.
+// 'drop_policy' picks what happens when the client falls behind, see DropPolicy.
+// A token is needed if the hub requires authentication, see RequireAuth.
+// Example: ws://localhost:8080/ws?match_id=match-123&resume_from=42&drop_policy=coalesce&token=...
 func (h *WebSocketHub) HandleConnections(w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
+	claims, release, err := h.admit(w, r)
 	if err != nil {
+		return
+	}
+	encoding, header := negotiateEncoding(r)
+	conn, err := h.upgrader.Upgrade(w, r, header)
+	if err != nil {
+		release()
 		log.Println(err)
 		return
 	}
-	// Extract match_id from query parameter
-	matchID := r.URL.Query().Get("match_id")
-	if matchID == "" {
-		// Optionally, disconnect if no match_id provided or provide general updates
-		log.Println("WebSocket connection without match_id provided. Providing general updates.")
-		// For a specific match, match_id is critical
+
+	policy, policyErr := h.clientDropPolicy(r)
+	if policyErr != nil {
+		policy = h.dropPolicy
+	}
+	client := newClient(conn, policy)
+	client.encoding = encoding
+	if claims != nil {
+		client.userID = claims.UserID
+	}
+	h.addClient(client)
+	if claims != nil {
+		defer h.expireWithToken(client, claims).Stop()
+	}
+	if policyErr != nil {
+		h.reply(client, ControlReply{Type: ReplyError, Code: ErrCodeInvalidMessage, Message: policyErr.Error()})
 	}
 
-	client := &Client{conn: conn, send: make(chan []byte, 256), matchID: matchID} // Increased buffer size
-	h.register <- client
+	// Extract match_id from query parameter
+	if matchID := r.URL.Query().Get("match_id"); matchID != "" {
+		resume := make(map[string]int64)
+		if value := r.URL.Query().Get("resume_from"); value != "" {
+			seq, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || seq < 0 {
+				h.reply(client, ControlReply{Type: ReplyError, Code: ErrCodeInvalidMessage, Message: "resume_from must be a sequence number"})
+			} else {
+				resume[matchID] = seq
+			}
+		}
+		if err := h.subscribeAndResume(client, []string{MatchTopic(matchID)}, resume); err != nil {
+			log.Printf("Failed to subscribe WebSocket client to match %s: %v", matchID, err)
+		}
+	}
 
 	go client.writePump() // Goroutine to write messages to the client
 	client.readPump(h)    // Blocking call to read messages from the client
@@ -146,22 +310,21 @@ func (h *WebSocketHub) HandleConnections(w http.ResponseWriter, r *http.Request)
 // readPump pumps messages from the websocket connection to the hub.
 func (c *Client) readPump(hub *WebSocketHub) {
 	defer func() {
-		hub.unregister <- c
+		hub.removeClient(c)
 		c.conn.Close()
 	}()
-	c.conn.SetReadLimit(512)
+	c.conn.SetReadLimit(maxControlMessageSize)
 	c.conn.SetReadDeadline(time.Now().Add(60 * time.Second))                                                           // Set a deadline for pings
 	c.conn.SetPongHandler(func(string) error { c.conn.SetReadDeadline(time.Now().Add(60 * time.Second)); return nil }) // Extend deadline on pong
 	for {
-		_, _, err := c.conn.ReadMessage() // Read messages from client (e.g., pings or control messages)
+		_, message, err := c.conn.ReadMessage() // Read control messages from client
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("error: %v", err)
 			}
 			break
 		}
-		// If you expect messages from clients, process them here
-		// For now, we only expect clients to receive updates.
+		hub.handleControlMessage(c, message)
 	}
 }
 
@@ -174,29 +337,27 @@ func (c *Client) writePump() {
 	}()
 	for {
 		select {
-		case message, ok := <-c.send:
-			c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if !ok {
-				// The hub closed the channel.
-				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
-				return
-			}
-
-			w, err := c.conn.NextWriter(websocket.TextMessage)
-			if err != nil {
-				return
-			}
-			w.Write(message)
-
-			// Add queued chat messages to the current WebSocket message.
-			n := len(c.send)
-			for i := 0; i < n; i++ {
-				w.Write(<-c.send)
+		case <-c.queue.ready:
+			for _, frame := range c.queue.drain() {
+				c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+				// One JSON message per frame, so clients can tell updates and replies apart
+				messageType := websocket.TextMessage
+				if frame.binary {
+					messageType = websocket.BinaryMessage
+				}
+				if err := c.conn.WriteMessage(messageType, frame.data); err != nil {
+					return
+				}
 			}
-
-			if err := w.Close(); err != nil {
-				return
+		case <-c.queue.done:
+			// The hub removed the client.
+			closeMessage := c.closeMessage
+			if closeMessage == nil {
+				closeMessage = []byte{}
 			}
+			c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			c.conn.WriteMessage(websocket.CloseMessage, closeMessage)
+			return
 		case <-ticker.C:
 			// Send ping messages to keep the connection alive
 			c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
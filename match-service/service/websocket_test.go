@@ -0,0 +1,163 @@
+package service
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/abaika-abay/live_sports_project/match-service/wsclient"
+)
+
+func TestSendLockedDisconnectsSlowClient(t *testing.T) {
+	hub := NewWebSocketHub()
+	client := newClient(nil, Disconnect) // No writePump, so nothing is ever sent
+	hub.addClient(client)
+	hub.mu.Lock()
+	client.topics[MatchTopic("m-1")] = true
+	hub.addSubscription(client, MatchTopic("m-1"))
+	hub.mu.Unlock()
+
+	for goals := int32(0); goals <= sendQueueSize; goals++ {
+		hub.BroadcastMatchUpdate("m-1", &proto.MatchResponse{MatchId: "m-1", HomeScore: goals})
+	}
+
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if hub.clients[client] || len(hub.topicSubscriptions[MatchTopic("m-1")]) != 0 {
+		t.Error("slow client is still registered")
+	}
+	select {
+	case <-client.queue.done:
+	default:
+		t.Fatal("slow client's queue wasn't closed")
+	}
+	if len(client.closeMessage) < 2 || binary.BigEndian.Uint16(client.closeMessage) != websocket.CloseTryAgainLater {
+		t.Errorf("close message = %q, want code %d", client.closeMessage, websocket.CloseTryAgainLater)
+	}
+}
+
+func TestSendLockedCoalescesForSlowClient(t *testing.T) {
+	hub := NewWebSocketHub()
+	client := newClient(nil, CoalesceLatest)
+	hub.addClient(client)
+	hub.mu.Lock()
+	hub.addSubscription(client, MatchTopic("m-1"))
+	hub.mu.Unlock()
+
+	for goals := int32(1); goals <= sendQueueSize+10; goals++ {
+		hub.BroadcastMatchUpdate("m-1", &proto.MatchResponse{MatchId: "m-1", HomeScore: goals})
+	}
+
+	hub.mu.Lock()
+	registered := hub.clients[client]
+	hub.mu.Unlock()
+	if !registered {
+		t.Fatal("slow client was disconnected")
+	}
+	// The queue filled up at update sendQueueSize+1, which replaced everything queued with a
+	// snapshot; the updates after it are patches on that snapshot
+	frames := client.queue.drain()
+	if len(frames) != 10 || frames[0].seq != sendQueueSize+1 || !strings.Contains(string(frames[0].data), `"type":"snapshot"`) {
+		t.Fatalf("queued %d frames starting with seq %d, want a snapshot of seq %d and 9 patches", len(frames), frames[0].seq, sendQueueSize+1)
+	}
+	decoder := wsclient.NewDecoder()
+	for _, frame := range frames {
+		if _, err := decoder.Decode(frame.data); err != nil {
+			t.Fatalf("frame #%d: %v", frame.seq, err)
+		}
+	}
+	if match, ok := decoder.Match("m-1"); !ok || match.HomeScore != sendQueueSize+10 {
+		t.Errorf("state = %v, want the latest one", match)
+	}
+}
+
+// TestDeliverToManyClients broadcasts to thousands of WebSocket clients at once and checks
+// every one of them ends up with the latest state.
+func TestDeliverToManyClients(t *testing.T) {
+	clients, updates := 2000, 5
+	if testing.Short() {
+		clients = 200
+	}
+	hub := NewWebSocketHub()
+	server := httptest.NewServer(http.HandlerFunc(hub.HandleConnections))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	conns := make([]*wsclient.Client, clients)
+	var wg sync.WaitGroup
+	errs := make(chan error, clients)
+	for i := range conns {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			matchID := fmt.Sprintf("m-%d", i%2) // Two matches, to check nobody gets the other's updates
+			client, err := wsclient.Dial(url+"?match_id="+matchID, nil)
+			if err != nil {
+				errs <- err
+				return
+			}
+			conns[i] = client
+			// Subscribed once the pong arrives, as match_id is handled before control messages
+			if _, err := client.Ping(); err != nil {
+				errs <- err
+				return
+			}
+			if frame, err := client.Next(); err != nil || frame.Type != wsclient.FramePong {
+				errs <- fmt.Errorf("client %d: first frame = %+v, %v, want a pong", i, frame, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	defer func() {
+		for _, client := range conns {
+			if client != nil {
+				client.Close()
+			}
+		}
+	}()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	for goals := int32(1); goals <= int32(updates); goals++ {
+		for _, matchID := range []string{"m-0", "m-1"} {
+			hub.BroadcastMatchUpdate(matchID, &proto.MatchResponse{MatchId: matchID, Status: "1st_half", HomeScore: goals})
+		}
+	}
+
+	errs = make(chan error, clients)
+	for i, client := range conns {
+		wg.Add(1)
+		go func(i int, client *wsclient.Client) {
+			defer wg.Done()
+			matchID := fmt.Sprintf("m-%d", i%2)
+			for n := 1; n <= updates; n++ {
+				frame, err := client.Next()
+				if err != nil {
+					errs <- fmt.Errorf("client %d: %w", i, err)
+					return
+				}
+				if frame.MatchID != matchID || frame.Seq != int64(n) {
+					errs <- fmt.Errorf("client %d: got %s #%d of %s, want #%d of %s", i, frame.Type, frame.Seq, frame.MatchID, n, matchID)
+					return
+				}
+			}
+			if match, ok := client.Decoder.Match(matchID); !ok || match.HomeScore != int32(updates) {
+				errs <- fmt.Errorf("client %d: state = %v, want %d goals", i, match, updates)
+			}
+		}(i, client)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/common/pkg/auth"
+	"github.com/gorilla/websocket"
+)
+
+// Push clients authenticate with a token from user-service's Login, checked locally with the
+// shared secret. WebSocket clients pass it as the 'token' query parameter, or, to keep it out
+// of URLs and logs, as a "token.<token>" subprotocol offered along with an encoding's:
+//
+//	new WebSocket(url, ["live-sports.v1.json", "token." + token])
+//
+// SSE clients can also send it as an "Authorization: Bearer <token>" header. When the token
+// expires the connection is closed with ClosePolicyViolation and the reason "token expired",
+// and the client should reconnect with a fresh token, resuming where it left off.
+const tokenSubprotocolPrefix = "token."
+
+var errMissingToken = errors.New("missing token")
+
+// RequireAuth makes the push endpoints reject connections without a valid token, and limits
+// how many connections a single user can have open. Call it before serving.
+func (h *WebSocketHub) RequireAuth(tokens *auth.Signer, maxConnsPerUser int) {
+	h.tokens = tokens
+	h.maxConnsPerUser = maxConnsPerUser
+}
+
+// SetAllowedOrigins sets the origins browsers can open WebSockets from; "*" allows any.
+// With none, only pages served from the WebSocket server's own host can. Requests without
+// an Origin header don't come from browsers and are always allowed. Call it before serving.
+func (h *WebSocketHub) SetAllowedOrigins(origins []string) {
+	h.allowedOrigins = origins
+}
+
+func (h *WebSocketHub) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if slices.Contains(h.allowedOrigins, "*") || slices.ContainsFunc(h.allowedOrigins, func(allowed string) bool {
+		return strings.EqualFold(allowed, origin)
+	}) {
+		return true
+	}
+	if len(h.allowedOrigins) == 0 {
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+	log.Printf("Rejected WebSocket connection from origin %s", origin)
+	return false
+}
+
+// authenticate checks the token of a push connection. It returns nil claims if
+// authentication isn't required.
+func (h *WebSocketHub) authenticate(r *http.Request) (*auth.Claims, error) {
+	if h.tokens == nil {
+		return nil, nil
+	}
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		for _, protocol := range websocket.Subprotocols(r) {
+			if strings.HasPrefix(protocol, tokenSubprotocolPrefix) {
+				token = strings.TrimPrefix(protocol, tokenSubprotocolPrefix)
+				break
+			}
+		}
+	}
+	if token == "" {
+		token, _ = strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+	if token == "" {
+		return nil, errMissingToken
+	}
+	return h.tokens.Verify(token)
+}
+
+// admit authenticates a push connection and reserves one of its user's connections.
+// If it returns an error, it has written the HTTP response. Otherwise the reservation
+// passes to the client once added with addClient; call release if it doesn't get that far.
+func (h *WebSocketHub) admit(w http.ResponseWriter, r *http.Request) (claims *auth.Claims, release func(), err error) {
+	claims, err = h.authenticate(r)
+	if err != nil {
+		http.Error(w, "unauthorized: "+err.Error(), http.StatusUnauthorized)
+		return nil, nil, err
+	}
+	if claims == nil {
+		return nil, func() {}, nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.maxConnsPerUser > 0 && h.connsPerUser[claims.UserID] >= h.maxConnsPerUser {
+		http.Error(w, "too many connections", http.StatusTooManyRequests)
+		return nil, nil, errors.New("too many connections")
+	}
+	h.connsPerUser[claims.UserID]++
+	return claims, func() { h.releaseConnection(claims.UserID) }, nil
+}
+
+func (h *WebSocketHub) releaseConnection(userID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.releaseConnectionLocked(userID)
+}
+
+func (h *WebSocketHub) releaseConnectionLocked(userID string) {
+	if h.connsPerUser[userID]--; h.connsPerUser[userID] <= 0 {
+		delete(h.connsPerUser, userID)
+	}
+}
+
+// expireWithToken disconnects client when the token it authenticated with expires. The
+// returned timer must be stopped when the client goes away.
+func (h *WebSocketHub) expireWithToken(client *Client, claims *auth.Claims) *time.Timer {
+	return time.AfterFunc(time.Until(claims.Expiry()), func() {
+		h.disconnect(client, websocket.ClosePolicyViolation, "token expired")
+	})
+}
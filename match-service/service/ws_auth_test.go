@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/abaika-abay/live_sports_project/common/pkg/auth"
+)
+
+// newAuthHub serves a hub requiring tokens from the returned signer on /ws and /events.
+func newAuthHub(t *testing.T, maxConnsPerUser int) (*WebSocketHub, *auth.Signer, string) {
+	t.Helper()
+	signer, err := auth.NewSigner("test-secret-of-at-least-32-bytes-long")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hub := NewWebSocketHub()
+	hub.RequireAuth(signer, maxConnsPerUser)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", hub.HandleConnections)
+	mux.HandleFunc("/events", hub.HandleEvents)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return hub, signer, server.URL
+}
+
+// dialWithToken opens a WebSocket to the hub at url, passing the token in the query or,
+// if subprotocol, as a subprotocol. It returns the HTTP status of the handshake.
+func dialWithToken(t *testing.T, url, token string, subprotocol bool) (*websocket.Conn, int) {
+	t.Helper()
+	dialer := *websocket.DefaultDialer
+	dialer.Subprotocols = []string{SubprotocolJSON}
+	url = "ws" + strings.TrimPrefix(url, "http") + "/ws"
+	if subprotocol {
+		dialer.Subprotocols = append(dialer.Subprotocols, tokenSubprotocolPrefix+token)
+	} else if token != "" {
+		url += "?token=" + token
+	}
+	conn, resp, err := dialer.Dial(url, nil)
+	if err != nil {
+		if resp == nil {
+			t.Fatal(err)
+		}
+		return nil, resp.StatusCode
+	}
+	t.Cleanup(func() { conn.Close() })
+	if conn.Subprotocol() != SubprotocolJSON {
+		t.Errorf("accepted subprotocol %q, want %q", conn.Subprotocol(), SubprotocolJSON)
+	}
+	return conn, resp.StatusCode
+}
+
+func TestCheckOrigin(t *testing.T) {
+	for _, tc := range []struct {
+		allowed []string
+		origin  string
+		want    bool
+	}{
+		{nil, "", true}, // Not a browser
+		{nil, "http://match.example", true},
+		{nil, "http://evil.example", false},
+		{[]string{"http://app.example"}, "http://APP.example", true},
+		{[]string{"http://app.example"}, "http://match.example", false}, // Same host isn't enough once set
+		{[]string{"http://app.example"}, "http://evil.example", false},
+		{[]string{"*"}, "http://evil.example", true},
+	} {
+		hub := NewWebSocketHub()
+		hub.SetAllowedOrigins(tc.allowed)
+		r := httptest.NewRequest(http.MethodGet, "http://match.example/ws", nil)
+		if tc.origin != "" {
+			r.Header.Set("Origin", tc.origin)
+		}
+		if got := hub.checkOrigin(r); got != tc.want {
+			t.Errorf("allowed %v, origin %q: checkOrigin() = %v, want %v", tc.allowed, tc.origin, got, tc.want)
+		}
+	}
+}
+
+func TestWebSocketTokenTransports(t *testing.T) {
+	_, signer, url := newAuthHub(t, 0)
+	token, _, _ := signer.Issue("user-1", time.Hour)
+	expired, _, _ := signer.Issue("user-1", -time.Minute)
+
+	for _, tc := range []struct {
+		name        string
+		token       string
+		subprotocol bool
+		want        int
+	}{
+		{"query", token, false, http.StatusSwitchingProtocols},
+		{"subprotocol", token, true, http.StatusSwitchingProtocols},
+		{"missing", "", false, http.StatusUnauthorized},
+		{"forged", token + "x", false, http.StatusUnauthorized},
+		{"expired", expired, true, http.StatusUnauthorized},
+	} {
+		if _, status := dialWithToken(t, url, tc.token, tc.subprotocol); status != tc.want {
+			t.Errorf("%s: handshake status %d, want %d", tc.name, status, tc.want)
+		}
+	}
+}
+
+func TestSSEBearerToken(t *testing.T) {
+	_, signer, url := newAuthHub(t, 0)
+	token, _, _ := signer.Issue("user-1", time.Hour)
+
+	for _, tc := range []struct {
+		header string
+		want   int
+	}{
+		{"Bearer " + token, http.StatusOK},
+		{"", http.StatusUnauthorized},
+		{"Basic " + token, http.StatusUnauthorized},
+	} {
+		ctx, cancel := context.WithCancel(context.Background())
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url+"/events?match_id=m-1", nil)
+		if tc.header != "" {
+			req.Header.Set("Authorization", tc.header)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != tc.want {
+			t.Errorf("Authorization %q: status %d, want %d", tc.header, resp.StatusCode, tc.want)
+		}
+		resp.Body.Close()
+		cancel()
+	}
+}
+
+func TestMaxConnectionsPerUser(t *testing.T) {
+	hub, signer, url := newAuthHub(t, 2)
+	token, _, _ := signer.Issue("user-1", time.Hour)
+	other, _, _ := signer.Issue("user-2", time.Hour)
+
+	first, _ := dialWithToken(t, url, token, false)
+	dialWithToken(t, url, token, true)
+	if _, status := dialWithToken(t, url, token, false); status != http.StatusTooManyRequests {
+		t.Errorf("third connection got status %d, want %d", status, http.StatusTooManyRequests)
+	}
+	if _, status := dialWithToken(t, url, other, false); status != http.StatusSwitchingProtocols {
+		t.Errorf("another user's connection got status %d, want %d", status, http.StatusSwitchingProtocols)
+	}
+
+	first.Close()
+	waitUntil(t, "the closed connection is released", func() bool {
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+		return hub.connsPerUser["user-1"] == 1
+	})
+	if _, status := dialWithToken(t, url, token, false); status != http.StatusSwitchingProtocols {
+		t.Errorf("connection after one closed got status %d, want %d", status, http.StatusSwitchingProtocols)
+	}
+}
+
+func TestTokenExpiryClosesConnection(t *testing.T) {
+	_, signer, url := newAuthHub(t, 0)
+	token, claims, _ := signer.Issue("user-1", 2*time.Second) // Expiry is in whole seconds
+	conn, _ := dialWithToken(t, url, token, true)
+
+	conn.SetReadDeadline(claims.Expiry().Add(5 * time.Second))
+	for {
+		_, _, err := conn.ReadMessage()
+		if err == nil {
+			continue
+		}
+		var closeErr *websocket.CloseError
+		if !errors.As(err, &closeErr) || closeErr.Code != websocket.ClosePolicyViolation || closeErr.Text != "token expired" {
+			t.Fatalf("read error = %v, want close %d with reason \"token expired\"", err, websocket.ClosePolicyViolation)
+		}
+		if time.Now().Before(claims.Expiry()) {
+			t.Errorf("closed at %v, before the token expired at %v", time.Now(), claims.Expiry())
+		}
+		return
+	}
+}
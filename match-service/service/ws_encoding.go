@@ -0,0 +1,79 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/gorilla/websocket"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// Encoding is how updates are sent to a WebSocket client, negotiated with a subprotocol when
+// connecting. Every update is encoded once per encoding, whatever the number of clients.
+// Control messages and replies are JSON text messages in every encoding.
+type Encoding int
+
+const (
+	// EncodingJSON sends UpdateFrames as JSON text messages, with match states as merge
+	// patches after the first snapshot. It is the default, and the only encoding over SSE.
+	EncodingJSON Encoding = iota
+	// EncodingProtobuf sends proto.UpdateFrames as binary messages, with every match state
+	// sent whole: cheaper to parse, and no patches to apply.
+	EncodingProtobuf
+
+	numEncodings
+)
+
+// Subprotocols clients offer to pick an encoding. Clients offering several get the first one
+// they offer; clients offering none get JSON.
+const (
+	Subprotocol         = "live-sports.v1" // JSON, kept for clients that predate encodings
+	SubprotocolJSON     = "live-sports.v1.json"
+	SubprotocolProtobuf = "live-sports.v1.protobuf"
+)
+
+var subprotocolEncodings = map[string]Encoding{
+	Subprotocol:         EncodingJSON,
+	SubprotocolJSON:     EncodingJSON,
+	SubprotocolProtobuf: EncodingProtobuf,
+}
+
+// negotiateEncoding picks the encoding of a WebSocket connection from the subprotocols the
+// client offers, and returns the response header accepting it.
+func negotiateEncoding(r *http.Request) (Encoding, http.Header) {
+	for _, protocol := range websocket.Subprotocols(r) {
+		if encoding, ok := subprotocolEncodings[protocol]; ok {
+			return encoding, http.Header{"Sec-Websocket-Protocol": {protocol}}
+		}
+	}
+	return EncodingJSON, nil
+}
+
+// encodedFrame is a frame in every encoding.
+type encodedFrame [numEncodings][]byte
+
+// encodeFrames encodes a frame in every encoding. raw is the JSON of the update; state is
+// the same update if it is a match state.
+func encodeFrames(frameType, matchID string, seq int64, raw json.RawMessage, state *proto.MatchResponse) (encodedFrame, error) {
+	var frames encodedFrame
+	var err error
+	if frames[EncodingJSON], err = json.Marshal(UpdateFrame{Type: frameType, MatchID: matchID, Seq: seq, Data: raw}); err != nil {
+		return frames, err
+	}
+
+	message := &proto.UpdateFrame{Type: frameType, MatchId: matchID, Seq: seq, State: state}
+	if state == nil {
+		message.Data = raw
+	}
+	if frames[EncodingProtobuf], err = protobuf.Marshal(message); err != nil {
+		return frames, fmt.Errorf("failed to encode protobuf frame: %w", err)
+	}
+	return frames, nil
+}
+
+// updateFrame returns an update for the client, in its encoding.
+func (c *Client) updateFrame(matchID string, seq int64, frame encodedFrame) outgoingFrame {
+	return outgoingFrame{matchID: matchID, seq: seq, data: frame[c.encoding], binary: c.encoding != EncodingJSON}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/abaika-abay/live_sports_project/match-service/wsclient"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	for _, tc := range []struct {
+		offered  []string
+		want     Encoding
+		accepted string
+	}{
+		{nil, EncodingJSON, ""},
+		{[]string{"graphql-ws"}, EncodingJSON, ""},
+		{[]string{Subprotocol}, EncodingJSON, Subprotocol},
+		{[]string{SubprotocolJSON}, EncodingJSON, SubprotocolJSON},
+		{[]string{SubprotocolProtobuf}, EncodingProtobuf, SubprotocolProtobuf},
+		// The first encoding offered wins; tokens aren't encodings
+		{[]string{tokenSubprotocolPrefix + "abc", SubprotocolProtobuf, SubprotocolJSON}, EncodingProtobuf, SubprotocolProtobuf},
+		{[]string{SubprotocolJSON, SubprotocolProtobuf}, EncodingJSON, SubprotocolJSON},
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+		if len(tc.offered) > 0 {
+			r.Header.Set("Sec-Websocket-Protocol", strings.Join(tc.offered, ", "))
+		}
+		encoding, header := negotiateEncoding(r)
+		if encoding != tc.want || header.Get("Sec-Websocket-Protocol") != tc.accepted {
+			t.Errorf("offered %v: got encoding %d accepting %q, want %d accepting %q", tc.offered, encoding, header.Get("Sec-Websocket-Protocol"), tc.want, tc.accepted)
+		}
+	}
+}
+
+func TestProtobufFrames(t *testing.T) {
+	hub := NewWebSocketHub()
+	server := httptest.NewServer(http.HandlerFunc(hub.HandleConnections))
+	defer server.Close()
+	dialer := *websocket.DefaultDialer
+	dialer.Subprotocols = []string{SubprotocolProtobuf}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"?match_id=m-1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if conn.Subprotocol() != SubprotocolProtobuf {
+		t.Fatalf("accepted subprotocol %q, want %q", conn.Subprotocol(), SubprotocolProtobuf)
+	}
+
+	// Control replies stay JSON text
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"ping","id":"1"}`)); err != nil {
+		t.Fatal(err)
+	}
+	read := func(want int) []byte {
+		t.Helper()
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if messageType != want {
+			t.Fatalf("message type %d, want %d: %q", messageType, want, data)
+		}
+		return data
+	}
+	var pong ControlReply
+	if err := json.Unmarshal(read(websocket.TextMessage), &pong); err != nil || pong.Type != ReplyPong {
+		t.Fatalf("reply = %+v, %v, want a pong", pong, err)
+	}
+
+	hub.BroadcastMatchUpdate("m-1", &proto.MatchResponse{MatchId: "m-1", HomeTeam: "Home", HomeScore: 1})
+	hub.BroadcastMatchUpdate("m-1", &proto.MatchResponse{MatchId: "m-1", HomeTeam: "Home", HomeScore: 2})
+	hub.BroadcastMatchUpdate("m-1", map[string]string{"type": "source_conflict"})
+
+	decoder := wsclient.NewDecoder()
+	for seq := int64(1); seq <= 2; seq++ {
+		data := read(websocket.BinaryMessage)
+		var frame proto.UpdateFrame
+		if err := protobuf.Unmarshal(data, &frame); err != nil {
+			t.Fatal(err)
+		}
+		// Every state is whole, a patch would leave out the unchanged team
+		if frame.Type != FrameSnapshot || frame.Seq != seq || frame.State.GetHomeScore() != int32(seq) || frame.State.GetHomeTeam() != "Home" || len(frame.Data) != 0 {
+			t.Fatalf("frame = %v, want the whole state #%d", &frame, seq)
+		}
+		if _, err := decoder.DecodeBinary(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if match, _ := decoder.Match("m-1"); match.HomeScore != 2 || match.HomeTeam != "Home" || decoder.Seq("m-1") != 2 {
+		t.Errorf("decoded state = %v at seq %d, want 2 goals at seq 2", match, decoder.Seq("m-1"))
+	}
+
+	frame, err := decoder.DecodeBinary(read(websocket.BinaryMessage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if frame.Type != FrameUpdate || frame.Seq != 3 || string(frame.Data) != `{"type":"source_conflict"}` {
+		t.Errorf("frame = %+v, want update #3 with its JSON data", frame)
+	}
+}
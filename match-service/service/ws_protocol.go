@@ -0,0 +1,200 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+)
+
+// WebSocket clients control their subscriptions with JSON messages like
+//
+//	{"type": "subscribe", "id": "1", "topics": ["match:match-123", "team:Barcelona"]}
+//
+// and get an "ack" with the same id and their current topics, or an "error" frame.
+// Subscribes can carry "resume_from": {"match-123": 42} to get missed updates, see replay.go.
+// Match updates are sent as UpdateFrames.
+const (
+	ControlSubscribe   = "subscribe"
+	ControlUnsubscribe = "unsubscribe"
+	ControlList        = "list"
+	ControlPing        = "ping"
+
+	ReplyAck   = "ack"
+	ReplyPong  = "pong"
+	ReplyError = "error"
+)
+
+// Error codes of error frames.
+const (
+	ErrCodeInvalidMessage = "invalid_message"
+	ErrCodeUnknownType    = "unknown_type"
+	ErrCodeInvalidTopic   = "invalid_topic"
+	ErrCodeTooManyTopics  = "too_many_topics"
+)
+
+const (
+	maxControlMessageSize = 4096 // Bytes, enough for a subscribe with a few dozen topics
+	maxTopicsPerClient    = 100
+)
+
+// Topic kinds. A topic is "<kind>:<value>", e.g. "match:match-123", "competition:LaLiga"
+// or "team:Barcelona". Competition and team names are matched case-insensitively.
+const (
+	TopicMatch       = "match"
+	TopicCompetition = "competition"
+	TopicTeam        = "team"
+)
+
+// MatchTopic returns the topic of a single match.
+func MatchTopic(matchID string) string {
+	return TopicMatch + ":" + matchID
+}
+
+// ParseTopic validates a topic and returns it in canonical form.
+func ParseTopic(topic string) (string, error) {
+	kind, value, ok := strings.Cut(topic, ":")
+	value = strings.TrimSpace(value)
+	if !ok || value == "" {
+		return "", fmt.Errorf("topic %q must look like <kind>:<value>", topic)
+	}
+	switch kind = strings.ToLower(kind); kind {
+	case TopicMatch:
+		return kind + ":" + value, nil
+	case TopicCompetition, TopicTeam:
+		return kind + ":" + strings.ToLower(value), nil
+	}
+	return "", fmt.Errorf("unknown topic kind %q, expected %s, %s or %s", kind, TopicMatch, TopicCompetition, TopicTeam)
+}
+
+// updateTopics returns the topics an update for matchID is delivered to.
+func updateTopics(matchID string, data interface{}) []string {
+	topics := []string{MatchTopic(matchID)}
+	if match, ok := data.(*proto.MatchResponse); ok {
+		if match.Competition != "" {
+			topics = append(topics, TopicCompetition+":"+strings.ToLower(match.Competition))
+		}
+		for _, team := range []string{match.HomeTeam, match.AwayTeam} {
+			if team != "" {
+				topics = append(topics, TopicTeam+":"+strings.ToLower(team))
+			}
+		}
+	}
+	return topics
+}
+
+// ControlMessage is a message from a WebSocket client.
+type ControlMessage struct {
+	Type   string   `json:"type"`
+	ID     string   `json:"id,omitempty"` // Echoed in the reply, to match replies to requests
+	Topics []string `json:"topics,omitempty"`
+	// Last seq seen per match ID, on subscribe. Missed updates are sent before the ack.
+	ResumeFrom map[string]int64 `json:"resume_from,omitempty"`
+}
+
+// ControlReply is a reply to a ControlMessage.
+type ControlReply struct {
+	Type    string   `json:"type"`
+	ID      string   `json:"id,omitempty"`
+	Topics  []string `json:"topics,omitempty"` // All topics the client follows, on acks
+	Code    string   `json:"code,omitempty"`   // On errors
+	Message string   `json:"message,omitempty"`
+}
+
+func (h *WebSocketHub) handleControlMessage(c *Client, data []byte) {
+	var msg ControlMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		h.reply(c, ControlReply{Type: ReplyError, Code: ErrCodeInvalidMessage, Message: "messages must be JSON objects"})
+		return
+	}
+
+	switch msg.Type {
+	case ControlSubscribe, ControlUnsubscribe:
+		topics := make([]string, 0, len(msg.Topics))
+		for _, topic := range msg.Topics {
+			parsed, err := ParseTopic(topic)
+			if err != nil {
+				h.reply(c, ControlReply{Type: ReplyError, ID: msg.ID, Code: ErrCodeInvalidTopic, Message: err.Error()})
+				return
+			}
+			topics = append(topics, parsed)
+		}
+		for matchID, seq := range msg.ResumeFrom {
+			if seq < 0 {
+				h.reply(c, ControlReply{Type: ReplyError, ID: msg.ID, Code: ErrCodeInvalidMessage, Message: fmt.Sprintf("invalid resume_from %d for match %s", seq, matchID)})
+				return
+			}
+		}
+		if msg.Type == ControlSubscribe {
+			if err := h.subscribeAndResume(c, topics, msg.ResumeFrom); err != nil {
+				h.reply(c, ControlReply{Type: ReplyError, ID: msg.ID, Code: ErrCodeTooManyTopics, Message: err.Error()})
+				return
+			}
+		} else {
+			h.unsubscribe(c, topics)
+		}
+		h.reply(c, ControlReply{Type: ReplyAck, ID: msg.ID, Topics: h.clientTopics(c)})
+	case ControlList:
+		h.reply(c, ControlReply{Type: ReplyAck, ID: msg.ID, Topics: h.clientTopics(c)})
+	case ControlPing:
+		h.reply(c, ControlReply{Type: ReplyPong, ID: msg.ID})
+	default:
+		h.reply(c, ControlReply{Type: ReplyError, ID: msg.ID, Code: ErrCodeUnknownType, Message: fmt.Sprintf("unknown message type %q", msg.Type)})
+	}
+}
+
+// subscribeLocked adds topics to a client's subscriptions. The caller must hold h.mu.
+func (h *WebSocketHub) subscribeLocked(c *Client, topics []string) error {
+	added := 0
+	for _, topic := range topics {
+		if !c.topics[topic] {
+			added++
+		}
+	}
+	if len(c.topics)+added > maxTopicsPerClient {
+		return fmt.Errorf("cannot follow more than %d topics", maxTopicsPerClient)
+	}
+	for _, topic := range topics {
+		c.topics[topic] = true
+		h.addSubscription(c, topic)
+	}
+	return nil
+}
+
+func (h *WebSocketHub) unsubscribe(c *Client, topics []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, topic := range topics {
+		delete(c.topics, topic)
+		h.removeSubscription(c, topic)
+	}
+}
+
+func (h *WebSocketHub) clientTopics(c *Client) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	topics := make([]string, 0, len(c.topics))
+	for topic := range c.topics {
+		topics = append(topics, topic)
+	}
+	slices.Sort(topics)
+	return topics
+}
+
+// reply queues a reply for the client, subject to its DropPolicy like updates.
+func (h *WebSocketHub) reply(c *Client, reply ControlReply) {
+	message, err := json.Marshal(reply)
+	if err != nil {
+		log.Printf("Error marshalling WebSocket reply: %v", err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sendLocked(c, outgoingFrame{data: message}, nil)
+}
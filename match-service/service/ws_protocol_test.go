@@ -0,0 +1,117 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	"github.com/abaika-abay/live_sports_project/match-service/wsclient"
+)
+
+// controlConn is a WebSocket connection to a hub that sends raw control messages.
+type controlConn struct {
+	t    *testing.T
+	conn *websocket.Conn
+}
+
+func dialControl(t *testing.T, hub *WebSocketHub) *controlConn {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(hub.HandleConnections))
+	t.Cleanup(server.Close)
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return &controlConn{t: t, conn: conn}
+}
+
+// send sends a control message and returns the next frame, which is its reply unless
+// updates were queued before it.
+func (c *controlConn) send(message string) *wsclient.Frame {
+	c.t.Helper()
+	if err := c.conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+		c.t.Fatal(err)
+	}
+	return c.next()
+}
+
+func (c *controlConn) next() *wsclient.Frame {
+	c.t.Helper()
+	c.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var frame wsclient.Frame
+	if err := c.conn.ReadJSON(&frame); err != nil {
+		c.t.Fatal(err)
+	}
+	return &frame
+}
+
+// expectAck checks frame acks the message with id, and that the client follows topics.
+func expectAck(t *testing.T, frame *wsclient.Frame, id string, topics ...string) {
+	t.Helper()
+	if frame.Type != ReplyAck || frame.ID != id || !slices.Equal(frame.Topics, topics) {
+		t.Fatalf("reply = %+v, want an ack of %s with topics %v", frame, id, topics)
+	}
+}
+
+// expectError checks frame is an error reply to the message with id.
+func expectError(t *testing.T, frame *wsclient.Frame, id, code string) {
+	t.Helper()
+	if frame.Type != ReplyError || frame.ID != id || frame.Code != code {
+		t.Fatalf("reply = %+v, want a %s error for %s", frame, code, id)
+	}
+}
+
+func TestControlMessages(t *testing.T) {
+	hub := NewWebSocketHub()
+	conn := dialControl(t, hub)
+
+	expectAck(t, conn.send(`{"type":"subscribe","id":"1","topics":["match:m-1","Team: Barcelona ","competition:La Liga"]}`),
+		"1", "competition:la liga", "match:m-1", "team:barcelona")
+	hub.BroadcastMatchUpdate("m-2", &proto.MatchResponse{MatchId: "m-2", HomeTeam: "BARCELONA"})
+	if frame := conn.next(); frame.Type != wsclient.FrameSnapshot || frame.MatchID != "m-2" {
+		t.Fatalf("frame = %+v, want the snapshot of m-2, followed through its team", frame)
+	}
+
+	expectAck(t, conn.send(`{"type":"unsubscribe","id":"2","topics":["team:BARCELONA"]}`), "2", "competition:la liga", "match:m-1")
+	hub.BroadcastMatchUpdate("m-2", &proto.MatchResponse{MatchId: "m-2", HomeTeam: "BARCELONA", HomeScore: 1})
+	expectAck(t, conn.send(`{"type":"list","id":"3"}`), "3", "competition:la liga", "match:m-1")
+	if frame := conn.send(`{"type":"ping","id":"4"}`); frame.Type != ReplyPong || frame.ID != "4" {
+		t.Fatalf("reply = %+v, want the pong of 4", frame)
+	}
+
+	expectError(t, conn.send(`{"type":"subscribe","id":"5","topics":["match:m-3","player:messi"]}`), "5", ErrCodeInvalidTopic)
+	expectError(t, conn.send(`{"type":"subscribe","id":"6","topics":["match: "]}`), "6", ErrCodeInvalidTopic)
+	expectError(t, conn.send(`{"type":"subscribe","id":"7","topics":["m-3"]}`), "7", ErrCodeInvalidTopic)
+	expectError(t, conn.send(`{"type":"subscribe","id":"8","topics":["match:m-3"],"resume_from":{"m-3":-1}}`), "8", ErrCodeInvalidMessage)
+	expectError(t, conn.send(`{"type":"dance","id":"9"}`), "9", ErrCodeUnknownType)
+	expectError(t, conn.send(`hello`), "", ErrCodeInvalidMessage)
+	// Invalid messages change nothing, not even their valid topics
+	expectAck(t, conn.send(`{"type":"list","id":"10"}`), "10", "competition:la liga", "match:m-1")
+}
+
+func TestTopicLimit(t *testing.T) {
+	hub := NewWebSocketHub()
+	conn := dialControl(t, hub)
+
+	var topics []string
+	for i := 0; i < maxTopicsPerClient; i++ {
+		topics = append(topics, MatchTopic(fmt.Sprintf("m-%03d", i)))
+	}
+	message, _ := json.Marshal(ControlMessage{Type: ControlSubscribe, ID: "1", Topics: topics})
+	expectAck(t, conn.send(string(message)), "1", topics...)
+
+	expectError(t, conn.send(`{"type":"subscribe","id":"2","topics":["match:m-new"]}`), "2", ErrCodeTooManyTopics)
+	// Topics the client already follows don't count again
+	expectAck(t, conn.send(`{"type":"subscribe","id":"3","topics":["match:m-000"]}`), "3", topics...)
+	expectAck(t, conn.send(`{"type":"unsubscribe","id":"4","topics":["match:m-000"]}`), "4", topics[1:]...)
+	expectAck(t, conn.send(`{"type":"subscribe","id":"5","topics":["match:m-new"]}`), "5", append(topics[1:], "match:m-new")...)
+}
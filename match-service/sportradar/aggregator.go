@@ -0,0 +1,332 @@
+package sportradar
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+)
+
+// Field groups of repository.Match that can be prioritised per source.
+const (
+	FieldScore     = "score"      // HomeScore and AwayScore
+	FieldStatus    = "status"     // Status
+	FieldStats     = "stats"      // Possession, Shots and Fouls
+	FieldCards     = "cards"      // Cards
+	FieldLastEvent = "last_event" // LastEvent
+)
+
+// AllFields lists every field group, in the order they are merged.
+var AllFields = []string{FieldScore, FieldStatus, FieldStats, FieldCards, FieldLastEvent}
+
+// Source is a named match data feed. Any SportradarClientI can be a source: the Sportradar
+// client or an adapter for another vendor. Admin corrections aren't a source; they are
+// persisted as overrides on the match, which provider data doesn't replace.
+type Source struct {
+	Name   string
+	Client SportradarClientI
+}
+
+// Conflict is reported when sources keep disagreeing on a field.
+type Conflict struct {
+	MatchID string
+	Field   string
+	Values  map[string]string // Value per source name
+	Since   time.Time         // When the sources started to disagree
+}
+
+func (c Conflict) String() string {
+	var values []string
+	for source, value := range c.Values {
+		values = append(values, fmt.Sprintf("%s=%s", source, value))
+	}
+	slices.Sort(values)
+	return fmt.Sprintf("sources disagree on %s of match %s since %s: %s",
+		c.Field, c.MatchID, c.Since.Format(time.RFC3339), strings.Join(values, ", "))
+}
+
+// AggregatorConfig configures an AggregatedClient.
+type AggregatorConfig struct {
+	// Priorities lists source names per field, highest priority first. Sources missing
+	// from a field's list (or fields without a list) fall back to the order of the sources.
+	Priorities map[string][]string
+	// ConflictThreshold is how long sources may disagree on score or status before
+	// OnConflict is called. Short disagreements are normal as feeds update at different times.
+	ConflictThreshold time.Duration
+	// OnConflict is called once per conflict, from the goroutine calling FetchMatchData.
+	OnConflict func(Conflict)
+}
+
+// AggregatedClient merges several sources into one SportradarClientI. Match data is fetched
+// from every source and merged field by field according to the configured priorities;
+// list endpoints are merged, and the remaining calls go to the first source that answers.
+type AggregatedClient struct {
+	sources []Source
+	cfg     AggregatorConfig
+	now     func() time.Time
+
+	mu            sync.Mutex
+	disagreeSince map[string]time.Time // Keyed by match ID and field
+	alerted       map[string]bool
+}
+
+// NewAggregatedClient creates a client over sources, ordered by default priority.
+func NewAggregatedClient(sources []Source, cfg AggregatorConfig) *AggregatedClient {
+	return &AggregatedClient{
+		sources:       sources,
+		cfg:           cfg,
+		now:           time.Now,
+		disagreeSince: make(map[string]time.Time),
+		alerted:       make(map[string]bool),
+	}
+}
+
+// sourceResult is what a single source returned for a match.
+type sourceResult struct {
+	match *repository.Match
+	err   error
+}
+
+// FetchMatchData fetches the match from every source and merges the results.
+// It only fails if no source returned data, with the error of the first source.
+func (c *AggregatedClient) FetchMatchData(ctx context.Context, matchID string) (*repository.Match, error) {
+	results := make(map[string]*sourceResult, len(c.sources))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, source := range c.sources {
+		wg.Add(1)
+		go func(source Source) {
+			defer wg.Done()
+			result := &sourceResult{}
+			result.match, result.err = source.Client.FetchMatchData(ctx, matchID)
+			mu.Lock()
+			results[source.Name] = result
+			mu.Unlock()
+		}(source)
+	}
+	wg.Wait()
+
+	var base *repository.Match
+	for _, source := range c.sources {
+		if r := results[source.Name]; r.err == nil {
+			base = r.match
+			break
+		}
+	}
+	if base == nil {
+		if len(c.sources) == 0 {
+			return nil, fmt.Errorf("no source for match %s: %w", matchID, ErrNotFound)
+		}
+		return nil, results[c.sources[0].Name].err
+	}
+
+	merged := *base
+	merged.Cards = slices.Clone(base.Cards)
+	for _, field := range AllFields {
+		for _, name := range c.priority(field) {
+			if r := results[name]; r.err == nil {
+				copyField(&merged, r.match, field)
+				break
+			}
+		}
+	}
+
+	if repository.IsFinishedStatus(merged.Status) {
+		c.forget(matchID)
+	} else {
+		c.detectConflicts(matchID, results)
+	}
+	return &merged, nil
+}
+
+// priority returns the source names for field, highest priority first.
+func (c *AggregatedClient) priority(field string) []string {
+	names := slices.Clone(c.cfg.Priorities[field])
+	for _, source := range c.sources {
+		if !slices.Contains(names, source.Name) {
+			names = append(names, source.Name)
+		}
+	}
+	return names
+}
+
+// detectConflicts tracks how long the sources have disagreed on score and status,
+// and reports conflicts lasting longer than the threshold.
+func (c *AggregatedClient) detectConflicts(matchID string, results map[string]*sourceResult) {
+	now := c.now()
+	var conflicts []Conflict
+
+	c.mu.Lock()
+	for _, field := range []string{FieldScore, FieldStatus} {
+		values := make(map[string]string)
+		distinct := make(map[string]bool)
+		for name, r := range results {
+			if r.err != nil {
+				continue
+			}
+			value := fieldValue(r.match, field)
+			if value == "" {
+				continue // The source doesn't know yet, e.g. no status before kick-off
+			}
+			values[name] = value
+			distinct[value] = true
+		}
+
+		key := matchID + "|" + field
+		if len(distinct) < 2 {
+			delete(c.disagreeSince, key)
+			delete(c.alerted, key)
+			continue
+		}
+		since, ok := c.disagreeSince[key]
+		if !ok {
+			c.disagreeSince[key] = now
+			continue
+		}
+		if now.Sub(since) >= c.cfg.ConflictThreshold && !c.alerted[key] {
+			c.alerted[key] = true
+			conflicts = append(conflicts, Conflict{MatchID: matchID, Field: field, Values: values, Since: since})
+		}
+	}
+	c.mu.Unlock()
+
+	for _, conflict := range conflicts {
+		if c.cfg.OnConflict != nil {
+			c.cfg.OnConflict(conflict)
+		} else {
+			log.Printf("Aggregator: %s", conflict)
+		}
+	}
+}
+
+// forget drops the disagreements tracked for a finished match, which no poll will ask for again.
+func (c *AggregatedClient) forget(matchID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, field := range []string{FieldScore, FieldStatus} {
+		delete(c.disagreeSince, matchID+"|"+field)
+		delete(c.alerted, matchID+"|"+field)
+	}
+}
+
+// FetchLiveMatches returns the live matches of every source, deduplicated by match ID.
+func (c *AggregatedClient) FetchLiveMatches(ctx context.Context) ([]*repository.Match, error) {
+	return c.mergeLists(func(client SportradarClientI) ([]*repository.Match, error) {
+		return client.FetchLiveMatches(ctx)
+	})
+}
+
+// FetchSchedule returns the schedule of every source, deduplicated by match ID.
+func (c *AggregatedClient) FetchSchedule(ctx context.Context, date time.Time) ([]*repository.Match, error) {
+	return c.mergeLists(func(client SportradarClientI) ([]*repository.Match, error) {
+		return client.FetchSchedule(ctx, date)
+	})
+}
+
+// FetchMatchTimeline returns the timeline of the first source that has one.
+func (c *AggregatedClient) FetchMatchTimeline(ctx context.Context, matchID string) ([]*repository.Event, error) {
+	var events []*repository.Event
+	err := c.first(func(client SportradarClientI) error {
+		var err error
+		events, err = client.FetchMatchTimeline(ctx, matchID)
+		return err
+	})
+	return events, err
+}
+
+// FetchLineups returns the lineups of the first source that has them.
+func (c *AggregatedClient) FetchLineups(ctx context.Context, matchID string) (*Lineups, error) {
+	var lineups *Lineups
+	err := c.first(func(client SportradarClientI) error {
+		var err error
+		lineups, err = client.FetchLineups(ctx, matchID)
+		return err
+	})
+	return lineups, err
+}
+
+// FetchStandings returns the standings of the first source that has them.
+func (c *AggregatedClient) FetchStandings(ctx context.Context, competitionID string) ([]*Standing, error) {
+	var standings []*Standing
+	err := c.first(func(client SportradarClientI) error {
+		var err error
+		standings, err = client.FetchStandings(ctx, competitionID)
+		return err
+	})
+	return standings, err
+}
+
+// mergeLists calls fetch on every source in order and merges the results. Earlier sources
+// win for matches listed by several. It only fails if every source failed.
+func (c *AggregatedClient) mergeLists(fetch func(SportradarClientI) ([]*repository.Match, error)) ([]*repository.Match, error) {
+	var merged []*repository.Match
+	seen := make(map[string]bool)
+	var firstErr error
+	failed := 0
+	for _, source := range c.sources {
+		matches, err := fetch(source.Client)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s: %w", source.Name, err)
+			}
+			failed++
+			continue
+		}
+		for _, match := range matches {
+			if !seen[match.MatchID] {
+				seen[match.MatchID] = true
+				merged = append(merged, match)
+			}
+		}
+	}
+	if failed == len(c.sources) {
+		return nil, firstErr
+	}
+	return merged, nil
+}
+
+// first calls fetch on each source in order until one succeeds.
+func (c *AggregatedClient) first(fetch func(SportradarClientI) error) error {
+	var firstErr error
+	for _, source := range c.sources {
+		err := fetch(source.Client)
+		if err == nil {
+			return nil
+		}
+		if firstErr == nil {
+			firstErr = fmt.Errorf("%s: %w", source.Name, err)
+		}
+	}
+	return firstErr
+}
+
+func copyField(dst, src *repository.Match, field string) {
+	switch field {
+	case FieldScore:
+		dst.HomeScore, dst.AwayScore = src.HomeScore, src.AwayScore
+	case FieldStatus:
+		dst.Status = src.Status
+	case FieldStats:
+		dst.Possession, dst.Shots, dst.Fouls = src.Possession, src.Shots, src.Fouls
+	case FieldCards:
+		dst.Cards = slices.Clone(src.Cards)
+	case FieldLastEvent:
+		dst.LastEvent = src.LastEvent
+	}
+}
+
+// fieldValue renders a field for conflict detection and reporting.
+func fieldValue(match *repository.Match, field string) string {
+	switch field {
+	case FieldScore:
+		return fmt.Sprintf("%d-%d", match.HomeScore, match.AwayScore)
+	case FieldStatus:
+		return strings.ToLower(match.Status)
+	}
+	return ""
+}
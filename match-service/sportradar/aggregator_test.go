@@ -0,0 +1,112 @@
+package sportradar
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+)
+
+// feedClient reports whatever match a test sets.
+type feedClient struct {
+	SportradarClientI
+	mu    sync.Mutex
+	match repository.Match
+}
+
+func (c *feedClient) set(status string, home, away int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.match.Status, c.match.HomeScore, c.match.AwayScore = status, home, away
+}
+
+func (c *feedClient) FetchMatchData(_ context.Context, matchID string) (*repository.Match, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	match := c.match
+	match.MatchID = matchID
+	return &match, nil
+}
+
+// newTestAggregator aggregates a primary and a secondary feed, with a clock the test moves.
+// Conflicts are collected in the returned slice.
+func newTestAggregator() (client *AggregatedClient, primary, secondary *feedClient, now *time.Time, conflicts *[]Conflict) {
+	primary, secondary = &feedClient{}, &feedClient{}
+	t := time.Date(2026, 5, 30, 18, 0, 0, 0, time.UTC)
+	now = &t
+	conflicts = new([]Conflict)
+	client = NewAggregatedClient([]Source{
+		{Name: "sportradar", Client: primary},
+		{Name: "vendor", Client: secondary},
+	}, AggregatorConfig{
+		ConflictThreshold: time.Minute,
+		OnConflict:        func(c Conflict) { *conflicts = append(*conflicts, c) },
+	})
+	client.now = func() time.Time { return *now }
+	return client, primary, secondary, now, conflicts
+}
+
+func TestAggregatorReportsLastingConflicts(t *testing.T) {
+	client, primary, secondary, now, conflicts := newTestAggregator()
+	primary.set("2nd_half", 1, 0)
+	secondary.set("2nd_half", 0, 0)
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.FetchMatchData(context.Background(), "m-1"); err != nil {
+			t.Fatal(err)
+		}
+		*now = now.Add(40 * time.Second)
+	}
+	if len(*conflicts) != 1 || (*conflicts)[0].Field != FieldScore {
+		t.Fatalf("conflicts = %v, want one on the score", *conflicts)
+	}
+	want := map[string]string{"sportradar": "1-0", "vendor": "0-0"}
+	for source, value := range want {
+		if got := (*conflicts)[0].Values[source]; got != value {
+			t.Errorf("%s reported %q, want %q", source, got, value)
+		}
+	}
+}
+
+func TestAggregatorIgnoresUnreportedFields(t *testing.T) {
+	client, primary, secondary, now, conflicts := newTestAggregator()
+	primary.set("1st_half", 0, 0)
+	secondary.set("", 0, 0) // Only has the score
+
+	for i := 0; i < 5; i++ {
+		match, err := client.FetchMatchData(context.Background(), "m-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if match.Status != "1st_half" {
+			t.Fatalf("merged status = %q, want 1st_half", match.Status)
+		}
+		*now = now.Add(time.Minute)
+	}
+	if len(*conflicts) != 0 {
+		t.Errorf("conflicts = %v, want none", *conflicts)
+	}
+}
+
+func TestAggregatorForgetsFinishedMatches(t *testing.T) {
+	client, primary, secondary, _, _ := newTestAggregator()
+	primary.set("2nd_half", 1, 0)
+	secondary.set("2nd_half", 0, 0)
+	if _, err := client.FetchMatchData(context.Background(), "m-1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.disagreeSince) == 0 {
+		t.Fatal("disagreement on the score isn't tracked")
+	}
+
+	primary.set("closed", 1, 0)
+	secondary.set("closed", 0, 0)
+	if _, err := client.FetchMatchData(context.Background(), "m-1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.disagreeSince) != 0 || len(client.alerted) != 0 {
+		t.Errorf("finished match still tracked: disagreeSince %v, alerted %v", client.disagreeSince, client.alerted)
+	}
+}
@@ -2,8 +2,8 @@ package sportradar
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,14 +13,20 @@ import (
 // SportradarClient simulates an external Sportradar API.
 // In a real application, this would make HTTP requests.
 type SportradarClient struct {
-	liveData map[string]*repository.Match // Mock live data storage
-	mu       sync.RWMutex
+	liveData  map[string]*repository.Match // Mock live data storage
+	timelines map[string][]*repository.Event
+	lineups   map[string]*Lineups
+	standings map[string][]*Standing // Keyed by competition ID
+	mu        sync.RWMutex
 }
 
 // NewSportradarClient creates a new mock Sportradar client.
 func NewSportradarClient() *SportradarClient {
 	return &SportradarClient{
-		liveData: make(map[string]*repository.Match),
+		liveData:  make(map[string]*repository.Match),
+		timelines: make(map[string][]*repository.Event),
+		lineups:   make(map[string]*Lineups),
+		standings: make(map[string][]*Standing),
 	}
 }
 
@@ -31,7 +37,7 @@ func (c *SportradarClient) FetchMatchData(ctx context.Context, matchID string) (
 
 	match, ok := c.liveData[matchID]
 	if !ok {
-		return nil, errors.New("match not found in Sportradar simulation")
+		return nil, fmt.Errorf("match %s not found in Sportradar simulation: %w", matchID, ErrNotFound)
 	}
 	return match, nil
 }
@@ -69,3 +75,90 @@ func (c *SportradarClient) AddInitialMatchData(match *repository.Match) {
 	defer c.mu.Unlock()
 	c.liveData[match.MatchID] = match
 }
+
+// FetchLiveMatches returns every mock match that has kicked off but not finished yet.
+func (c *SportradarClient) FetchLiveMatches(ctx context.Context) ([]*repository.Match, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var matches []*repository.Match
+	for _, match := range c.liveData {
+		status := strings.ToLower(match.Status)
+		if status != "scheduled" && status != "not_started" && !repository.IsFinishedStatus(status) {
+			matches = append(matches, match)
+		}
+	}
+	return matches, nil
+}
+
+// FetchSchedule returns every mock match whose start time falls on the given day (UTC).
+func (c *SportradarClient) FetchSchedule(ctx context.Context, date time.Time) ([]*repository.Match, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	day := date.UTC().Format("2006-01-02")
+	var matches []*repository.Match
+	for _, match := range c.liveData {
+		startTime, err := time.Parse(time.RFC3339, match.StartTime)
+		if err == nil && startTime.UTC().Format("2006-01-02") == day {
+			matches = append(matches, match)
+		}
+	}
+	return matches, nil
+}
+
+// FetchMatchTimeline returns the events added with AddTimelineEvent.
+func (c *SportradarClient) FetchMatchTimeline(ctx context.Context, matchID string) ([]*repository.Event, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if _, ok := c.liveData[matchID]; !ok {
+		return nil, fmt.Errorf("match %s not found in Sportradar simulation: %w", matchID, ErrNotFound)
+	}
+	return append([]*repository.Event(nil), c.timelines[matchID]...), nil
+}
+
+// FetchLineups returns the lineups set with SetLineups.
+func (c *SportradarClient) FetchLineups(ctx context.Context, matchID string) (*Lineups, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	lineups, ok := c.lineups[matchID]
+	if !ok {
+		return nil, fmt.Errorf("no lineups for match %s in Sportradar simulation: %w", matchID, ErrNotFound)
+	}
+	return lineups, nil
+}
+
+// FetchStandings returns the standings set with SetStandings.
+func (c *SportradarClient) FetchStandings(ctx context.Context, competitionID string) ([]*Standing, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	standings, ok := c.standings[competitionID]
+	if !ok {
+		return nil, fmt.Errorf("no standings for competition %s in Sportradar simulation: %w", competitionID, ErrNotFound)
+	}
+	return standings, nil
+}
+
+// AddTimelineEvent appends an event to the mock timeline of a match.
+func (c *SportradarClient) AddTimelineEvent(event *repository.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.timelines[event.MatchID] = append(c.timelines[event.MatchID], event)
+}
+
+// SetLineups sets the mock lineups of a match.
+func (c *SportradarClient) SetLineups(lineups *Lineups) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lineups[lineups.MatchID] = lineups
+}
+
+// SetStandings sets the mock standings of a competition.
+func (c *SportradarClient) SetStandings(competitionID string, standings []*Standing) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.standings[competitionID] = standings
+}
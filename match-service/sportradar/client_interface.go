@@ -2,6 +2,7 @@ package sportradar
 
 import (
 	"context"
+	"time"
 
 	"github.com/abaika-abay/live_sports_project/match-service/repository"
 )
@@ -9,5 +10,13 @@ import (
 // SportradarClientI defines the interface for interacting with the Sportradar API.
 type SportradarClientI interface {
 	FetchMatchData(ctx context.Context, matchID string) (*repository.Match, error)
-	// You might add other methods like FetchLiveMatchesList, etc.
+	// FetchLiveMatches returns every match currently in play.
+	FetchLiveMatches(ctx context.Context) ([]*repository.Match, error)
+	// FetchSchedule returns every match scheduled on the given day (UTC).
+	FetchSchedule(ctx context.Context, date time.Time) ([]*repository.Match, error)
+	// FetchMatchTimeline returns the events of a match in chronological order.
+	FetchMatchTimeline(ctx context.Context, matchID string) ([]*repository.Event, error)
+	FetchLineups(ctx context.Context, matchID string) (*Lineups, error)
+	// FetchStandings returns the league table of a competition, ordered by rank.
+	FetchStandings(ctx context.Context, competitionID string) ([]*Standing, error)
 }
@@ -0,0 +1,72 @@
+package sportradar
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Sentinel errors for the Sportradar API status codes we handle differently.
+// Use errors.Is on errors returned by SportradarHTTPClient to check for them.
+var (
+	ErrUnauthorized = errors.New("sportradar: unauthorized, check the API key")
+	ErrForbidden    = errors.New("sportradar: forbidden, the API key has no access to this resource")
+	ErrNotFound     = errors.New("sportradar: not found")
+	ErrRateLimited  = errors.New("sportradar: rate limit or quota exceeded")
+	ErrServerError  = errors.New("sportradar: server error")
+)
+
+// APIError is returned when Sportradar answers with a non-200 status code.
+type APIError struct {
+	StatusCode int
+	Status     string
+	RetryAfter time.Duration // Parsed Retry-After header, zero if absent
+	kind       error         // One of the sentinel errors above, nil for other codes
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Sportradar API returned status code %d: %s", e.StatusCode, e.Status)
+}
+
+// Unwrap lets errors.Is match the sentinel error for the status code.
+func (e *APIError) Unwrap() error {
+	return e.kind
+}
+
+// newAPIError builds an APIError from a non-200 response.
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		apiErr.kind = ErrUnauthorized
+	case resp.StatusCode == http.StatusForbidden:
+		apiErr.kind = ErrForbidden
+	case resp.StatusCode == http.StatusNotFound:
+		apiErr.kind = ErrNotFound
+	case resp.StatusCode == http.StatusTooManyRequests:
+		apiErr.kind = ErrRateLimited
+	case resp.StatusCode >= 500:
+		apiErr.kind = ErrServerError
+	}
+	return apiErr
+}
+
+// parseRetryAfter accepts both forms of the Retry-After header: delay in seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,77 @@
+package sportradar
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Handler exposes the simulator as a fake Sportradar Soccer v4 API, so SportradarHTTPClient
+// can be pointed at it for demos and load tests. Paths are relative to the API base URL;
+// mount it with http.StripPrefix to serve it under e.g. /soccer/trial/v4/en/.
+func (s *Simulator) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /sport_events/{id}/summary.json", func(w http.ResponseWriter, r *http.Request) {
+		resp, err := s.response(r.PathValue("id"))
+		if err != nil {
+			writeFakeError(w, err)
+			return
+		}
+		writeFakeJSON(w, resp.summary())
+	})
+	mux.HandleFunc("GET /sport_events/{id}/timeline.json", func(w http.ResponseWriter, r *http.Request) {
+		resp, err := s.response(r.PathValue("id"))
+		if err != nil {
+			writeFakeError(w, err)
+			return
+		}
+		writeFakeJSON(w, resp)
+	})
+	mux.HandleFunc("GET /sport_events/{id}/lineups.json", func(w http.ResponseWriter, r *http.Request) {
+		resp, err := s.lineupsResponse(r.PathValue("id"))
+		if err != nil {
+			writeFakeError(w, err)
+			return
+		}
+		writeFakeJSON(w, resp)
+	})
+	mux.HandleFunc("GET /schedules/live/summaries.json", func(w http.ResponseWriter, r *http.Request) {
+		writeFakeJSON(w, sportradarSummariesResponse{Summaries: s.liveSummaries()})
+	})
+	mux.HandleFunc("GET /schedules/{date}/summaries.json", func(w http.ResponseWriter, r *http.Request) {
+		date, err := time.Parse("2006-01-02", r.PathValue("date"))
+		if err != nil {
+			http.Error(w, `{"message":"invalid date"}`, http.StatusBadRequest)
+			return
+		}
+		writeFakeJSON(w, sportradarSummariesResponse{Summaries: s.scheduleSummaries(date)})
+	})
+	mux.HandleFunc("GET /seasons/{id}/standings.json", func(w http.ResponseWriter, r *http.Request) {
+		resp, err := s.standingsResponse(r.PathValue("id"))
+		if err != nil {
+			writeFakeError(w, err)
+			return
+		}
+		writeFakeJSON(w, resp)
+	})
+	return mux
+}
+
+func writeFakeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Fake Sportradar: failed to encode response: %v", err)
+	}
+}
+
+func writeFakeError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	if errors.Is(err, ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+	} else {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	json.NewEncoder(w).Encode(map[string]string{"message": err.Error()})
+}
@@ -2,8 +2,11 @@ package sportradar
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/abaika-abay/live_sports_project/match-service/repository"
@@ -26,111 +29,407 @@ func NewSportradarHTTPClient(baseURL, apiKey string) *SportradarHTTPClient {
 	}
 }
 
-// NOTE: This is a SIMPLIFIED mock of Sportradar's complex API structure.
-// You will need to adjust 'SportradarMatchResponse' and 'FetchMatchData'
-// based on the ACTUAL Sportradar API documentation for the sport you are tracking.
-// Sportradar API responses are nested and require careful parsing.
-// For example, soccer: /{sport}/trial/v4/{lang}/matches/{match_id}/summary.{format}?api_key={api_key}
+// NOTE: Only the parts of the Sportradar Soccer v4 payloads we actually use are modelled here.
+// The summary endpoint (/sport_events/{id}/summary.json) returns sport_event, sport_event_status
+// and statistics; the timeline endpoint (/sport_events/{id}/timeline.json) returns the same
+// plus the timeline array, so SportradarMatchResponse covers both.
 
-// SportradarMatchResponse defines the structure of a simplified Sportradar API response.
-// THIS IS A PLACEHOLDER. You MUST adapt this struct to match Sportradar's actual JSON.
+// SportradarMatchResponse is the decoded summary/timeline response for a single match.
 type SportradarMatchResponse struct {
-	Match struct {
-		ID       string `json:"id"`
-		Status   string `json:"status"` // e.g., "live", "finished", "scheduled"
-		HomeTeam struct {
-			Name  string `json:"name"`
-			Score int32  `json:"score"`
-		} `json:"home"`
-		AwayTeam struct {
-			Name  string `json:"name"`
-			Score int32  `json:"score"`
-		} `json:"away"`
-		// You would add more fields for stats, events, etc., as per Sportradar's API docs
-		Statistics struct {
-			PossessionHome int32 `json:"possession_home"`
-			PossessionAway int32 `json:"possession_away"`
-			ShotsHome      int32 `json:"shots_home"`
-			ShotsAway      int32 `json:"shots_away"`
-			FoulsHome      int32 `json:"fouls_home"`
-			FoulsAway      int32 `json:"fouls_away"`
-		} `json:"statistics"` // This nested structure is common in Sportradar
-	} `json:"match"`
-	// Additional top-level fields for events, cards etc. might be present
-	// E.g., 'timeline' or 'events' array depending on the endpoint.
-}
-
-// FetchMatchData simulates fetching real-time data for a specific match from Sportradar.
+	SportEvent struct {
+		ID                string `json:"id"`
+		StartTime         string `json:"start_time"` // ISO 8601
+		SportEventContext struct {
+			Sport struct {
+				Name string `json:"name"`
+			} `json:"sport"`
+			Competition struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"competition"`
+		} `json:"sport_event_context"`
+		Competitors []SportradarCompetitor `json:"competitors"`
+	} `json:"sport_event"`
+	SportEventStatus struct {
+		Status      string `json:"status"`       // e.g., "not_started", "live", "closed"
+		MatchStatus string `json:"match_status"` // e.g., "1st_half", "halftime", "ended"
+		HomeScore   int32  `json:"home_score"`
+		AwayScore   int32  `json:"away_score"`
+	} `json:"sport_event_status"`
+	Statistics struct {
+		Totals struct {
+			Competitors []SportradarTeamStatistics `json:"competitors"`
+		} `json:"totals"`
+	} `json:"statistics"`
+	Timeline []SportradarTimelineEvent `json:"timeline"` // Only present on the timeline endpoint
+}
+
+// sportradarSummariesResponse is returned by the live and daily schedule endpoints.
+type sportradarSummariesResponse struct {
+	Summaries []SportradarMatchResponse `json:"summaries"`
+}
+
+func (r *sportradarSummariesResponse) toMatches() []*repository.Match {
+	matches := make([]*repository.Match, 0, len(r.Summaries))
+	for i := range r.Summaries {
+		matches = append(matches, r.Summaries[i].toMatch())
+	}
+	return matches
+}
+
+// sportradarLineupsResponse is returned by /sport_events/{id}/lineups.json.
+type sportradarLineupsResponse struct {
+	Lineups struct {
+		Competitors []sportradarLineupCompetitor `json:"competitors"`
+	} `json:"lineups"`
+}
+
+type sportradarLineupCompetitor struct {
+	Name      string `json:"name"`
+	Qualifier string `json:"qualifier"`
+	Formation struct {
+		Type string `json:"type"`
+	} `json:"formation"`
+	Players []sportradarLineupPlayer `json:"players"`
+}
+
+type sportradarLineupPlayer struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	JerseyNumber int32  `json:"jersey_number"`
+	Position     string `json:"type"`
+	Starter      bool   `json:"starter"`
+}
+
+func (c *sportradarLineupCompetitor) toTeamLineup() TeamLineup {
+	team := TeamLineup{TeamName: c.Name, Formation: c.Formation.Type}
+	for _, p := range c.Players {
+		player := Player{ID: p.ID, Name: p.Name, JerseyNumber: p.JerseyNumber, Position: p.Position}
+		if p.Starter {
+			team.Starters = append(team.Starters, player)
+		} else {
+			team.Substitutes = append(team.Substitutes, player)
+		}
+	}
+	return team
+}
+
+// sportradarStandingsResponse is returned by /seasons/{id}/standings.json.
+type sportradarStandingsResponse struct {
+	Standings []sportradarStandingsTable `json:"standings"`
+}
+
+type sportradarStandingsTable struct {
+	Type   string                     `json:"type"` // "total", "home" or "away"
+	Groups []sportradarStandingsGroup `json:"groups"`
+}
+
+type sportradarStandingsGroup struct {
+	Name      string                  `json:"name"`
+	Standings []sportradarStandingRow `json:"standings"`
+}
+
+type sportradarStandingRow struct {
+	Rank         int32                `json:"rank"`
+	Competitor   SportradarCompetitor `json:"competitor"`
+	Played       int32                `json:"played"`
+	Win          int32                `json:"win"`
+	Draw         int32                `json:"draw"`
+	Loss         int32                `json:"loss"`
+	GoalsFor     int32                `json:"goals_for"`
+	GoalsAgainst int32                `json:"goals_against"`
+	Points       int32                `json:"points"`
+}
+
+// SportradarCompetitor is a team taking part in a match.
+type SportradarCompetitor struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Qualifier string `json:"qualifier"` // "home" or "away"
+}
+
+// SportradarTeamStatistics holds the statistics of one team.
+type SportradarTeamStatistics struct {
+	Qualifier  string               `json:"qualifier"` // "home" or "away"
+	Statistics SportradarStatistics `json:"statistics"`
+}
+
+// SportradarStatistics holds the per-team totals we map onto repository.Match.
+type SportradarStatistics struct {
+	BallPossession int32 `json:"ball_possession"`
+	ShotsTotal     int32 `json:"shots_total"`
+	Fouls          int32 `json:"fouls"`
+	YellowCards    int32 `json:"yellow_cards"`
+	RedCards       int32 `json:"red_cards"`
+}
+
+// SportradarTimelineEvent is a single entry of the match timeline.
+type SportradarTimelineEvent struct {
+	ID           int64                   `json:"id"`
+	Type         string                  `json:"type"` // e.g., "score_change", "yellow_card", "substitution"
+	Time         string                  `json:"time"` // ISO 8601
+	MatchTime    int32                   `json:"match_time"`
+	StoppageTime int32                   `json:"stoppage_time"`
+	Competitor   string                  `json:"competitor"` // "home" or "away"
+	HomeScore    int32                   `json:"home_score"`
+	AwayScore    int32                   `json:"away_score"`
+	Players      []SportradarEventPlayer `json:"players"`
+}
+
+// SportradarEventPlayer is a player involved in a timeline event.
+type SportradarEventPlayer struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"` // e.g., "scorer", "assist", "substituted_in"
+}
+
+// FetchMatchData fetches real-time data for a specific match from Sportradar.
+// It uses the timeline endpoint, which includes everything the summary has plus
+// the events needed for cards and the last event.
 func (c *SportradarHTTPClient) FetchMatchData(ctx context.Context, matchID string) (*repository.Match, error) {
-	// Construct the actual API endpoint based on Sportradar documentation
-	// Example for a match summary:
-	// url := fmt.Sprintf("%smatches/%s/summary.json?api_key=%s", c.BaseURL, matchID, c.APIKey)
-	// For testing, let's use a dummy endpoint or in-memory simulation for now
-	// For a real setup, make an actual HTTP GET request
-	// For this example, we'll return hardcoded data as if from Sportradar
-	// In a real scenario, this would be http.Get(url) and JSON decoding.
-
-	// --- START: Real HTTP Request (uncomment and adjust for actual Sportradar API) ---
-	/*
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create Sportradar request: %w", err)
+	var srResponse SportradarMatchResponse
+	if err := c.getJSON(ctx, "sport_events/"+url.PathEscape(matchID)+"/timeline.json", &srResponse); err != nil {
+		return nil, err
+	}
+	return srResponse.toMatch(), nil
+}
+
+// FetchLiveMatches fetches every match currently in play.
+func (c *SportradarHTTPClient) FetchLiveMatches(ctx context.Context) ([]*repository.Match, error) {
+	var srResponse sportradarSummariesResponse
+	if err := c.getJSON(ctx, "schedules/live/summaries.json", &srResponse); err != nil {
+		return nil, err
+	}
+	return srResponse.toMatches(), nil
+}
+
+// FetchSchedule fetches every match scheduled on the given day.
+func (c *SportradarHTTPClient) FetchSchedule(ctx context.Context, date time.Time) ([]*repository.Match, error) {
+	var srResponse sportradarSummariesResponse
+	if err := c.getJSON(ctx, "schedules/"+date.UTC().Format("2006-01-02")+"/summaries.json", &srResponse); err != nil {
+		return nil, err
+	}
+	return srResponse.toMatches(), nil
+}
+
+// FetchMatchTimeline fetches the events of a match.
+func (c *SportradarHTTPClient) FetchMatchTimeline(ctx context.Context, matchID string) ([]*repository.Event, error) {
+	var srResponse SportradarMatchResponse
+	if err := c.getJSON(ctx, "sport_events/"+url.PathEscape(matchID)+"/timeline.json", &srResponse); err != nil {
+		return nil, err
+	}
+	events := make([]*repository.Event, 0, len(srResponse.Timeline))
+	for i := range srResponse.Timeline {
+		events = append(events, srResponse.Timeline[i].toEvent(matchID))
+	}
+	return events, nil
+}
+
+// FetchLineups fetches the lineups of a match. Sportradar publishes them about an hour before kick-off.
+func (c *SportradarHTTPClient) FetchLineups(ctx context.Context, matchID string) (*Lineups, error) {
+	var srResponse sportradarLineupsResponse
+	if err := c.getJSON(ctx, "sport_events/"+url.PathEscape(matchID)+"/lineups.json", &srResponse); err != nil {
+		return nil, err
+	}
+	lineups := &Lineups{MatchID: matchID}
+	for _, competitor := range srResponse.Lineups.Competitors {
+		team := competitor.toTeamLineup()
+		switch competitor.Qualifier {
+		case "home":
+			lineups.Home = team
+		case "away":
+			lineups.Away = team
 		}
+	}
+	return lineups, nil
+}
 
-		resp, err := c.HTTPClient.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("failed to make Sportradar API request: %w", err)
+// FetchStandings fetches the league table. Soccer v4 keys standings by season,
+// so competitionID is expected to be a season ID (e.g. "sr:season:118689").
+func (c *SportradarHTTPClient) FetchStandings(ctx context.Context, competitionID string) ([]*Standing, error) {
+	var srResponse sportradarStandingsResponse
+	if err := c.getJSON(ctx, "seasons/"+url.PathEscape(competitionID)+"/standings.json", &srResponse); err != nil {
+		return nil, err
+	}
+	var standings []*Standing
+	for _, table := range srResponse.Standings {
+		if table.Type != "total" { // Skip the home/away tables
+			continue
+		}
+		for _, group := range table.Groups {
+			for _, row := range group.Standings {
+				standings = append(standings, &Standing{
+					Group:        group.Name,
+					Rank:         row.Rank,
+					TeamID:       row.Competitor.ID,
+					TeamName:     row.Competitor.Name,
+					Played:       row.Played,
+					Won:          row.Win,
+					Drawn:        row.Draw,
+					Lost:         row.Loss,
+					GoalsFor:     row.GoalsFor,
+					GoalsAgainst: row.GoalsAgainst,
+					Points:       row.Points,
+				})
+			}
 		}
-		defer resp.Body.Close()
+	}
+	return standings, nil
+}
+
+// getJSON performs a GET request against path (relative to BaseURL) and decodes the JSON body into out.
+// Non-200 responses are returned as *APIError.
+func (c *SportradarHTTPClient) getJSON(ctx context.Context, path string, out interface{}) error {
+	endpoint, err := url.Parse(strings.TrimSuffix(c.BaseURL, "/") + "/" + path)
+	if err != nil {
+		return fmt.Errorf("failed to build Sportradar URL: %w", err)
+	}
+	query := endpoint.Query()
+	query.Set("api_key", c.APIKey)
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create Sportradar request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
 
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("Sportradar API returned status code %d: %s", resp.StatusCode, resp.Status)
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		// Don't wrap the *url.Error directly, its message contains the API key.
+		return fmt.Errorf("failed to make Sportradar API request to %s: %w", path, unwrapURLError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode Sportradar API response: %w", err)
+	}
+	return nil
+}
+
+func unwrapURLError(err error) error {
+	if urlErr, ok := err.(*url.Error); ok {
+		return urlErr.Err
+	}
+	return err
+}
+
+// utcStartTime reformats Sportradar's start times ("2024-08-18T19:00:00+00:00") in UTC,
+// so start times in the DB can be compared as strings. Unparseable values are kept as is.
+func utcStartTime(value string) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// toMatch transforms Sportradar's response into our internal repository.Match format.
+func (r *SportradarMatchResponse) toMatch() *repository.Match {
+	match := &repository.Match{
+		MatchID:     r.SportEvent.ID,
+		StartTime:   utcStartTime(r.SportEvent.StartTime),
+		Sport:       strings.ToLower(r.SportEvent.SportEventContext.Sport.Name),
+		Competition: r.SportEvent.SportEventContext.Competition.Name,
+		Status:      r.status(),
+		HomeScore:   r.SportEventStatus.HomeScore,
+		AwayScore:   r.SportEventStatus.AwayScore,
+		Cards:       []string{},
+	}
+	for _, competitor := range r.SportEvent.Competitors {
+		switch competitor.Qualifier {
+		case "home":
+			match.HomeTeam = competitor.Name
+		case "away":
+			match.AwayTeam = competitor.Name
 		}
+	}
 
-		var srResponse SportradarMatchResponse
-		if err := json.NewDecoder(resp.Body).Decode(&srResponse); err != nil {
-			return nil, fmt.Errorf("failed to decode Sportradar API response: %w", err)
+	for _, competitor := range r.Statistics.Totals.Competitors {
+		stats := competitor.Statistics
+		if competitor.Qualifier == "home" {
+			match.Possession = stats.BallPossession // Possession is stored from the home team's point of view
 		}
+		match.Shots += stats.ShotsTotal
+		match.Fouls += stats.Fouls
+	}
 
-		// Transform Sportradar's response into your internal repository.Match format
-		// This mapping is crucial and will be complex based on the real API structure
-		repoMatch := &repository.Match{
-			MatchID:   srResponse.Match.ID,
-			Status:    srResponse.Match.Status,
-			HomeTeam:  srResponse.Match.HomeTeam.Name,
-			AwayTeam:  srResponse.Match.AwayTeam.Name,
-			HomeScore: srResponse.Match.HomeTeam.Score,
-			AwayScore: srResponse.Match.AwayTeam.Score,
-			// ... map other stats and fields
-			Possession: srResponse.Match.Statistics.PossessionHome, // Simplistic: assuming Home possession is the one stored
-			Shots:      srResponse.Match.Statistics.ShotsHome + srResponse.Match.Statistics.ShotsAway,
-			Fouls:      srResponse.Match.Statistics.FoulsHome + srResponse.Match.Statistics.FoulsAway,
-			// Cards, LastEvent would come from event streams/summaries, more complex parsing needed
-			Cards:      []string{}, // Placeholder
-			LastEvent:  "Data from Sportradar", // Placeholder
+	for _, event := range r.Timeline {
+		if color := cardColor(event.Type); color != "" {
+			match.Cards = append(match.Cards, fmt.Sprintf("%s_%s", color, event.playerName()))
 		}
-		return repoMatch, nil
-	*/
-	// --- END: Real HTTP Request ---
-
-	// --- START: Temporary Hardcoded Mock Data for Testing ---
-	// REMOVE THIS BLOCK ONCE YOU INTEGRATE THE REAL HTTP REQUEST ABOVE
-	// This simulates a changing score from Sportradar
-	currentScoreHome := time.Now().Second() % 3       // Example: 0, 1, 2
-	currentScoreAway := (time.Now().Second() + 1) % 2 // Example: 0, 1
-
-	return &repository.Match{
-		MatchID:    matchID,
-		Status:     "live",
-		HomeTeam:   "Real Madrid",
-		AwayTeam:   "Barcelona",
-		HomeScore:  int32(currentScoreHome),
-		AwayScore:  int32(currentScoreAway),
-		LastEvent:  fmt.Sprintf("Score updated at %s (mock SR)", time.Now().Format("15:04:05")),
-		Possession: int32(50 + (time.Now().Second() % 10)), // Dynamic possession
-		Shots:      int32(10 + (time.Now().Second() % 5)),
-		Fouls:      int32(5 + (time.Now().Second() % 3)),
-		Cards:      []string{},
-	}, nil
-	// --- END: Temporary Hardcoded Mock Data ---
+	}
+	if len(r.Timeline) > 0 {
+		match.LastEvent = r.Timeline[len(r.Timeline)-1].describe()
+	} else {
+		match.LastEvent = fmt.Sprintf("STATUS: %s", match.Status)
+	}
+	return match
+}
+
+// status prefers the detailed match_status ("1st_half", "halftime") while a match is live.
+func (r *SportradarMatchResponse) status() string {
+	if r.SportEventStatus.Status == "live" && r.SportEventStatus.MatchStatus != "" {
+		return r.SportEventStatus.MatchStatus
+	}
+	return r.SportEventStatus.Status
+}
+
+// cardColor maps a timeline event type to the card color used in repository.Match.Cards,
+// or "" if the event is not a card.
+func cardColor(eventType string) string {
+	switch eventType {
+	case "yellow_card":
+		return "yellow"
+	case "red_card", "yellow_red_card":
+		return "red"
+	}
+	return ""
+}
+
+func (e *SportradarTimelineEvent) playerName() string {
+	if len(e.Players) > 0 {
+		return e.Players[0].Name
+	}
+	return e.Competitor
+}
+
+// toEvent converts a timeline entry into the event format stored in the events collection.
+func (e *SportradarTimelineEvent) toEvent(matchID string) *repository.Event {
+	eventType := e.Type
+	switch {
+	case e.Type == "score_change":
+		eventType = "goal"
+	case cardColor(e.Type) != "":
+		eventType = "card"
+	}
+	return &repository.Event{
+		EventID:     fmt.Sprintf("sr-%s-%d", matchID, e.ID),
+		MatchID:     matchID,
+		EventType:   eventType,
+		Description: e.describe(),
+		Timestamp:   e.Time,
+	}
+}
+
+// describe renders the event in the same style as admin-submitted events.
+func (e *SportradarTimelineEvent) describe() string {
+	minute := fmt.Sprintf("%d'", e.MatchTime)
+	if e.StoppageTime > 0 {
+		minute = fmt.Sprintf("%d+%d'", e.MatchTime, e.StoppageTime)
+	}
+	switch e.Type {
+	case "score_change":
+		return fmt.Sprintf("GOAL! %s (%s) %d-%d", e.playerName(), minute, e.HomeScore, e.AwayScore)
+	case "yellow_card", "red_card", "yellow_red_card":
+		return fmt.Sprintf("%s CARD: %s (%s)", cardColor(e.Type), e.playerName(), minute)
+	case "substitution":
+		return fmt.Sprintf("SUBSTITUTION: %s (%s)", e.Competitor, minute)
+	default:
+		return fmt.Sprintf("%s (%s)", strings.ToUpper(strings.ReplaceAll(e.Type, "_", " ")), minute)
+	}
 }
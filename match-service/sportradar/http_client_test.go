@@ -0,0 +1,193 @@
+package sportradar
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+)
+
+const testAPIKey = "test-key"
+
+// newFixtureServer serves recorded responses from testdata by request path, checking the API key.
+func newFixtureServer(t *testing.T, fixtures map[string]string) *SportradarHTTPClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := r.URL.Query().Get("api_key"); key != testAPIKey {
+			t.Errorf("request to %s with api_key %q, want %q", r.URL.Path, key, testAPIKey)
+		}
+		fixture, ok := fixtures[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		data, err := os.ReadFile("testdata/" + fixture)
+		if err != nil {
+			t.Errorf("reading fixture: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return NewSportradarHTTPClient(server.URL+"/soccer/trial/v4/en/", testAPIKey)
+}
+
+func TestFetchMatchData(t *testing.T) {
+	client := newFixtureServer(t, map[string]string{
+		"/soccer/trial/v4/en/sport_events/sr:sport_event:41762701/timeline.json": "timeline.json",
+	})
+
+	match, err := client.FetchMatchData(context.Background(), "sr:sport_event:41762701")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &repository.Match{
+		MatchID:     "sr:sport_event:41762701",
+		HomeTeam:    "Manchester City",
+		AwayTeam:    "Manchester United",
+		StartTime:   "2024-05-25T16:00:00Z",
+		Sport:       "soccer",
+		Competition: "FA Cup",
+		Status:      "2nd_extra",
+		HomeScore:   2,
+		AwayScore:   2,
+		LastEvent:   "SUBSTITUTION: home (108')",
+		Possession:  61,
+		Shots:       27,
+		Fouls:       23,
+		Cards:       []string{"yellow_Rodri", "yellow_Casemiro", "red_Casemiro"},
+	}
+	if !reflect.DeepEqual(match, want) {
+		t.Errorf("FetchMatchData() =\n%+v\nwant\n%+v", match, want)
+	}
+}
+
+func TestFetchSchedule(t *testing.T) {
+	client := newFixtureServer(t, map[string]string{
+		"/soccer/trial/v4/en/schedules/2024-08-18/summaries.json": "schedule.json",
+	})
+
+	day := time.Date(2024, 8, 18, 23, 30, 0, 0, time.FixedZone("CEST", 2*60*60)) // Still the 18th in UTC
+	matches, err := client.FetchSchedule(context.Background(), day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*repository.Match{
+		{
+			MatchID:     "sr:sport_event:50850011",
+			HomeTeam:    "Real Madrid",
+			AwayTeam:    "Osasuna",
+			StartTime:   "2024-08-18T15:30:00Z",
+			Sport:       "soccer",
+			Competition: "LaLiga",
+			Status:      "closed",
+			HomeScore:   2,
+			LastEvent:   "STATUS: closed",
+			Possession:  58,
+			Shots:       23,
+			Fouls:       26,
+			Cards:       []string{},
+		},
+		{
+			MatchID:     "sr:sport_event:50850017",
+			HomeTeam:    "FC Barcelona",
+			AwayTeam:    "Athletic Bilbao",
+			StartTime:   "2024-08-18T19:30:00Z",
+			Sport:       "soccer",
+			Competition: "LaLiga",
+			Status:      "not_started",
+			LastEvent:   "STATUS: not_started",
+			Cards:       []string{},
+		},
+	}
+	if !reflect.DeepEqual(matches, want) {
+		t.Errorf("FetchSchedule() =\n%+v\nwant\n%+v", matches, want)
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	for _, tc := range []struct {
+		code       int
+		retryAfter string
+		want       error
+		wantRetry  time.Duration
+	}{
+		{code: http.StatusUnauthorized, want: ErrUnauthorized},
+		{code: http.StatusForbidden, want: ErrForbidden},
+		{code: http.StatusNotFound, want: ErrNotFound},
+		{code: http.StatusTooManyRequests, retryAfter: "30", want: ErrRateLimited, wantRetry: 30 * time.Second},
+		{code: http.StatusInternalServerError, want: ErrServerError},
+		{code: http.StatusBadGateway, want: ErrServerError},
+		{code: http.StatusServiceUnavailable, retryAfter: "5", want: ErrServerError, wantRetry: 5 * time.Second},
+	} {
+		t.Run(http.StatusText(tc.code), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tc.retryAfter != "" {
+					w.Header().Set("Retry-After", tc.retryAfter)
+				}
+				http.Error(w, `{"message": "error"}`, tc.code)
+			}))
+			defer server.Close()
+			client := NewSportradarHTTPClient(server.URL, testAPIKey)
+
+			_, err := client.FetchMatchData(context.Background(), "sr:sport_event:1")
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("error = %v, want %v", err, tc.want)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error = %v, want an *APIError", err)
+			}
+			if apiErr.StatusCode != tc.code || apiErr.RetryAfter != tc.wantRetry {
+				t.Errorf("APIError{StatusCode: %d, RetryAfter: %v}, want {%d, %v}", apiErr.StatusCode, apiErr.RetryAfter, tc.code, tc.wantRetry)
+			}
+			if strings.Contains(err.Error(), testAPIKey) {
+				t.Errorf("error %q contains the API key", err)
+			}
+		})
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"sport_event": {"id": "sr:sport_event:1", "start_time": `)) // Cut off
+	}))
+	defer server.Close()
+	client := NewSportradarHTTPClient(server.URL, testAPIKey)
+
+	_, err := client.FetchMatchData(context.Background(), "sr:sport_event:1")
+	var apiErr *APIError
+	if err == nil || errors.As(err, &apiErr) || !strings.Contains(err.Error(), "decode") {
+		t.Fatalf("error = %v, want a decode error", err)
+	}
+}
+
+// TestStatusExtraTime checks matches going to extra time and penalties stay active, so the
+// poller keeps following them after 90 minutes.
+func TestStatusExtraTime(t *testing.T) {
+	for _, matchStatus := range []string{
+		"1st_half", "halftime", "2nd_half", "awaiting_extra", "1st_extra", "extra_time_halftime",
+		"2nd_extra", "awaiting_penalties", "penalties", "pause", "interrupted",
+	} {
+		var r SportradarMatchResponse
+		r.SportEventStatus.Status = "live"
+		r.SportEventStatus.MatchStatus = matchStatus
+		status := r.status()
+		if status != matchStatus {
+			t.Errorf("status() of a live match in %s = %q", matchStatus, status)
+		}
+		if repository.IsFinishedStatus(status) || !slices.Contains(repository.ActiveStatuses, status) {
+			t.Errorf("status %q is not considered active", status)
+		}
+	}
+}
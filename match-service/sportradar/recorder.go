@@ -0,0 +1,321 @@
+package sportradar
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+)
+
+// Recording is a single provider call, written as one JSON line by RecordingClient
+// and played back by ReplayClient.
+type Recording struct {
+	Time      time.Time       `json:"time"`
+	Method    string          `json:"method"` // SportradarClientI method, e.g. "FetchMatchData"
+	Key       string          `json:"key"`    // The call's argument: match ID, date or competition ID
+	Response  json.RawMessage `json:"response,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	ErrorKind string          `json:"error_kind,omitempty"` // Sentinel error, so replays keep errors.Is working
+}
+
+// Sentinel errors by name, for recording and replaying typed errors.
+var errorKinds = map[string]error{
+	"unauthorized":    ErrUnauthorized,
+	"forbidden":       ErrForbidden,
+	"not_found":       ErrNotFound,
+	"rate_limited":    ErrRateLimited,
+	"server_error":    ErrServerError,
+	"circuit_open":    ErrCircuitOpen,
+	"quota_exhausted": ErrQuotaExhausted,
+}
+
+func errorKind(err error) string {
+	for kind, sentinel := range errorKinds {
+		if errors.Is(err, sentinel) {
+			return kind
+		}
+	}
+	return ""
+}
+
+// RecordingClient wraps another SportradarClientI and writes every response, with
+// the time it was received, to a JSON lines file. Use it in production to capture
+// incidents and ReplayClient to reproduce them locally.
+type RecordingClient struct {
+	next   SportradarClientI
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+	now    func() time.Time
+}
+
+// NewRecordingClient records every call made through next to w.
+func NewRecordingClient(next SportradarClientI, w io.Writer) *RecordingClient {
+	return &RecordingClient{next: next, w: w, now: time.Now}
+}
+
+// NewRecordingFileClient records every call made through next to the file at path.
+// Recordings are appended, so restarts don't lose earlier data. Call Close when done.
+func NewRecordingFileClient(next SportradarClientI, path string) (*RecordingClient, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Sportradar recording file: %w", err)
+	}
+	c := NewRecordingClient(next, f)
+	c.closer = f
+	return c, nil
+}
+
+// Close closes the recording file, if the client owns one.
+func (c *RecordingClient) Close() error {
+	if c.closer == nil {
+		return nil
+	}
+	return c.closer.Close()
+}
+
+// FetchMatchData implements SportradarClientI.
+func (c *RecordingClient) FetchMatchData(ctx context.Context, matchID string) (*repository.Match, error) {
+	match, err := c.next.FetchMatchData(ctx, matchID)
+	c.record("FetchMatchData", matchID, match, err)
+	return match, err
+}
+
+// FetchLiveMatches implements SportradarClientI.
+func (c *RecordingClient) FetchLiveMatches(ctx context.Context) ([]*repository.Match, error) {
+	matches, err := c.next.FetchLiveMatches(ctx)
+	c.record("FetchLiveMatches", "", matches, err)
+	return matches, err
+}
+
+// FetchSchedule implements SportradarClientI.
+func (c *RecordingClient) FetchSchedule(ctx context.Context, date time.Time) ([]*repository.Match, error) {
+	matches, err := c.next.FetchSchedule(ctx, date)
+	c.record("FetchSchedule", scheduleKey(date), matches, err)
+	return matches, err
+}
+
+// FetchMatchTimeline implements SportradarClientI.
+func (c *RecordingClient) FetchMatchTimeline(ctx context.Context, matchID string) ([]*repository.Event, error) {
+	events, err := c.next.FetchMatchTimeline(ctx, matchID)
+	c.record("FetchMatchTimeline", matchID, events, err)
+	return events, err
+}
+
+// FetchLineups implements SportradarClientI.
+func (c *RecordingClient) FetchLineups(ctx context.Context, matchID string) (*Lineups, error) {
+	lineups, err := c.next.FetchLineups(ctx, matchID)
+	c.record("FetchLineups", matchID, lineups, err)
+	return lineups, err
+}
+
+// FetchStandings implements SportradarClientI.
+func (c *RecordingClient) FetchStandings(ctx context.Context, competitionID string) ([]*Standing, error) {
+	standings, err := c.next.FetchStandings(ctx, competitionID)
+	c.record("FetchStandings", competitionID, standings, err)
+	return standings, err
+}
+
+// record writes a single call. Recording failures are logged, never returned,
+// so a full disk can't take down polling.
+func (c *RecordingClient) record(method, key string, response interface{}, err error) {
+	rec := Recording{Time: c.now(), Method: method, Key: key}
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return // Our own timeouts are not provider behaviour
+		}
+		rec.Error = err.Error()
+		rec.ErrorKind = errorKind(err)
+	} else {
+		body, marshalErr := json.Marshal(response)
+		if marshalErr != nil {
+			fmt.Printf("Warning: Failed to marshal Sportradar response for recording: %v\n", marshalErr)
+			return
+		}
+		rec.Response = body
+	}
+
+	line, marshalErr := json.Marshal(rec)
+	if marshalErr != nil {
+		fmt.Printf("Warning: Failed to marshal Sportradar recording: %v\n", marshalErr)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, writeErr := c.w.Write(append(line, '\n')); writeErr != nil {
+		fmt.Printf("Warning: Failed to write Sportradar recording: %v\n", writeErr)
+	}
+}
+
+// ReplayClient plays back a recording made by RecordingClient. The recording's timeline
+// starts when the ReplayClient is created and runs at the configured speed (1 for real
+// time, 60 to play a minute per second). Every call returns the latest response recorded
+// for the same method and argument at the current replay position.
+type ReplayClient struct {
+	recordings map[string][]Recording // Keyed by method and key, sorted by time
+	start      time.Time              // Time of the first recording
+	end        time.Time              // Time of the last recording
+	speed      float64
+
+	mu          sync.Mutex
+	now         func() time.Time
+	replayStart time.Time
+}
+
+// NewReplayClient reads recordings from r. speed must be positive.
+func NewReplayClient(r io.Reader, speed float64) (*ReplayClient, error) {
+	if speed <= 0 {
+		return nil, fmt.Errorf("replay speed must be positive, got %v", speed)
+	}
+	c := &ReplayClient{
+		recordings: make(map[string][]Recording),
+		speed:      speed,
+		now:        time.Now,
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024) // Timelines can get large
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec Recording
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("failed to decode recording on line %d: %w", line, err)
+		}
+		if c.start.IsZero() || rec.Time.Before(c.start) {
+			c.start = rec.Time
+		}
+		if rec.Time.After(c.end) {
+			c.end = rec.Time
+		}
+		key := replayKey(rec.Method, rec.Key)
+		c.recordings[key] = append(c.recordings[key], rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	for _, recs := range c.recordings {
+		sort.SliceStable(recs, func(i, j int) bool { return recs[i].Time.Before(recs[j].Time) })
+	}
+	c.replayStart = c.now()
+	return c, nil
+}
+
+// LoadReplayFile reads the recording at path, see NewReplayClient.
+func LoadReplayFile(path string, speed float64) (*ReplayClient, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Sportradar recording: %w", err)
+	}
+	defer f.Close()
+	return NewReplayClient(f, speed)
+}
+
+// SetClock replaces the clock driving the replay and restarts it. Meant for tests.
+func (c *ReplayClient) SetClock(now func() time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+	c.replayStart = now()
+}
+
+// Position returns the recorded time the replay has reached.
+func (c *ReplayClient) Position() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elapsed := time.Duration(float64(c.now().Sub(c.replayStart)) * c.speed)
+	return c.start.Add(elapsed)
+}
+
+// Done reports whether the replay has passed the last recording.
+func (c *ReplayClient) Done() bool {
+	return c.Position().After(c.end)
+}
+
+// FetchMatchData implements SportradarClientI.
+func (c *ReplayClient) FetchMatchData(ctx context.Context, matchID string) (*repository.Match, error) {
+	var match *repository.Match
+	err := c.replay("FetchMatchData", matchID, &match)
+	return match, err
+}
+
+// FetchLiveMatches implements SportradarClientI.
+func (c *ReplayClient) FetchLiveMatches(ctx context.Context) ([]*repository.Match, error) {
+	var matches []*repository.Match
+	err := c.replay("FetchLiveMatches", "", &matches)
+	return matches, err
+}
+
+// FetchSchedule implements SportradarClientI.
+func (c *ReplayClient) FetchSchedule(ctx context.Context, date time.Time) ([]*repository.Match, error) {
+	var matches []*repository.Match
+	err := c.replay("FetchSchedule", scheduleKey(date), &matches)
+	return matches, err
+}
+
+// FetchMatchTimeline implements SportradarClientI.
+func (c *ReplayClient) FetchMatchTimeline(ctx context.Context, matchID string) ([]*repository.Event, error) {
+	var events []*repository.Event
+	err := c.replay("FetchMatchTimeline", matchID, &events)
+	return events, err
+}
+
+// FetchLineups implements SportradarClientI.
+func (c *ReplayClient) FetchLineups(ctx context.Context, matchID string) (*Lineups, error) {
+	var lineups *Lineups
+	err := c.replay("FetchLineups", matchID, &lineups)
+	return lineups, err
+}
+
+// FetchStandings implements SportradarClientI.
+func (c *ReplayClient) FetchStandings(ctx context.Context, competitionID string) ([]*Standing, error) {
+	var standings []*Standing
+	err := c.replay("FetchStandings", competitionID, &standings)
+	return standings, err
+}
+
+// replay decodes the latest recording for method and key at the current position into out.
+// Before the first recording of a call it behaves as if Sportradar didn't know about it yet.
+func (c *ReplayClient) replay(method, key string, out interface{}) error {
+	recs := c.recordings[replayKey(method, key)]
+	position := c.Position()
+	i := sort.Search(len(recs), func(i int) bool { return recs[i].Time.After(position) })
+	if i == 0 {
+		return fmt.Errorf("no recorded %s for %q at %s: %w", method, key, position.Format(time.RFC3339), ErrNotFound)
+	}
+
+	rec := recs[i-1]
+	if rec.Error != "" {
+		return &replayedError{msg: rec.Error, kind: errorKinds[rec.ErrorKind]}
+	}
+	if err := json.Unmarshal(rec.Response, out); err != nil {
+		return fmt.Errorf("failed to decode recorded %s response: %w", method, err)
+	}
+	return nil
+}
+
+// replayedError reproduces a recorded error, including its sentinel for errors.Is.
+type replayedError struct {
+	msg  string
+	kind error
+}
+
+func (e *replayedError) Error() string { return e.msg }
+func (e *replayedError) Unwrap() error { return e.kind }
+
+func replayKey(method, key string) string {
+	return method + "|" + key
+}
+
+func scheduleKey(date time.Time) string {
+	return date.UTC().Format("2006-01-02")
+}
@@ -0,0 +1,393 @@
+package sportradar
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+)
+
+var (
+	// ErrCircuitOpen is returned without contacting Sportradar while the circuit breaker
+	// considers the provider down. Callers should fall back to DB data.
+	ErrCircuitOpen = errors.New("sportradar: circuit breaker open, provider considered down")
+	// ErrQuotaExhausted is returned once the configured daily request quota is used up.
+	ErrQuotaExhausted = errors.New("sportradar: daily request quota exhausted")
+)
+
+// BreakerState is the state of the ResilientClient circuit breaker.
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"    // Requests flow normally
+	BreakerOpen     BreakerState = "open"      // Requests fail fast with ErrCircuitOpen
+	BreakerHalfOpen BreakerState = "half_open" // A single probe request decides whether to close again
+)
+
+// ResilientConfig configures rate limiting, retries and the circuit breaker of a ResilientClient.
+type ResilientConfig struct {
+	RequestsPerSecond float64       // Token bucket refill rate, 0 or less for no rate limit
+	Burst             int           // Token bucket size
+	DailyQuota        int           // Requests allowed per UTC day, 0 for unlimited
+	MaxRetries        int           // Retries after the first attempt for retryable errors
+	BaseBackoff       time.Duration // Backoff before the first retry, doubled on every retry
+	MaxBackoff        time.Duration
+	FailureThreshold  int           // Consecutive failed calls that open the breaker
+	OpenTimeout       time.Duration // How long the breaker stays open before probing again
+}
+
+// DefaultResilientConfig returns the rate limit of a Sportradar trial key, without a daily
+// quota: set DailyQuota to that of the key in use, see config.SportradarDailyQuota.
+func DefaultResilientConfig() ResilientConfig {
+	return ResilientConfig{
+		RequestsPerSecond: 1,
+		Burst:             1,
+		MaxRetries:        2,
+		BaseBackoff:       200 * time.Millisecond,
+		MaxBackoff:        5 * time.Second,
+		FailureThreshold:  5,
+		OpenTimeout:       30 * time.Second,
+	}
+}
+
+// ClientStats is a snapshot of the ResilientClient state, for debugging and dashboards.
+type ClientStats struct {
+	BreakerState        BreakerState `json:"breaker_state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	BreakerOpenedAt     time.Time    `json:"breaker_opened_at"`
+	TokensAvailable     float64      `json:"tokens_available"`
+	QuotaDay            string       `json:"quota_day"`
+	QuotaUsed           int          `json:"quota_used"`
+	DailyQuota          int          `json:"daily_quota"`
+	Requests            int64        `json:"requests"`            // Requests sent to the provider
+	Retries             int64        `json:"retries"`             // Of which retries
+	RejectedByBreaker   int64        `json:"rejected_by_breaker"` // Calls failed fast while open
+}
+
+// ResilientClient wraps another SportradarClientI with token-bucket rate limiting,
+// a daily quota, retries with exponential backoff and jitter (honouring Retry-After),
+// and a circuit breaker.
+type ResilientClient struct {
+	next SportradarClientI
+	cfg  ResilientConfig
+
+	mu         sync.Mutex
+	tokens     float64
+	lastRefill time.Time
+	stats      ClientStats
+	probing    bool // A half-open probe is in flight
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+	rand  *rand.Rand
+}
+
+// NewResilientClient wraps next with the limits in cfg.
+func NewResilientClient(next SportradarClientI, cfg ResilientConfig) *ResilientClient {
+	if cfg.Burst < 1 {
+		cfg.Burst = 1
+	}
+	if !(cfg.RequestsPerSecond > 0) { // Also catches NaN
+		cfg.RequestsPerSecond = 0
+	}
+	return &ResilientClient{
+		next:       next,
+		cfg:        cfg,
+		tokens:     float64(cfg.Burst),
+		lastRefill: time.Now(),
+		stats:      ClientStats{BreakerState: BreakerClosed, DailyQuota: cfg.DailyQuota},
+		now:        time.Now,
+		sleep:      sleepContext,
+		rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// FetchMatchData implements SportradarClientI.
+func (c *ResilientClient) FetchMatchData(ctx context.Context, matchID string) (*repository.Match, error) {
+	var match *repository.Match
+	err := c.do(ctx, func(ctx context.Context) error {
+		var err error
+		match, err = c.next.FetchMatchData(ctx, matchID)
+		return err
+	})
+	return match, err
+}
+
+// FetchLiveMatches implements SportradarClientI.
+func (c *ResilientClient) FetchLiveMatches(ctx context.Context) ([]*repository.Match, error) {
+	var matches []*repository.Match
+	err := c.do(ctx, func(ctx context.Context) error {
+		var err error
+		matches, err = c.next.FetchLiveMatches(ctx)
+		return err
+	})
+	return matches, err
+}
+
+// FetchSchedule implements SportradarClientI.
+func (c *ResilientClient) FetchSchedule(ctx context.Context, date time.Time) ([]*repository.Match, error) {
+	var matches []*repository.Match
+	err := c.do(ctx, func(ctx context.Context) error {
+		var err error
+		matches, err = c.next.FetchSchedule(ctx, date)
+		return err
+	})
+	return matches, err
+}
+
+// FetchMatchTimeline implements SportradarClientI.
+func (c *ResilientClient) FetchMatchTimeline(ctx context.Context, matchID string) ([]*repository.Event, error) {
+	var events []*repository.Event
+	err := c.do(ctx, func(ctx context.Context) error {
+		var err error
+		events, err = c.next.FetchMatchTimeline(ctx, matchID)
+		return err
+	})
+	return events, err
+}
+
+// FetchLineups implements SportradarClientI.
+func (c *ResilientClient) FetchLineups(ctx context.Context, matchID string) (*Lineups, error) {
+	var lineups *Lineups
+	err := c.do(ctx, func(ctx context.Context) error {
+		var err error
+		lineups, err = c.next.FetchLineups(ctx, matchID)
+		return err
+	})
+	return lineups, err
+}
+
+// FetchStandings implements SportradarClientI.
+func (c *ResilientClient) FetchStandings(ctx context.Context, competitionID string) ([]*Standing, error) {
+	var standings []*Standing
+	err := c.do(ctx, func(ctx context.Context) error {
+		var err error
+		standings, err = c.next.FetchStandings(ctx, competitionID)
+		return err
+	})
+	return standings, err
+}
+
+// Stats returns a snapshot of breaker state and quota use.
+func (c *ResilientClient) Stats() ClientStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refillLocked()
+	c.rollQuotaLocked()
+	stats := c.stats
+	stats.TokensAvailable = c.tokens
+	return stats
+}
+
+// StatsHandler serves Stats as JSON, e.g. on /debug/sportradar of the internal debug listener.
+func (c *ResilientClient) StatsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(c.Stats()); err != nil {
+		log.Printf("Error encoding Sportradar client stats: %v", err)
+	}
+}
+
+// do runs call through the breaker, rate limiter and retry loop.
+func (c *ResilientClient) do(ctx context.Context, call func(ctx context.Context) error) error {
+	if err := c.allow(); err != nil {
+		return err
+	}
+
+	var err error
+	for attempt := 0; attempt <= c.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if waitErr := c.sleep(ctx, c.backoff(attempt, err)); waitErr != nil {
+				break
+			}
+		}
+		if acquireErr := c.acquire(ctx, attempt > 0); acquireErr != nil {
+			if attempt == 0 {
+				// Throttled locally: nothing was learned about the provider's health.
+				c.abandon()
+				return acquireErr
+			}
+			break // Report the last provider error
+		}
+		err = call(ctx)
+		if err == nil || ctx.Err() != nil || !isRetryable(err) {
+			break
+		}
+	}
+
+	if errors.Is(err, context.Canceled) {
+		// The caller went away before the provider answered, which says nothing about its
+		// health. A half-open breaker stays half-open for the next probe.
+		c.abandon()
+		return err
+	}
+	c.record(err)
+	return err
+}
+
+// allow checks the circuit breaker before a call.
+func (c *ResilientClient) allow() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.stats.BreakerState {
+	case BreakerOpen:
+		if c.now().Sub(c.stats.BreakerOpenedAt) < c.cfg.OpenTimeout {
+			c.stats.RejectedByBreaker++
+			return ErrCircuitOpen
+		}
+		c.setStateLocked(BreakerHalfOpen)
+		c.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if c.probing {
+			c.stats.RejectedByBreaker++
+			return ErrCircuitOpen
+		}
+		c.probing = true
+	}
+	return nil
+}
+
+// record updates the circuit breaker with the outcome of a call.
+func (c *ResilientClient) record(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.probing = false
+	if !countsAsFailure(err) {
+		c.stats.ConsecutiveFailures = 0
+		if c.stats.BreakerState != BreakerClosed {
+			c.setStateLocked(BreakerClosed)
+		}
+		return
+	}
+
+	c.stats.ConsecutiveFailures++
+	if c.stats.BreakerState == BreakerHalfOpen || c.stats.ConsecutiveFailures >= c.cfg.FailureThreshold {
+		c.stats.BreakerOpenedAt = c.now()
+		c.setStateLocked(BreakerOpen)
+	}
+}
+
+// abandon releases a half-open probe slot without recording an outcome.
+func (c *ResilientClient) abandon() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.probing = false
+}
+
+func (c *ResilientClient) setStateLocked(state BreakerState) {
+	if c.stats.BreakerState == state {
+		return
+	}
+	log.Printf("Sportradar circuit breaker: %s -> %s (consecutive failures: %d)", c.stats.BreakerState, state, c.stats.ConsecutiveFailures)
+	c.stats.BreakerState = state
+}
+
+// acquire waits for a rate limiter token and consumes one request of the daily quota.
+func (c *ResilientClient) acquire(ctx context.Context, retry bool) error {
+	for {
+		c.mu.Lock()
+		c.refillLocked()
+		c.rollQuotaLocked()
+		if c.cfg.DailyQuota > 0 && c.stats.QuotaUsed >= c.cfg.DailyQuota {
+			c.mu.Unlock()
+			return ErrQuotaExhausted
+		}
+		if c.cfg.RequestsPerSecond == 0 || c.tokens >= 1 {
+			if c.cfg.RequestsPerSecond > 0 {
+				c.tokens--
+			}
+			c.stats.QuotaUsed++
+			c.stats.Requests++
+			if retry {
+				c.stats.Retries++
+			}
+			c.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - c.tokens) / c.cfg.RequestsPerSecond * float64(time.Second))
+		c.mu.Unlock()
+
+		if err := c.sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *ResilientClient) refillLocked() {
+	now := c.now()
+	c.tokens += now.Sub(c.lastRefill).Seconds() * c.cfg.RequestsPerSecond
+	if c.tokens > float64(c.cfg.Burst) {
+		c.tokens = float64(c.cfg.Burst)
+	}
+	c.lastRefill = now
+}
+
+// rollQuotaLocked resets the quota counter when a new UTC day starts.
+func (c *ResilientClient) rollQuotaLocked() {
+	day := c.now().UTC().Format("2006-01-02")
+	if c.stats.QuotaDay != day {
+		c.stats.QuotaDay = day
+		c.stats.QuotaUsed = 0
+	}
+}
+
+// backoff returns the wait before the given retry: exponential with full jitter,
+// but never shorter than the Retry-After the provider asked for.
+func (c *ResilientClient) backoff(attempt int, err error) time.Duration {
+	backoff := c.cfg.BaseBackoff << (attempt - 1)
+	if backoff > c.cfg.MaxBackoff || backoff <= 0 {
+		backoff = c.cfg.MaxBackoff
+	}
+	c.mu.Lock()
+	wait := time.Duration(c.rand.Int63n(int64(backoff) + 1))
+	c.mu.Unlock()
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > wait {
+		wait = apiErr.RetryAfter
+	}
+	return wait
+}
+
+// isRetryable reports whether a failed request might succeed if tried again: network errors
+// and timeouts, rate limiting and server errors. Anything else, like a response that can't be
+// decoded, would fail the same way again and only use up quota.
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServerError)
+}
+
+// countsAsFailure reports whether err means the provider is unhealthy. A 404 for an
+// unknown match says nothing about the provider. Cancelled calls aren't recorded at all.
+func countsAsFailure(err error) bool {
+	if err == nil || errors.Is(err, ErrNotFound) || errors.Is(err, ErrQuotaExhausted) {
+		return false
+	}
+	return true
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
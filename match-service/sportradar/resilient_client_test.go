@@ -0,0 +1,204 @@
+package sportradar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+)
+
+func TestIsRetryable(t *testing.T) {
+	timeout := &net.OpError{Op: "dial", Net: "tcp", Err: errTimeout{}}
+	for _, tc := range []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"network error", fmt.Errorf("request failed: %w", &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}), true},
+		{"timeout", fmt.Errorf("request failed: %w", timeout), true},
+		{"rate limited", &APIError{StatusCode: http.StatusTooManyRequests, kind: ErrRateLimited}, true},
+		{"server error", &APIError{StatusCode: http.StatusBadGateway, kind: ErrServerError}, true},
+		{"not found", &APIError{StatusCode: http.StatusNotFound, kind: ErrNotFound}, false},
+		{"unauthorized", &APIError{StatusCode: http.StatusUnauthorized, kind: ErrUnauthorized}, false},
+		{"decode error", fmt.Errorf("failed to decode Sportradar API response: %w", errors.New("unexpected end of JSON input")), false},
+		{"canceled", fmt.Errorf("request failed: %w", context.Canceled), false},
+	} {
+		if got := isRetryable(tc.err); got != tc.want {
+			t.Errorf("isRetryable(%s) = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+type errTimeout struct{}
+
+func (errTimeout) Error() string   { return "i/o timeout" }
+func (errTimeout) Timeout() bool   { return true }
+func (errTimeout) Temporary() bool { return true }
+
+// failingClient fails every FetchMatchData with err and counts the calls.
+type failingClient struct {
+	SportradarClientI
+	err   error
+	calls int
+}
+
+func (c *failingClient) FetchMatchData(context.Context, string) (*repository.Match, error) {
+	c.calls++
+	return nil, c.err
+}
+
+func TestResilientClientDoesNotRetryDecodeErrors(t *testing.T) {
+	next := &failingClient{err: errors.New("failed to decode Sportradar API response: invalid character")}
+	cfg := DefaultResilientConfig()
+	cfg.DailyQuota = 0
+	client := NewResilientClient(next, cfg)
+	client.sleep = func(context.Context, time.Duration) error { return nil }
+
+	if _, err := client.FetchMatchData(context.Background(), "sr:sport_event:1"); err == nil {
+		t.Fatal("expected the error of the wrapped client")
+	}
+	if next.calls != 1 {
+		t.Errorf("decode error tried %d times, want once", next.calls)
+	}
+}
+
+func TestResilientClientWithoutRateLimit(t *testing.T) {
+	for _, rate := range []float64{0, -1, math.NaN()} {
+		next := &failingClient{err: &APIError{StatusCode: http.StatusNotFound, kind: ErrNotFound}}
+		cfg := DefaultResilientConfig()
+		cfg.RequestsPerSecond = rate
+		client := NewResilientClient(next, cfg)
+		client.sleep = func(_ context.Context, d time.Duration) error {
+			t.Fatalf("rate %v: waited %v for a token", rate, d)
+			return nil
+		}
+
+		for i := 0; i < 10; i++ {
+			client.FetchMatchData(context.Background(), "sr:sport_event:1")
+		}
+		if next.calls != 10 {
+			t.Errorf("rate %v: %d calls went through, want 10", rate, next.calls)
+		}
+	}
+}
+
+// newTestResilientClient wraps next without rate limit or quota, with a clock the test moves.
+// Waits move the clock instead of sleeping, and are collected in the returned slice.
+func newTestResilientClient(next SportradarClientI, cfg ResilientConfig) (*ResilientClient, *time.Time, *[]time.Duration) {
+	client := NewResilientClient(next, cfg)
+	now := time.Date(2026, 5, 30, 18, 0, 0, 0, time.UTC)
+	waits := new([]time.Duration)
+	client.now = func() time.Time { return now }
+	client.sleep = func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		now = now.Add(d)
+		return nil
+	}
+	return client, &now, waits
+}
+
+func TestResilientClientBreaker(t *testing.T) {
+	next := &failingClient{err: &APIError{StatusCode: http.StatusBadGateway, kind: ErrServerError}}
+	cfg := DefaultResilientConfig()
+	cfg.RequestsPerSecond, cfg.DailyQuota, cfg.MaxRetries = 0, 0, 0
+	cfg.FailureThreshold, cfg.OpenTimeout = 2, 30*time.Second
+	client, now, _ := newTestResilientClient(next, cfg)
+	fetch := func() error {
+		_, err := client.FetchMatchData(context.Background(), "sr:sport_event:1")
+		return err
+	}
+	wantState := func(want BreakerState) {
+		t.Helper()
+		if got := client.Stats().BreakerState; got != want {
+			t.Fatalf("breaker is %s, want %s", got, want)
+		}
+	}
+
+	fetch()
+	wantState(BreakerClosed)
+	fetch()
+	wantState(BreakerOpen)
+	if err := fetch(); !errors.Is(err, ErrCircuitOpen) || next.calls != 2 {
+		t.Fatalf("call while open = %v after %d calls, want ErrCircuitOpen without calling", err, next.calls)
+	}
+
+	// A failed probe opens it again, for another OpenTimeout
+	*now = now.Add(cfg.OpenTimeout)
+	if err := fetch(); errors.Is(err, ErrCircuitOpen) || next.calls != 3 {
+		t.Fatalf("probe = %v after %d calls, want it to reach the provider", err, next.calls)
+	}
+	wantState(BreakerOpen)
+	*now = now.Add(cfg.OpenTimeout - time.Second)
+	if err := fetch(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("call before OpenTimeout = %v, want ErrCircuitOpen", err)
+	}
+
+	// A probe whose caller goes away decides nothing
+	*now = now.Add(time.Second)
+	next.err = fmt.Errorf("request failed: %w", context.Canceled)
+	fetch()
+	wantState(BreakerHalfOpen)
+
+	// The next probe is let through, and closes it when the provider answers
+	next.err = nil
+	if err := fetch(); err != nil {
+		t.Fatalf("probe = %v, want it to succeed", err)
+	}
+	wantState(BreakerClosed)
+	if stats := client.Stats(); stats.ConsecutiveFailures != 0 || stats.RejectedByBreaker != 2 {
+		t.Errorf("stats = %+v, want no failures and 2 rejected calls", stats)
+	}
+}
+
+func TestResilientClientHonoursRetryAfter(t *testing.T) {
+	next := &failingClient{err: &APIError{StatusCode: http.StatusTooManyRequests, kind: ErrRateLimited, RetryAfter: 7 * time.Second}}
+	cfg := DefaultResilientConfig()
+	cfg.RequestsPerSecond, cfg.DailyQuota, cfg.MaxRetries = 0, 0, 2
+	client, _, waits := newTestResilientClient(next, cfg)
+
+	if _, err := client.FetchMatchData(context.Background(), "sr:sport_event:1"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("FetchMatchData() = %v, want ErrRateLimited", err)
+	}
+	if next.calls != 3 || len(*waits) != 2 {
+		t.Fatalf("%d calls and waits %v, want 3 calls", next.calls, *waits)
+	}
+	for _, wait := range *waits {
+		if wait != 7*time.Second {
+			t.Errorf("waited %v before retrying, want the 7s of Retry-After", wait)
+		}
+	}
+}
+
+func TestResilientClientQuotaRollsOverAtUTCMidnight(t *testing.T) {
+	next := &failingClient{}
+	cfg := DefaultResilientConfig()
+	cfg.RequestsPerSecond, cfg.DailyQuota = 0, 2
+	client, now, _ := newTestResilientClient(next, cfg)
+	*now = time.Date(2026, 5, 31, 1, 59, 0, 0, time.FixedZone("CEST", 2*60*60)) // 23:59 UTC
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.FetchMatchData(context.Background(), "sr:sport_event:1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.FetchMatchData(context.Background(), "sr:sport_event:1"); !errors.Is(err, ErrQuotaExhausted) || next.calls != 2 {
+		t.Fatalf("call over quota = %v after %d calls, want ErrQuotaExhausted without calling", err, next.calls)
+	}
+	if stats := client.Stats(); stats.BreakerState != BreakerClosed {
+		t.Errorf("breaker is %s after running out of quota, want closed", stats.BreakerState)
+	}
+
+	*now = now.Add(2 * time.Minute) // 00:01 UTC
+	if _, err := client.FetchMatchData(context.Background(), "sr:sport_event:1"); err != nil {
+		t.Fatalf("call on the next UTC day = %v", err)
+	}
+	if stats := client.Stats(); stats.QuotaDay != "2026-05-31" || stats.QuotaUsed != 1 {
+		t.Errorf("quota day %s with %d used, want 2026-05-31 with 1", stats.QuotaDay, stats.QuotaUsed)
+	}
+}
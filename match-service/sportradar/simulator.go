@@ -0,0 +1,611 @@
+package sportradar
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+)
+
+// SimulatedCompetitionID is the competition every simulated match belongs to.
+const SimulatedCompetitionID = "sr:competition:simulated"
+
+const halfTimeBreak = 15 * time.Minute
+
+// Simulator generates plausible football matches (kick-off, shots, goals, fouls, cards,
+// substitutions, half-time, full-time) and serves them like Sportradar would. Every match
+// is scripted up front from the seed and match ID, so the same seed always produces the
+// same match, and its state at any moment only depends on the clock. One simulated match
+// minute takes one minute of clock time; use AcceleratedClock or ManualClock to speed it up.
+//
+// Simulator implements SportradarClientI, and Handler exposes it as a fake Sportradar HTTP API.
+type Simulator struct {
+	seed int64
+	now  func() time.Time
+
+	mu      sync.RWMutex
+	matches map[string]*simulatedMatch
+}
+
+// simulatedMatch is the pre-generated script of a single match.
+type simulatedMatch struct {
+	id             string
+	home, away     string
+	kickOff        time.Time
+	firstHalf      time.Duration // Including stoppage time
+	secondHalf     time.Duration // Including stoppage time
+	possessionBias int32         // Home possession above/below 50%
+	events         []simulatedEvent
+}
+
+type simulatedEvent struct {
+	offset time.Duration // Clock time since kick-off
+	event  SportradarTimelineEvent
+}
+
+// NewSimulator creates a simulator driven by the given clock (time.Now for real time).
+func NewSimulator(seed int64, now func() time.Time) *Simulator {
+	return &Simulator{
+		seed:    seed,
+		now:     now,
+		matches: make(map[string]*simulatedMatch),
+	}
+}
+
+// AddMatch schedules a match. Adding an existing match ID replaces it.
+func (s *Simulator) AddMatch(matchID, homeTeam, awayTeam string, kickOff time.Time) {
+	h := fnv.New64a()
+	h.Write([]byte(matchID))
+	rng := rand.New(rand.NewSource(s.seed ^ int64(h.Sum64())))
+
+	m := &simulatedMatch{
+		id:             matchID,
+		home:           homeTeam,
+		away:           awayTeam,
+		kickOff:        kickOff,
+		firstHalf:      time.Duration(45+1+rng.Intn(3)) * time.Minute,
+		secondHalf:     time.Duration(45+2+rng.Intn(5)) * time.Minute,
+		possessionBias: int32(rng.Intn(17) - 8),
+	}
+	m.generate(rng)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.matches[matchID] = m
+}
+
+// GenerateFixtures schedules n matches between teams of a fixed pool, the first kicking off
+// at firstKickOff and every following one spacing later. Useful for demos and load tests.
+// It returns the generated match IDs.
+func (s *Simulator) GenerateFixtures(n int, firstKickOff time.Time, spacing time.Duration) []string {
+	ids := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		home := simulatedTeams[(2*i)%len(simulatedTeams)]
+		away := simulatedTeams[(2*i+1)%len(simulatedTeams)]
+		id := fmt.Sprintf("sim-match-%03d", i+1)
+		s.AddMatch(id, home, away, firstKickOff.Add(time.Duration(i)*spacing))
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// FetchMatchData implements SportradarClientI.
+func (s *Simulator) FetchMatchData(ctx context.Context, matchID string) (*repository.Match, error) {
+	resp, err := s.response(matchID)
+	if err != nil {
+		return nil, err
+	}
+	return resp.toMatch(), nil
+}
+
+// FetchLiveMatches implements SportradarClientI.
+func (s *Simulator) FetchLiveMatches(ctx context.Context) ([]*repository.Match, error) {
+	var matches []*repository.Match
+	for _, resp := range s.liveSummaries() {
+		matches = append(matches, resp.toMatch())
+	}
+	return matches, nil
+}
+
+// FetchSchedule implements SportradarClientI.
+func (s *Simulator) FetchSchedule(ctx context.Context, date time.Time) ([]*repository.Match, error) {
+	var matches []*repository.Match
+	for _, resp := range s.scheduleSummaries(date) {
+		matches = append(matches, resp.toMatch())
+	}
+	return matches, nil
+}
+
+// FetchMatchTimeline implements SportradarClientI.
+func (s *Simulator) FetchMatchTimeline(ctx context.Context, matchID string) ([]*repository.Event, error) {
+	resp, err := s.response(matchID)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*repository.Event, 0, len(resp.Timeline))
+	for i := range resp.Timeline {
+		events = append(events, resp.Timeline[i].toEvent(matchID))
+	}
+	return events, nil
+}
+
+// FetchLineups implements SportradarClientI.
+func (s *Simulator) FetchLineups(ctx context.Context, matchID string) (*Lineups, error) {
+	resp, err := s.lineupsResponse(matchID)
+	if err != nil {
+		return nil, err
+	}
+	lineups := &Lineups{MatchID: matchID}
+	for _, competitor := range resp.Lineups.Competitors {
+		if competitor.Qualifier == "home" {
+			lineups.Home = competitor.toTeamLineup()
+		} else {
+			lineups.Away = competitor.toTeamLineup()
+		}
+	}
+	return lineups, nil
+}
+
+// FetchStandings implements SportradarClientI. The table is computed from the
+// finished simulated matches; the only known competition is SimulatedCompetitionID.
+func (s *Simulator) FetchStandings(ctx context.Context, competitionID string) ([]*Standing, error) {
+	resp, err := s.standingsResponse(competitionID)
+	if err != nil {
+		return nil, err
+	}
+	var standings []*Standing
+	for _, row := range resp.Standings[0].Groups[0].Standings {
+		standings = append(standings, &Standing{
+			Rank:         row.Rank,
+			TeamID:       row.Competitor.ID,
+			TeamName:     row.Competitor.Name,
+			Played:       row.Played,
+			Won:          row.Win,
+			Drawn:        row.Draw,
+			Lost:         row.Loss,
+			GoalsFor:     row.GoalsFor,
+			GoalsAgainst: row.GoalsAgainst,
+			Points:       row.Points,
+		})
+	}
+	return standings, nil
+}
+
+// response renders a match as of the current clock time, in the shape of the timeline endpoint.
+func (s *Simulator) response(matchID string) (*SportradarMatchResponse, error) {
+	s.mu.RLock()
+	m, ok := s.matches[matchID]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("match %s not found in simulator: %w", matchID, ErrNotFound)
+	}
+	return m.at(s.now()), nil
+}
+
+func (s *Simulator) liveSummaries() []SportradarMatchResponse {
+	var summaries []SportradarMatchResponse
+	for _, resp := range s.allResponses() {
+		if resp.SportEventStatus.Status == "live" {
+			summaries = append(summaries, resp.summary())
+		}
+	}
+	return summaries
+}
+
+func (s *Simulator) scheduleSummaries(date time.Time) []SportradarMatchResponse {
+	day := date.UTC().Format("2006-01-02")
+	var summaries []SportradarMatchResponse
+	for _, resp := range s.allResponses() {
+		if strings.HasPrefix(resp.SportEvent.StartTime, day) {
+			summaries = append(summaries, resp.summary())
+		}
+	}
+	return summaries
+}
+
+// allResponses renders every match as of now, ordered by kick-off.
+func (s *Simulator) allResponses() []*SportradarMatchResponse {
+	now := s.now()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	responses := make([]*SportradarMatchResponse, 0, len(s.matches))
+	for _, m := range s.matches {
+		responses = append(responses, m.at(now))
+	}
+	sort.Slice(responses, func(i, j int) bool {
+		if responses[i].SportEvent.StartTime != responses[j].SportEvent.StartTime {
+			return responses[i].SportEvent.StartTime < responses[j].SportEvent.StartTime
+		}
+		return responses[i].SportEvent.ID < responses[j].SportEvent.ID
+	})
+	return responses
+}
+
+func (s *Simulator) lineupsResponse(matchID string) (*sportradarLineupsResponse, error) {
+	s.mu.RLock()
+	m, ok := s.matches[matchID]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("match %s not found in simulator: %w", matchID, ErrNotFound)
+	}
+
+	resp := &sportradarLineupsResponse{}
+	for _, side := range []string{"home", "away"} {
+		team := m.team(side)
+		competitor := sportradarLineupCompetitor{Name: team, Qualifier: side}
+		competitor.Formation.Type = "4-3-3"
+		for number := int32(1); number <= squadSize; number++ {
+			competitor.Players = append(competitor.Players, sportradarLineupPlayer{
+				ID:           playerID(team, number),
+				Name:         playerName(team, number),
+				JerseyNumber: number,
+				Position:     playerPosition(number),
+				Starter:      number <= 11,
+			})
+		}
+		resp.Lineups.Competitors = append(resp.Lineups.Competitors, competitor)
+	}
+	return resp, nil
+}
+
+func (s *Simulator) standingsResponse(competitionID string) (*sportradarStandingsResponse, error) {
+	if competitionID != SimulatedCompetitionID {
+		return nil, fmt.Errorf("competition %s not found in simulator: %w", competitionID, ErrNotFound)
+	}
+
+	rows := make(map[string]*sportradarStandingRow)
+	row := func(team string) *sportradarStandingRow {
+		if rows[team] == nil {
+			rows[team] = &sportradarStandingRow{Competitor: SportradarCompetitor{ID: teamID(team), Name: team}}
+		}
+		return rows[team]
+	}
+	for _, resp := range s.allResponses() {
+		if resp.SportEventStatus.Status != "closed" {
+			continue
+		}
+		home, away := row(resp.SportEvent.Competitors[0].Name), row(resp.SportEvent.Competitors[1].Name)
+		homeGoals, awayGoals := resp.SportEventStatus.HomeScore, resp.SportEventStatus.AwayScore
+		home.Played++
+		away.Played++
+		home.GoalsFor, home.GoalsAgainst = home.GoalsFor+homeGoals, home.GoalsAgainst+awayGoals
+		away.GoalsFor, away.GoalsAgainst = away.GoalsFor+awayGoals, away.GoalsAgainst+homeGoals
+		switch {
+		case homeGoals > awayGoals:
+			home.Win, home.Points, away.Loss = home.Win+1, home.Points+3, away.Loss+1
+		case homeGoals < awayGoals:
+			away.Win, away.Points, home.Loss = away.Win+1, away.Points+3, home.Loss+1
+		default:
+			home.Draw, home.Points, away.Draw, away.Points = home.Draw+1, home.Points+1, away.Draw+1, away.Points+1
+		}
+	}
+
+	group := sportradarStandingsGroup{Name: "Simulated League"}
+	for _, r := range rows {
+		group.Standings = append(group.Standings, *r)
+	}
+	sort.Slice(group.Standings, func(i, j int) bool {
+		a, b := group.Standings[i], group.Standings[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.GoalsFor-a.GoalsAgainst != b.GoalsFor-b.GoalsAgainst {
+			return a.GoalsFor-a.GoalsAgainst > b.GoalsFor-b.GoalsAgainst
+		}
+		return a.Competitor.Name < b.Competitor.Name
+	})
+	for i := range group.Standings {
+		group.Standings[i].Rank = int32(i + 1)
+	}
+	return &sportradarStandingsResponse{
+		Standings: []sportradarStandingsTable{{Type: "total", Groups: []sportradarStandingsGroup{group}}},
+	}, nil
+}
+
+// generate scripts the whole match. Probabilities are per team and match minute and
+// give roughly 12 shots, 2-3 goals, 12 fouls and 2 yellow cards per team.
+func (m *simulatedMatch) generate(rng *rand.Rand) {
+	secondHalfStart := m.firstHalf + halfTimeBreak
+	m.add(0, 0, 0, SportradarTimelineEvent{Type: "match_started"})
+
+	onPitch := map[string][]int32{"home": startingEleven(), "away": startingEleven()}
+	bench := map[string][]int32{"home": substitutes(), "away": substitutes()}
+	booked := map[string]bool{}
+	subMinutes := map[string]map[int]bool{"home": pickSubMinutes(rng), "away": pickSubMinutes(rng)}
+
+	for half := 1; half <= 2; half++ {
+		length, start, firstMinute := m.firstHalf, time.Duration(0), 1
+		if half == 2 {
+			length, start, firstMinute = m.secondHalf, secondHalfStart, 46
+			m.add(start, 45, 0, SportradarTimelineEvent{Type: "period_start"})
+		}
+		minutes := int(length / time.Minute)
+		for i := 0; i < minutes; i++ {
+			minute := firstMinute + i
+			matchTime, stoppage := int32(minute), int32(0)
+			if regular := int32(45 * half); matchTime > regular {
+				matchTime, stoppage = regular, int32(minute)-regular
+			}
+			for _, side := range []string{"home", "away"} {
+				offset := start + time.Duration(i)*time.Minute + time.Duration(rng.Intn(60))*time.Second
+				outfielder := func() int32 {
+					return onPitch[side][1+rng.Intn(len(onPitch[side])-1)] // Never the goalkeeper
+				}
+
+				if rng.Float64() < 0.12 {
+					shooter := m.player(side, outfielder(), "")
+					switch roll := rng.Float64(); {
+					case roll < 0.35*0.3:
+						shooter.Type = "scorer"
+						m.add(offset, matchTime, stoppage, SportradarTimelineEvent{Type: "score_change", Competitor: side, Players: []SportradarEventPlayer{shooter}})
+					case roll < 0.35:
+						m.add(offset, matchTime, stoppage, SportradarTimelineEvent{Type: "shot_on_target", Competitor: side, Players: []SportradarEventPlayer{shooter}})
+					default:
+						m.add(offset, matchTime, stoppage, SportradarTimelineEvent{Type: "shot_off_target", Competitor: side, Players: []SportradarEventPlayer{shooter}})
+					}
+				}
+
+				if rng.Float64() < 0.13 {
+					number := outfielder()
+					offender := m.player(side, number, "")
+					m.add(offset+time.Second, matchTime, stoppage, SportradarTimelineEvent{Type: "foul", Competitor: side, Players: []SportradarEventPlayer{offender}})
+					cardType := ""
+					switch roll := rng.Float64(); {
+					case roll < 0.01:
+						cardType = "red_card"
+					case roll < 0.16:
+						cardType = "yellow_card"
+						if booked[offender.ID] {
+							cardType = "yellow_red_card"
+						}
+						booked[offender.ID] = true
+					}
+					if cardType != "" {
+						m.add(offset+2*time.Second, matchTime, stoppage, SportradarTimelineEvent{Type: cardType, Competitor: side, Players: []SportradarEventPlayer{offender}})
+						if cardType != "yellow_card" && len(onPitch[side]) > 7 {
+							onPitch[side] = removeNumber(onPitch[side], number)
+						}
+					}
+				}
+
+				if subMinutes[side][minute] && len(bench[side]) > 0 && len(onPitch[side]) > 1 {
+					out := onPitch[side][1+rng.Intn(len(onPitch[side])-1)]
+					in := bench[side][0]
+					bench[side] = bench[side][1:]
+					onPitch[side] = append(removeNumber(onPitch[side], out), in)
+					m.add(offset+3*time.Second, matchTime, stoppage, SportradarTimelineEvent{
+						Type: "substitution", Competitor: side,
+						Players: []SportradarEventPlayer{m.player(side, in, "substituted_in"), m.player(side, out, "substituted_out")},
+					})
+				}
+			}
+		}
+		if half == 1 {
+			m.add(m.firstHalf, 45, 0, SportradarTimelineEvent{Type: "break_start"})
+		}
+	}
+	m.add(secondHalfStart+m.secondHalf, 90, 0, SportradarTimelineEvent{Type: "match_ended"})
+
+	sort.SliceStable(m.events, func(i, j int) bool { return m.events[i].offset < m.events[j].offset })
+	for i := range m.events {
+		m.events[i].event.ID = int64(i + 1)
+	}
+	// Running scores are assigned after sorting, as both teams' events of a minute interleave.
+	var homeScore, awayScore int32
+	for i := range m.events {
+		event := &m.events[i].event
+		if event.Type == "score_change" {
+			if event.Competitor == "home" {
+				homeScore++
+			} else {
+				awayScore++
+			}
+			event.HomeScore, event.AwayScore = homeScore, awayScore
+		}
+	}
+}
+
+func (m *simulatedMatch) add(offset time.Duration, matchTime, stoppage int32, event SportradarTimelineEvent) {
+	event.Time = m.kickOff.Add(offset).UTC().Format(time.RFC3339)
+	event.MatchTime = matchTime
+	event.StoppageTime = stoppage
+	m.events = append(m.events, simulatedEvent{offset: offset, event: event})
+}
+
+// at renders the match as Sportradar would report it at the given time.
+func (m *simulatedMatch) at(now time.Time) *SportradarMatchResponse {
+	resp := &SportradarMatchResponse{}
+	resp.SportEvent.ID = m.id
+	resp.SportEvent.StartTime = m.kickOff.UTC().Format(time.RFC3339)
+	resp.SportEvent.SportEventContext.Sport.Name = "Soccer"
+	resp.SportEvent.SportEventContext.Competition.ID = SimulatedCompetitionID
+	resp.SportEvent.SportEventContext.Competition.Name = "Simulated League"
+	resp.SportEvent.Competitors = []SportradarCompetitor{
+		{ID: teamID(m.home), Name: m.home, Qualifier: "home"},
+		{ID: teamID(m.away), Name: m.away, Qualifier: "away"},
+	}
+
+	elapsed := now.Sub(m.kickOff)
+	secondHalfStart := m.firstHalf + halfTimeBreak
+	status := &resp.SportEventStatus
+	switch {
+	case elapsed < 0:
+		status.Status = "not_started"
+		return resp // No statistics or timeline before kick-off
+	case elapsed < m.firstHalf:
+		status.Status, status.MatchStatus = "live", "1st_half"
+	case elapsed < secondHalfStart:
+		status.Status, status.MatchStatus = "live", "halftime"
+	case elapsed < secondHalfStart+m.secondHalf:
+		status.Status, status.MatchStatus = "live", "2nd_half"
+	default:
+		status.Status, status.MatchStatus = "closed", "ended"
+	}
+
+	stats := map[string]*SportradarStatistics{"home": {}, "away": {}}
+	for _, e := range m.events {
+		if e.offset > elapsed {
+			break
+		}
+		event := e.event
+		resp.Timeline = append(resp.Timeline, event)
+		teamStats := stats[event.Competitor]
+		switch event.Type {
+		case "score_change":
+			status.HomeScore, status.AwayScore = event.HomeScore, event.AwayScore
+			teamStats.ShotsTotal++
+		case "shot_on_target", "shot_off_target":
+			teamStats.ShotsTotal++
+		case "foul":
+			teamStats.Fouls++
+		case "yellow_card":
+			teamStats.YellowCards++
+		case "red_card", "yellow_red_card":
+			teamStats.RedCards++
+		}
+	}
+
+	// Possession drifts slowly around the match's bias.
+	minute := math.Min(elapsed.Minutes(), (secondHalfStart + m.secondHalf).Minutes())
+	homePossession := 50 + m.possessionBias + int32(math.Round(4*math.Sin(minute/7)))
+	stats["home"].BallPossession = homePossession
+	stats["away"].BallPossession = 100 - homePossession
+	resp.Statistics.Totals.Competitors = []SportradarTeamStatistics{
+		{Qualifier: "home", Statistics: *stats["home"]},
+		{Qualifier: "away", Statistics: *stats["away"]},
+	}
+	return resp
+}
+
+// summary drops the timeline, like Sportradar's summaries endpoints do.
+func (r *SportradarMatchResponse) summary() SportradarMatchResponse {
+	summary := *r
+	summary.Timeline = nil
+	return summary
+}
+
+func (m *simulatedMatch) team(side string) string {
+	if side == "home" {
+		return m.home
+	}
+	return m.away
+}
+
+func (m *simulatedMatch) player(side string, number int32, role string) SportradarEventPlayer {
+	team := m.team(side)
+	return SportradarEventPlayer{ID: playerID(team, number), Name: playerName(team, number), Type: role}
+}
+
+const squadSize = 18 // Starting eleven plus seven substitutes
+
+var simulatedTeams = []string{
+	"Real Madrid", "Barcelona", "Atletico Madrid", "Sevilla", "Valencia", "Villarreal",
+	"Real Sociedad", "Athletic Club", "Real Betis", "Celta Vigo", "Osasuna", "Getafe",
+	"Girona", "Mallorca", "Rayo Vallecano", "Las Palmas", "Alaves", "Espanyol",
+	"Valladolid", "Leganes",
+}
+
+var simulatedSurnames = []string{
+	"Garcia", "Fernandez", "Gonzalez", "Rodriguez", "Lopez", "Martinez", "Sanchez", "Perez",
+	"Gomez", "Martin", "Jimenez", "Ruiz", "Hernandez", "Diaz", "Moreno", "Alvarez", "Munoz",
+	"Romero", "Alonso", "Gutierrez", "Navarro", "Torres", "Dominguez", "Vazquez", "Ramos",
+	"Gil", "Ramirez", "Serrano", "Blanco", "Molina", "Morales", "Suarez", "Ortega",
+}
+
+func teamID(team string) string {
+	return "sr:competitor:" + strings.ToLower(strings.ReplaceAll(team, " ", "_"))
+}
+
+func playerID(team string, number int32) string {
+	return fmt.Sprintf("sr:player:%s_%d", strings.ToLower(strings.ReplaceAll(team, " ", "_")), number)
+}
+
+// playerName deterministically picks a surname for a team's shirt number.
+func playerName(team string, number int32) string {
+	h := fnv.New32a()
+	h.Write([]byte(team))
+	return simulatedSurnames[(h.Sum32()+uint32(number)*7)%uint32(len(simulatedSurnames))]
+}
+
+func playerPosition(number int32) string {
+	switch {
+	case number == 1 || number == 12:
+		return "goalkeeper"
+	case number <= 5 || number == 13 || number == 14:
+		return "defender"
+	case number <= 8 || number == 15 || number == 16:
+		return "midfielder"
+	default:
+		return "forward"
+	}
+}
+
+func startingEleven() []int32 {
+	return []int32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11} // Goalkeeper first
+}
+
+func substitutes() []int32 {
+	return []int32{13, 14, 15, 16, 17, 18} // 12 is the backup goalkeeper and stays on the bench
+}
+
+// pickSubMinutes picks three to five second-half minutes for a team's substitutions.
+func pickSubMinutes(rng *rand.Rand) map[int]bool {
+	minutes := make(map[int]bool)
+	for n := 3 + rng.Intn(3); len(minutes) < n; {
+		minutes[55+rng.Intn(34)] = true
+	}
+	return minutes
+}
+
+func removeNumber(numbers []int32, number int32) []int32 {
+	out := make([]int32, 0, len(numbers))
+	for _, n := range numbers {
+		if n != number {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// ManualClock is a clock that only moves when told to. Pass its Now method to
+// NewSimulator or ReplayClient.SetClock in tests.
+type ManualClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+// NewManualClock creates a ManualClock set to t.
+func NewManualClock(t time.Time) *ManualClock {
+	return &ManualClock{t: t}
+}
+
+// Now returns the clock's current time.
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+// Advance moves the clock forward by d.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+// AcceleratedClock returns a clock that starts at the current time and runs speed times
+// faster than real time, e.g. 90 plays a whole match in about a minute.
+func AcceleratedClock(speed float64) func() time.Time {
+	start := time.Now()
+	return func() time.Time {
+		return start.Add(time.Duration(float64(time.Since(start)) * speed))
+	}
+}
@@ -0,0 +1,96 @@
+package sportradar
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/abaika-abay/live_sports_project/match-service/repository"
+)
+
+var testKickOff = time.Date(2026, 5, 30, 18, 0, 0, 0, time.UTC)
+
+// simulateMatch plays match m-1 of a simulator with the given seed, calling observe with its
+// state every minute from before kick-off until well after full-time.
+func simulateMatch(t *testing.T, seed int64, observe func(match *repository.Match, timeline []*repository.Event)) {
+	t.Helper()
+	ctx := context.Background()
+	clock := NewManualClock(testKickOff.Add(-5 * time.Minute))
+	simulator := NewSimulator(seed, clock.Now)
+	simulator.AddMatch("m-1", "Home FC", "Away FC", testKickOff)
+	for minute := 0; minute < 150; minute++ {
+		match, err := simulator.FetchMatchData(ctx, "m-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		timeline, err := simulator.FetchMatchTimeline(ctx, "m-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		observe(match, timeline)
+		clock.Advance(time.Minute)
+	}
+}
+
+func TestSimulatorIsDeterministic(t *testing.T) {
+	type state struct {
+		match    *repository.Match
+		timeline []*repository.Event
+	}
+	play := func(seed int64) []state {
+		var states []state
+		simulateMatch(t, seed, func(match *repository.Match, timeline []*repository.Event) {
+			states = append(states, state{match, timeline})
+		})
+		return states
+	}
+
+	first, again := play(7), play(7)
+	for i := range first {
+		if !reflect.DeepEqual(first[i], again[i]) {
+			t.Fatalf("minute %d differs with the same seed and clock:\n%+v\n%+v", i, first[i].match, again[i].match)
+		}
+	}
+	if other := play(8); reflect.DeepEqual(first[len(first)-1], other[len(other)-1]) {
+		t.Error("another seed played the same match")
+	}
+}
+
+func TestSimulatorScoresNeverDecrease(t *testing.T) {
+	var previous *repository.Match
+	var goals int32
+	simulateMatch(t, 7, func(match *repository.Match, timeline []*repository.Event) {
+		if previous != nil && (match.HomeScore < previous.HomeScore || match.AwayScore < previous.AwayScore ||
+			match.Shots < previous.Shots || match.Fouls < previous.Fouls || len(match.Cards) < len(previous.Cards)) {
+			t.Fatalf("match went from %+v to %+v", previous, match)
+		}
+		previous = match
+		goals = 0
+		for _, event := range timeline {
+			if event.EventType == repository.EventGoal {
+				goals++
+			}
+		}
+	})
+	if goals == 0 || previous.HomeScore+previous.AwayScore != goals {
+		t.Errorf("final score %d-%d, but the timeline has %d goals", previous.HomeScore, previous.AwayScore, goals)
+	}
+	if previous.Shots == 0 || previous.Fouls == 0 {
+		t.Errorf("final match has %d shots and %d fouls, want some of each", previous.Shots, previous.Fouls)
+	}
+}
+
+func TestSimulatorPhaseOrder(t *testing.T) {
+	var statuses []string
+	simulateMatch(t, 7, func(match *repository.Match, _ []*repository.Event) {
+		if len(statuses) == 0 || statuses[len(statuses)-1] != match.Status {
+			statuses = append(statuses, match.Status)
+		}
+	})
+	want := []string{"not_started", "1st_half", "halftime", "2nd_half", "closed"}
+	if !slices.Equal(statuses, want) {
+		t.Errorf("statuses = %v, want %v", statuses, want)
+	}
+}
@@ -0,0 +1,39 @@
+package sportradar
+
+// Lineups holds the starting elevens and benches of both teams.
+type Lineups struct {
+	MatchID string
+	Home    TeamLineup
+	Away    TeamLineup
+}
+
+// TeamLineup is the lineup of a single team.
+type TeamLineup struct {
+	TeamName    string
+	Formation   string // e.g., "4-3-3"
+	Starters    []Player
+	Substitutes []Player
+}
+
+// Player is a player as listed in a lineup.
+type Player struct {
+	ID           string
+	Name         string
+	JerseyNumber int32
+	Position     string // e.g., "goalkeeper", "defender"
+}
+
+// Standing is a single row of a league table.
+type Standing struct {
+	Group        string // Empty for single-table competitions
+	Rank         int32
+	TeamID       string
+	TeamName     string
+	Played       int32
+	Won          int32
+	Drawn        int32
+	Lost         int32
+	GoalsFor     int32
+	GoalsAgainst int32
+	Points       int32
+}
@@ -0,0 +1,116 @@
+package wsclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// Client is a WebSocket connection to the match service that keeps match states with a Decoder.
+// Reads must happen from a single goroutine; writes are safe from any.
+type Client struct {
+	conn    *websocket.Conn
+	Decoder *Decoder
+
+	writeMu sync.Mutex
+	nextID  int
+}
+
+// Dial connects to the match service WebSocket endpoint, e.g. ws://localhost:8080/ws.
+// Pass a Decoder from a previous connection to keep its states across reconnects,
+// then call Resubscribe; nil starts empty.
+func Dial(url string, decoder *Decoder) (*Client, error) {
+	return dial(url, decoder, SubprotocolJSON)
+}
+
+// DialProtobuf is like Dial, but asks for updates in the protobuf encoding.
+func DialProtobuf(url string, decoder *Decoder) (*Client, error) {
+	return dial(url, decoder, SubprotocolProtobuf)
+}
+
+func dial(url string, decoder *Decoder, subprotocol string) (*Client, error) {
+	dialer := *websocket.DefaultDialer
+	dialer.Subprotocols = []string{subprotocol}
+	conn, _, err := dialer.Dial(url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", url, err)
+	}
+	if decoder == nil {
+		decoder = NewDecoder()
+	}
+	return &Client{conn: conn, Decoder: decoder}, nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Subscribe follows topics like "match:match-123" or "team:Barcelona". It returns the
+// request ID, echoed in the server's ack or error frame.
+func (c *Client) Subscribe(topics ...string) (string, error) {
+	return c.send(map[string]interface{}{"type": "subscribe", "topics": topics})
+}
+
+// Resubscribe follows topics and resumes every match the decoder knows about,
+// so updates missed while disconnected are replayed.
+func (c *Client) Resubscribe(topics ...string) (string, error) {
+	return c.send(map[string]interface{}{"type": "subscribe", "topics": topics, "resume_from": c.Decoder.ResumeFrom()})
+}
+
+// Unsubscribe stops following topics.
+func (c *Client) Unsubscribe(topics ...string) (string, error) {
+	return c.send(map[string]interface{}{"type": "unsubscribe", "topics": topics})
+}
+
+// Ping asks the server for a pong frame.
+func (c *Client) Ping() (string, error) {
+	return c.send(map[string]interface{}{"type": "ping"})
+}
+
+func (c *Client) send(msg map[string]interface{}) (string, error) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	c.nextID++
+	id := strconv.Itoa(c.nextID)
+	msg["id"] = id
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		return "", fmt.Errorf("failed to send %s: %w", msg["type"], err)
+	}
+	return id, nil
+}
+
+// Next reads the next frame and applies it to the decoder. On a gap it resubscribes to
+// the match with resume_from on its own and carries on with the next frame.
+func (c *Client) Next() (*Frame, error) {
+	for {
+		messageType, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return nil, err
+		}
+		if messageType == websocket.BinaryMessage {
+			return c.Decoder.DecodeBinary(data) // Protobuf states are whole, there are no gaps
+		}
+		frame, err := c.Decoder.Decode(data)
+		if errors.Is(err, ErrGap) {
+			if _, err := c.send(map[string]interface{}{
+				"type":        "subscribe",
+				"topics":      []string{"match:" + frame.MatchID},
+				"resume_from": map[string]int64{frame.MatchID: c.Decoder.Seq(frame.MatchID)},
+			}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		return frame, err
+	}
+}
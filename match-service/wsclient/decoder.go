@@ -0,0 +1,208 @@
+// Package wsclient is a reference client for the match service WebSocket protocol, used by
+// integration tests and as an example for app developers. Decoder keeps match states up to
+// date from snapshot and patch frames; Client adds the connection and subscriptions.
+package wsclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/abaika-abay/live_sports_project/match-service/proto"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// Frame types, mirroring the service package.
+const (
+	FrameSnapshot   = "snapshot"
+	FramePatch      = "patch"
+	FrameUpdate     = "update"
+	FrameCorrection = "correction" // Data says which event an admin amended or retracted
+	FrameViewers    = "viewers"
+	FrameAck        = "ack"
+	FramePong       = "pong"
+	FrameError      = "error"
+)
+
+// Subprotocols picking the encoding, mirroring the service package.
+const (
+	SubprotocolJSON     = "live-sports.v1.json"
+	SubprotocolProtobuf = "live-sports.v1.protobuf"
+)
+
+// ErrGap is returned when a patch doesn't follow the last seq seen for its match, so it
+// can't be applied. Resubscribe with ResumeFrom to catch up.
+var ErrGap = errors.New("missed updates")
+
+// Frame is any message sent by the server. Which fields are set depends on the type.
+type Frame struct {
+	Type    string          `json:"type"`
+	MatchID string          `json:"match_id,omitempty"`
+	Seq     int64           `json:"seq,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	ID      string          `json:"id,omitempty"`
+	Topics  []string        `json:"topics,omitempty"`
+	Code    string          `json:"code,omitempty"`
+	Message string          `json:"message,omitempty"`
+	Viewers int64           `json:"viewers,omitempty"` // On viewers frames
+}
+
+// Decoder applies snapshot and patch frames to the match states it tracks.
+// It is safe for concurrent use.
+type Decoder struct {
+	mu      sync.Mutex
+	matches map[string]*matchState
+}
+
+type matchState struct {
+	seq   int64
+	state map[string]interface{}
+}
+
+// NewDecoder creates an empty Decoder.
+func NewDecoder() *Decoder {
+	return &Decoder{matches: make(map[string]*matchState)}
+}
+
+// Decode parses a frame and, for snapshots and patches, applies it. Other frames with a seq,
+// like corrections, only move the seq of their match on. On ErrGap the frame is still
+// returned, and the match keeps its last good state.
+func (d *Decoder) Decode(data []byte) (*Frame, error) {
+	var frame Frame
+	if err := json.Unmarshal(data, &frame); err != nil {
+		return nil, fmt.Errorf("failed to decode frame: %w", err)
+	}
+
+	switch frame.Type {
+	case FrameSnapshot:
+		var state map[string]interface{}
+		if err := json.Unmarshal(frame.Data, &state); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot of match %s: %w", frame.MatchID, err)
+		}
+		d.mu.Lock()
+		d.matches[frame.MatchID] = &matchState{seq: frame.Seq, state: state}
+		d.mu.Unlock()
+	case FramePatch:
+		var patch map[string]interface{}
+		if err := json.Unmarshal(frame.Data, &patch); err != nil {
+			return nil, fmt.Errorf("failed to decode patch of match %s: %w", frame.MatchID, err)
+		}
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		match, ok := d.matches[frame.MatchID]
+		if !ok || match.seq != frame.Seq-1 {
+			return &frame, fmt.Errorf("patch %d of match %s: %w", frame.Seq, frame.MatchID, ErrGap)
+		}
+		match.state = ApplyMergePatch(match.state, patch)
+		match.seq = frame.Seq
+	default:
+		if frame.Seq == 0 {
+			break // Control replies and viewer counts aren't numbered
+		}
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		match, ok := d.matches[frame.MatchID]
+		if !ok || frame.Seq <= match.seq {
+			break // Nothing to move on, or the service restarted and its next state is a snapshot
+		}
+		if frame.Seq != match.seq+1 {
+			return &frame, fmt.Errorf("%s %d of match %s: %w", frame.Type, frame.Seq, frame.MatchID, ErrGap)
+		}
+		match.seq = frame.Seq
+	}
+	return &frame, nil
+}
+
+// DecodeBinary parses a binary frame of the protobuf encoding and, for snapshots, applies it.
+// Update data is returned as JSON, like in the JSON encoding.
+func (d *Decoder) DecodeBinary(data []byte) (*Frame, error) {
+	var message proto.UpdateFrame
+	if err := protobuf.Unmarshal(data, &message); err != nil {
+		return nil, fmt.Errorf("failed to decode frame: %w", err)
+	}
+	frame := &Frame{Type: message.Type, MatchID: message.MatchId, Seq: message.Seq, Data: message.Data, Viewers: message.Viewers}
+
+	if frame.Type == FrameSnapshot && message.State != nil {
+		// Kept as JSON like the states of the JSON encoding, so Match works the same
+		var err error
+		if frame.Data, err = json.Marshal(message.State); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot of match %s: %w", frame.MatchID, err)
+		}
+		var state map[string]interface{}
+		if err := json.Unmarshal(frame.Data, &state); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot of match %s: %w", frame.MatchID, err)
+		}
+		d.mu.Lock()
+		d.matches[frame.MatchID] = &matchState{seq: frame.Seq, state: state}
+		d.mu.Unlock()
+	} else if frame.Seq > 0 {
+		d.mu.Lock()
+		if match, ok := d.matches[frame.MatchID]; ok && frame.Seq > match.seq {
+			match.seq = frame.Seq // States are whole, so a missed frame doesn't matter
+		}
+		d.mu.Unlock()
+	}
+	return frame, nil
+}
+
+// Seq returns the last seq applied for a match, zero if none.
+func (d *Decoder) Seq(matchID string) int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if match, ok := d.matches[matchID]; ok {
+		return match.seq
+	}
+	return 0
+}
+
+// ResumeFrom returns the last seq of every tracked match, for resubscribing after a reconnect.
+func (d *Decoder) ResumeFrom() map[string]int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	resume := make(map[string]int64, len(d.matches))
+	for matchID, match := range d.matches {
+		resume[matchID] = match.seq
+	}
+	return resume
+}
+
+// Match returns the current state of a match, or false if the decoder has none.
+func (d *Decoder) Match(matchID string) (*proto.MatchResponse, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	match, ok := d.matches[matchID]
+	if !ok {
+		return nil, false
+	}
+	data, err := json.Marshal(match.state)
+	if err != nil {
+		return nil, false
+	}
+	var resp proto.MatchResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, false
+	}
+	return &resp, true
+}
+
+// ApplyMergePatch applies a JSON Merge Patch (RFC 7386) to target and returns the result.
+// target is modified in place.
+func ApplyMergePatch(target, patch map[string]interface{}) map[string]interface{} {
+	if target == nil {
+		target = make(map[string]interface{})
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			existing, _ := target[key].(map[string]interface{})
+			target[key] = ApplyMergePatch(existing, nested)
+			continue
+		}
+		target[key] = value
+	}
+	return target
+}
@@ -0,0 +1,60 @@
+package wsclient
+
+import (
+	"errors"
+	"testing"
+)
+
+// decode decodes a frame, failing the test unless it returns wantErr.
+func decode(t *testing.T, decoder *Decoder, frame string, wantErr error) {
+	t.Helper()
+	if _, err := decoder.Decode([]byte(frame)); !errors.Is(err, wantErr) {
+		t.Fatalf("Decode(%s) = %v, want %v", frame, err, wantErr)
+	}
+}
+
+func TestDecoderGap(t *testing.T) {
+	decoder := NewDecoder()
+	decode(t, decoder, `{"type":"snapshot","match_id":"m-1","seq":1,"data":{"match_id":"m-1","home_score":1,"fouls":2}}`, nil)
+	decode(t, decoder, `{"type":"patch","match_id":"m-1","seq":3,"data":{"home_score":3}}`, ErrGap)
+	if match, _ := decoder.Match("m-1"); match.HomeScore != 1 || decoder.Seq("m-1") != 1 {
+		t.Fatalf("state = %v at seq %d after a gap, want the last good one at seq 1", match, decoder.Seq("m-1"))
+	}
+	if resume := decoder.ResumeFrom(); resume["m-1"] != 1 {
+		t.Errorf("ResumeFrom() = %v, want m-1 from seq 1", resume)
+	}
+
+	// Resuming sends the missed frames, the patch again included
+	decode(t, decoder, `{"type":"patch","match_id":"m-1","seq":2,"data":{"home_score":2}}`, nil)
+	decode(t, decoder, `{"type":"patch","match_id":"m-1","seq":3,"data":{"home_score":3}}`, nil)
+	if match, _ := decoder.Match("m-1"); match.HomeScore != 3 || match.Fouls != 2 || decoder.Seq("m-1") != 3 {
+		t.Errorf("state = %v at seq %d, want 3 goals and 2 fouls at seq 3", match, decoder.Seq("m-1"))
+	}
+}
+
+func TestDecoderNumberedFramesMoveSeq(t *testing.T) {
+	decoder := NewDecoder()
+	decode(t, decoder, `{"type":"snapshot","match_id":"m-1","seq":1,"data":{"home_score":1}}`, nil)
+	decode(t, decoder, `{"type":"viewers","match_id":"m-1","viewers":10}`, nil)
+	decode(t, decoder, `{"type":"correction","match_id":"m-1","seq":2,"data":{"action":"retract"}}`, nil)
+	decode(t, decoder, `{"type":"patch","match_id":"m-1","seq":3,"data":{"home_score":0}}`, nil)
+	if match, _ := decoder.Match("m-1"); match.HomeScore != 0 || decoder.Seq("m-1") != 3 {
+		t.Errorf("state = %v at seq %d, want 0 goals at seq 3", match, decoder.Seq("m-1"))
+	}
+
+	decode(t, decoder, `{"type":"update","match_id":"m-1","seq":5,"data":{}}`, ErrGap)
+	if decoder.Seq("m-1") != 3 {
+		t.Errorf("seq = %d after a gap, want 3", decoder.Seq("m-1"))
+	}
+}
+
+// TestDecoderSnapshotAfterRestart takes a snapshot with a lower seq, from a service that
+// restarted, in place of the state it had.
+func TestDecoderSnapshotAfterRestart(t *testing.T) {
+	decoder := NewDecoder()
+	decode(t, decoder, `{"type":"snapshot","match_id":"m-1","seq":10,"data":{"home_score":4}}`, nil)
+	decode(t, decoder, `{"type":"snapshot","match_id":"m-1","seq":2,"data":{"home_score":2}}`, nil)
+	if match, _ := decoder.Match("m-1"); match.HomeScore != 2 || decoder.Seq("m-1") != 2 {
+		t.Errorf("state = %v at seq %d, want the restarted service's at seq 2", match, decoder.Seq("m-1"))
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"net"
 
+	"github.com/abaika-abay/live_sports_project/common/pkg/auth"
 	"github.com/abaika-abay/live_sports_project/common/pkg/config"
 	"github.com/abaika-abay/live_sports_project/common/pkg/db"
 	"github.com/abaika-abay/live_sports_project/common/pkg/logger"
@@ -29,8 +30,14 @@ func main() {
 	}
 	defer mongoDB.Disconnect(context.Background())
 
+	// Login tokens are checked by match-service with the same secret
+	tokens, err := auth.NewSigner(cfg.AuthTokenSecret)
+	if err != nil {
+		log.Fatalf("Failed to set up login tokens: %v", err)
+	}
+
 	// Initialize user service
-	userService := service.NewUserService(mongoDB.Database) // Use Database field directly
+	userService := service.NewUserService(mongoDB.Database, tokens, cfg.AuthTokenTTL) // Use Database field directly
 
 	// Start gRPC server
 	lis, err := net.Listen("tcp", cfg.Server.Port)
@@ -6,6 +6,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"time"
 
+	"github.com/abaika-abay/live_sports_project/common/pkg/auth"
 	pb "github.com/abaika-abay/live_sports_project/user-service/proto"
 	"github.com/abaika-abay/live_sports_project/user-service/repository"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -15,12 +16,16 @@ import (
 
 type UserService struct {
 	pb.UnimplementedUserServiceServer
-	repo *repository.UserRepository
+	repo     *repository.UserRepository
+	tokens   *auth.Signer // Issues login tokens, checked by the other services
+	tokenTTL time.Duration
 }
 
-func NewUserService(db *mongo.Database) *UserService {
+func NewUserService(db *mongo.Database, tokens *auth.Signer, tokenTTL time.Duration) *UserService {
 	return &UserService{
-		repo: repository.NewUserRepository(db),
+		repo:     repository.NewUserRepository(db),
+		tokens:   tokens,
+		tokenTTL: tokenTTL,
 	}
 }
 func (s *UserService) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
@@ -68,14 +73,18 @@ func (s *UserService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Logi
 		return nil, status.Errorf(codes.Unauthenticated, "invalid credentials")
 	}
 
-	// Simple token (in production, use JWT)
-	token := primitive.NewObjectID().Hex()
+	// Signed token, so other services can check it without asking us
+	token, claims, err := s.tokens.Issue(user.UserID, s.tokenTTL)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to issue token: %v", err)
+	}
 
 	return &pb.LoginResponse{
-		UserId:  user.UserID,
-		Token:   token,
-		Success: true,
-		Message: "Login successful",
+		UserId:    user.UserID,
+		Token:     token,
+		Success:   true,
+		Message:   "Login successful",
+		ExpiresAt: claims.Expiry().UTC().Format(time.RFC3339),
 	}, nil
 }
 